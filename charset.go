@@ -0,0 +1,144 @@
+package csvlib
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// windows1252Runes runes of the bytes from 0x80 to 0x9F in Windows-1252, the other bytes have the same
+// values as their code points. Undefined bytes are mapped to the control characters of the same values.
+var windows1252Runes = [32]rune{
+	'€', '\u0081', '‚', 'ƒ', '„', '…', '†', '‡',
+	'ˆ', '‰', 'Š', '‹', 'Œ', '\u008D', 'Ž', '\u008F',
+	'\u0090', '‘', '’', '“', '”', '•', '–', '—',
+	'˜', '™', 'š', '›', 'œ', '\u009D', 'ž', 'Ÿ',
+}
+
+// charsetRuneDecoder function to read the next rune from the source data in a specific charset
+type charsetRuneDecoder func(src *bufio.Reader) (rune, error)
+
+// getCharsetRuneDecoder gets the rune decoder of the given charset. Returns `nil` for UTF-8
+// as the data need no conversion.
+func getCharsetRuneDecoder(charset string) (charsetRuneDecoder, error) {
+	name := strings.NewReplacer("-", "", "_", "").Replace(strings.ToLower(charset))
+	switch name {
+	case "", "utf8":
+		return nil, nil
+	case "utf16le":
+		return decodeUTF16Rune(false), nil
+	case "utf16be":
+		return decodeUTF16Rune(true), nil
+	case "windows1252", "cp1252":
+		return decodeWindows1252Rune, nil
+	case "iso88591", "latin1":
+		return decodeLatin1Rune, nil
+	}
+	return nil, fmt.Errorf("%w: SourceCharset \"%s\" unsupported", ErrConfigOptionInvalid, charset)
+}
+
+// newCharsetReader wraps the reader to convert the data in the given charset into UTF-8
+func newCharsetReader(r io.Reader, charset string) (io.Reader, error) {
+	decodeRune, err := getCharsetRuneDecoder(charset)
+	if err != nil {
+		return nil, err
+	}
+	if decodeRune == nil {
+		return r, nil
+	}
+	return &charsetReader{src: bufio.NewReader(r), decodeRune: decodeRune}, nil
+}
+
+// charsetReader reader converting the source data in a specific charset into UTF-8
+type charsetReader struct {
+	src        *bufio.Reader
+	decodeRune charsetRuneDecoder
+	pending    []byte
+	started    bool
+}
+
+func (r *charsetReader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if len(r.pending) > 0 {
+			copied := copy(p[n:], r.pending)
+			r.pending = r.pending[copied:]
+			n += copied
+			continue
+		}
+		// Return the converted data without waiting for more source data
+		if n > 0 && r.src.Buffered() == 0 {
+			break
+		}
+		ch, err := r.decodeRune(r.src)
+		if err != nil {
+			return n, err
+		}
+		// The byte order mark of UTF-16 data is not a part of the content
+		started := r.started
+		r.started = true
+		if !started && ch == '\uFEFF' {
+			continue
+		}
+		r.pending = utf8.AppendRune(r.pending[:0], ch)
+	}
+	return n, nil
+}
+
+func decodeLatin1Rune(src *bufio.Reader) (rune, error) {
+	b, err := src.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	return rune(b), nil
+}
+
+func decodeWindows1252Rune(src *bufio.Reader) (rune, error) {
+	b, err := src.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	if b >= 0x80 && b <= 0x9F {
+		return windows1252Runes[b-0x80], nil
+	}
+	return rune(b), nil
+}
+
+func decodeUTF16Rune(bigEndian bool) charsetRuneDecoder {
+	peekUnit := func(src *bufio.Reader) (rune, error) {
+		b, err := src.Peek(2) // nolint: mnd
+		if err != nil {
+			if len(b) > 0 && err == io.EOF { // nolint: errorlint
+				err = io.ErrUnexpectedEOF
+			}
+			return 0, err
+		}
+		if bigEndian {
+			return rune(b[0])<<8 | rune(b[1]), nil
+		}
+		return rune(b[1])<<8 | rune(b[0]), nil
+	}
+	return func(src *bufio.Reader) (rune, error) {
+		r1, err := peekUnit(src)
+		if err != nil {
+			return 0, err
+		}
+		_, _ = src.Discard(2) // nolint: mnd
+		if !utf16.IsSurrogate(r1) {
+			return r1, nil
+		}
+		// An unpaired surrogate is decoded as utf8.RuneError, the next unit is kept for the next rune
+		r2, err := peekUnit(src)
+		if err != nil {
+			return utf8.RuneError, nil
+		}
+		ch := utf16.DecodeRune(r1, r2)
+		if ch != utf8.RuneError {
+			_, _ = src.Discard(2) // nolint: mnd
+		}
+		return ch, nil
+	}
+}
@@ -0,0 +1,74 @@
+package csvlib
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func encodeUTF16(s string, bigEndian bool) []byte {
+	units := utf16.Encode([]rune(s))
+	data := make([]byte, 0, len(units)*2)
+	for _, u := range units {
+		if bigEndian {
+			data = append(data, byte(u>>8), byte(u))
+		} else {
+			data = append(data, byte(u), byte(u>>8))
+		}
+	}
+	return data
+}
+
+func Test_newCharsetReader(t *testing.T) {
+	readAll := func(data []byte, charset string) (string, error) {
+		r, err := newCharsetReader(bytes.NewReader(data), charset)
+		if err != nil {
+			return "", err
+		}
+		b, err := io.ReadAll(r)
+		return string(b), err
+	}
+
+	t.Run("#1: utf-8", func(t *testing.T) {
+		src := strings.NewReader("abc")
+		r, err := newCharsetReader(src, "UTF-8")
+		assert.Nil(t, err)
+		assert.Equal(t, src, r)
+	})
+
+	t.Run("#2: utf-16", func(t *testing.T) {
+		s, err := readAll(encodeUTF16("\uFEFFnăm,😀\n1,2", false), "utf-16le")
+		assert.Nil(t, err)
+		assert.Equal(t, "năm,😀\n1,2", s)
+
+		s, err = readAll(encodeUTF16("năm,😀\n1,2", true), "UTF16BE")
+		assert.Nil(t, err)
+		assert.Equal(t, "năm,😀\n1,2", s)
+
+		// Unpaired surrogate and odd number of bytes
+		s, err = readAll([]byte{0x3D, 0xD8, 'a', 0}, "utf-16le")
+		assert.Nil(t, err)
+		assert.Equal(t, "�a", s)
+		_, err = readAll([]byte{'a', 0, 'b'}, "utf-16le")
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	})
+
+	t.Run("#3: windows-1252 and iso-8859-1", func(t *testing.T) {
+		s, err := readAll([]byte{'c', 'a', 'f', 0xE9, ',', 0x80, 0x93}, "windows-1252")
+		assert.Nil(t, err)
+		assert.Equal(t, "café,€“", s)
+
+		s, err = readAll([]byte{'c', 'a', 'f', 0xE9, ',', 0x80}, "latin1")
+		assert.Nil(t, err)
+		assert.Equal(t, "café,\u0080", s)
+	})
+
+	t.Run("#4: unsupported charset", func(t *testing.T) {
+		_, err := readAll([]byte("abc"), "shift-jis")
+		assert.ErrorIs(t, err, ErrConfigOptionInvalid)
+	})
+}
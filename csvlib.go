@@ -5,6 +5,10 @@ import (
 	"encoding/csv"
 	"fmt"
 	"reflect"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/tiendc/gofn"
 )
 
 const (
@@ -33,6 +37,35 @@ type CSVMarshaler interface {
 	MarshalCSV() ([]byte, error)
 }
 
+// StructValidator interface of decoded items which can validate themselves, see DecodeConfig.UseStructValidator
+type StructValidator interface {
+	Validate() error
+}
+
+// PostDecodeHook interface of decoded items which need to transform themselves after decoding, e.g. to compute
+// derived fields. The function is called only when all the cells of the row are decoded successfully, before
+// the row validators. The returned error is put in the RowErrors of the row with column index -1.
+type PostDecodeHook interface {
+	PostDecode() error
+}
+
+// RowValidator interface of decoded items which can validate the values of multiple columns together, e.g. a
+// column's value depends on another one. The function is called only when all the cells of the row are decoded
+// successfully, after PostDecodeHook and before the row validators. A returned *CellError is put in the RowErrors
+// of the row as it is, other errors are put with column index -1.
+type RowValidator interface {
+	ValidateRow() error
+}
+
+// PreEncodeHook interface of items which need to prepare themselves before encoding, e.g. to compute
+// transient fields. The function is called before the fields of the item are read. The returned error
+// stops the encoding unless EncodeConfig.StopOnError is `false`, then the item is not encoded and the
+// error is collected in a RowErrors with the 1-based number of the item (counted from the first item encoded
+// by the Encoder).
+type PreEncodeHook interface {
+	PreEncode() error
+}
+
 // DecodeFunc decode function for a given cell text
 type DecodeFunc func(text string, v reflect.Value) error
 
@@ -50,16 +83,85 @@ type ParameterMap map[string]any
 // LocalizationFunc function to translate message into a specific language
 type LocalizationFunc func(key string, params ParameterMap) (string, error)
 
+// RowFilterFunc function to decide whether a row should be decoded or skipped
+type RowFilterFunc func(rawRow []string, header []string) bool
+
 // OnCellErrorFunc function to be called when error happens on decoding cell value
 type OnCellErrorFunc func(e *CellError)
 
+// NumberFormat format of numbers in CSV data, e.g. `1,234.56` or `1.234,56`
+type NumberFormat struct {
+	// GroupSeparator separator of digit groups of the integer part (optional)
+	GroupSeparator byte
+	// DecimalSeparator separator of the integer part and the fractional part (default is `.`)
+	DecimalSeparator byte
+}
+
+func (f *NumberFormat) decimalSeparator() byte {
+	if f.DecimalSeparator == 0 {
+		return '.'
+	}
+	return f.DecimalSeparator
+}
+
+func (f *NumberFormat) validate() error {
+	if f.GroupSeparator != 0 && f.GroupSeparator == f.decimalSeparator() {
+		return fmt.Errorf("%w: NumberFormat separators must be different", ErrConfigOptionInvalid)
+	}
+	return nil
+}
+
+// parse converts a number text in this format to the format accepted by strconv functions
+func (f *NumberFormat) parse(s string) string {
+	if f.GroupSeparator != 0 {
+		s = gofn.NumberFmtUngroup(s, f.GroupSeparator)
+	}
+	if decimalSep := f.decimalSeparator(); decimalSep != '.' {
+		s = strings.Replace(s, string(decimalSep), ".", 1)
+	}
+	return s
+}
+
+// format converts a number text generated by strconv functions to this format
+func (f *NumberFormat) format(s string) string {
+	intPart, fracPart, hasFrac := strings.Cut(s, ".")
+	if f.GroupSeparator != 0 {
+		intPart = gofn.NumberFmtGroup(intPart, 0, f.GroupSeparator)
+	}
+	if !hasFrac {
+		return intPart
+	}
+	return intPart + string(f.decimalSeparator()) + fracPart
+}
+
+// validateCSVDelimiter validates a delimiter or comment character to be set to csv.Reader or csv.Writer.
+// Zero means the option is not set.
+func validateCSVDelimiter(name string, ch rune) error {
+	if ch == 0 {
+		return nil
+	}
+	if ch >= utf8.RuneSelf || ch == '"' || ch == '\r' || ch == '\n' {
+		return fmt.Errorf("%w: %s '%c' unsupported", ErrConfigOptionInvalid, name, ch)
+	}
+	return nil
+}
+
 // ColumnDetail details of a column parsed from a struct tag
 type ColumnDetail struct {
 	Name      string
+	Aliases   []string
 	Optional  bool
 	OmitEmpty bool
 	Inline    bool
 	DataType  reflect.Type
+
+	// Prefix prefix of the inline column (including the prefix of the embedded struct)
+	Prefix string
+	// Dynamic the column is a dynamic inline column (e.g. InlineColumn[T]) whose actual columns
+	// are determined by the data
+	Dynamic bool
+	// ElementType type of the values of the dynamic inline column
+	ElementType reflect.Type
 }
 
 // Unmarshal convenient method to decode CVS data into a slice of structs
@@ -68,6 +170,19 @@ func Unmarshal(data []byte, v any, options ...DecodeOption) (*DecodeResult, erro
 	return decoder.Decode(v)
 }
 
+// ValidateSchema validates the header of the input data against the struct type of the given var without
+// decoding any data rows. The var must be of the type accepted by Decoder.Decode (e.g. `*[]Student`),
+// it is not modified. The same header errors as decoding (e.g. ErrHeaderColumnRequired) are returned
+// within an `*Errors`.
+func ValidateSchema(r Reader, v any, options ...DecodeOption) error {
+	decoder := NewDecoder(r, options...)
+	if err := decoder.prepareDecode(reflect.ValueOf(v)); err != nil {
+		decoder.err.Add(err)
+		return decoder.err
+	}
+	return nil
+}
+
 // Marshal convenient method to encode a slice of structs into CSV format
 func Marshal(v any, options ...EncodeOption) ([]byte, error) {
 	var buf bytes.Buffer
@@ -83,6 +198,39 @@ func Marshal(v any, options ...EncodeOption) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// WriteTemplate writes the header of the given struct type only, e.g. to generate a template for users to fill in.
+// The given var can be a struct, a pointer to struct or a slice of them, the data of the var are not encoded.
+// As there is no data to infer the header from, dynamic inline columns are not accepted. In NoHeaderMode,
+// nothing is written.
+func WriteTemplate(w Writer, v any, options ...EncodeOption) error {
+	typ := reflect.TypeOf(v)
+	if typ == nil {
+		return fmt.Errorf("%w: must be struct", ErrTypeInvalid)
+	}
+	if typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array {
+		typ = typ.Elem()
+	}
+	if indirectType(typ).Kind() != reflect.Struct {
+		return fmt.Errorf("%w: must be struct", ErrTypeInvalid)
+	}
+	encoder := NewEncoder(w, options...)
+	return encoder.prepareEncode(reflect.MakeSlice(reflect.SliceOf(typ), 0, 0))
+}
+
+// MarshalTemplate convenient method to encode the header of the given struct type into CSV format
+func MarshalTemplate(v any, options ...EncodeOption) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := WriteTemplate(w, v, options...); err != nil {
+		return nil, err
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
 // GetHeaderDetails get CSV header details from the given struct type
 func GetHeaderDetails(v any, tagName string) (columnDetails []ColumnDetail, err error) {
 	t := reflect.TypeOf(v)
@@ -90,6 +238,78 @@ func GetHeaderDetails(v any, tagName string) (columnDetails []ColumnDetail, err
 	if t.Kind() != reflect.Struct {
 		return nil, fmt.Errorf("%w: must be struct", ErrTypeInvalid)
 	}
+	return getStructColumnDetails(t, tagName, &embeddedStruct{}, false), nil
+}
+
+// GetFlatHeaderDetails get CSV header details from the given struct type with one entry per actual column.
+// Fixed inline columns are expanded into the columns of their fields (with prefix).
+// A dynamic inline column has one entry only as its columns are determined by the data, the same as
+// GetHeaderDetails, its name is without prefix (the prefix is set in the Prefix field).
+func GetFlatHeaderDetails(v any, tagName string) (columnDetails []ColumnDetail, err error) {
+	t := reflect.TypeOf(v)
+	t = indirectType(t)
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: must be struct", ErrTypeInvalid)
+	}
+	return getStructColumnDetails(t, tagName, &embeddedStruct{}, true), nil
+}
+
+func getStructColumnDetails(t reflect.Type, tagName string, parent *embeddedStruct, flat bool) (
+	columnDetails []ColumnDetail) {
+	numFields := t.NumField()
+	for i := 0; i < numFields; i++ {
+		field := parent.structField(t, i)
+		tag, _ := parseTag(tagName, field)
+		if isEmbeddedStructField(field, tag) {
+			columnDetails = append(columnDetails,
+				getStructColumnDetails(indirectType(field.Type), tagName, parent.embed(field, tag), flat)...)
+			continue
+		}
+		if tag == nil || tag.ignored {
+			continue
+		}
+		if !tag.inline {
+			columnDetails = append(columnDetails, ColumnDetail{
+				Name:      parent.prefix + tag.name,
+				Aliases:   tag.aliases,
+				Optional:  tag.optional || parent.optional,
+				OmitEmpty: tag.omitEmpty || parent.omitEmpty,
+				DataType:  field.Type,
+			})
+			continue
+		}
+
+		prefix := parent.prefix + tag.prefix
+		omitEmpty := tag.omitEmpty || parent.omitEmpty
+		elemType, dynamic := dynamicInlineColumnElemType(field.Type)
+		if flat && !dynamic {
+			columnDetails = append(columnDetails,
+				getInlineColumnDetails(field.Type, tagName, prefix, parent.optional, omitEmpty)...)
+			continue
+		}
+		// Prefix of the inline column is not applied to the name, it is put in the Prefix field
+		columnDetails = append(columnDetails, ColumnDetail{
+			Name:        tag.name,
+			Optional:    parent.optional,
+			OmitEmpty:   omitEmpty,
+			Inline:      true,
+			DataType:    field.Type,
+			Prefix:      prefix,
+			Dynamic:     dynamic,
+			ElementType: elemType,
+		})
+	}
+	return
+}
+
+// getInlineColumnDetails get details of the columns of the fixed inline column type.
+// The columns are optional or omitted when empty if the inline column (or its embedded struct) is.
+func getInlineColumnDetails(t reflect.Type, tagName string, prefix string, optional, omitEmpty bool) (
+	columnDetails []ColumnDetail) {
+	t = indirectType(t)
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
 	numFields := t.NumField()
 	for i := 0; i < numFields; i++ {
 		field := t.Field(i)
@@ -98,19 +318,23 @@ func GetHeaderDetails(v any, tagName string) (columnDetails []ColumnDetail, err
 			continue
 		}
 		columnDetails = append(columnDetails, ColumnDetail{
-			Name:      tag.name,
-			Optional:  tag.optional,
-			OmitEmpty: tag.omitEmpty,
-			Inline:    tag.inline,
+			Name:      prefix + tag.name,
+			Aliases:   tag.aliases,
+			Optional:  tag.optional || optional,
+			OmitEmpty: tag.omitEmpty || omitEmpty,
+			Inline:    true,
 			DataType:  field.Type,
+			Prefix:    prefix,
 		})
 	}
 	return
 }
 
-// GetHeader get CSV header from the given struct
+// GetHeader get CSV header from the given struct.
+// Fixed inline columns are expanded into the columns of their fields (see GetFlatHeaderDetails).
+// A dynamic inline column is returned by its name without prefix as its actual columns are determined by the data.
 func GetHeader(v any, tagName string) ([]string, error) {
-	details, err := GetHeaderDetails(v, tagName)
+	details, err := GetFlatHeaderDetails(v, tagName)
 	if err != nil {
 		return nil, err
 	}
@@ -1,7 +1,9 @@
 package csvlib
 
 import (
+	"encoding/csv"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -29,6 +31,115 @@ func Test_Unmarshal(t *testing.T) {
 		assert.Equal(t, []string{"ColX"}, ret.MissingOptionalColumns())
 		assert.Equal(t, []Item{{Col1: 1, Col2: 2.123}, {Col1: 100, Col2: 200}}, v)
 	})
+
+	t.Run("#2: with Comma and Comment", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`# exported data
+			col1;col2
+			1;2.123
+			# total
+			100;200`)
+
+		var v []Item
+		ret, err := Unmarshal([]byte(data), &v, func(cfg *DecodeConfig) {
+			cfg.Comma = ';'
+			cfg.Comment = '#'
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 3, ret.TotalRow())
+		assert.Equal(t, []Item{{Col1: 1, Col2: 2.123}, {Col1: 100, Col2: 200}}, v)
+	})
+
+	t.Run("#3: Comma applied to user csv.Reader", func(t *testing.T) {
+		r := csv.NewReader(strings.NewReader("col1|col2\n1|2.5"))
+		var v []Item
+		_, err := NewDecoder(r, func(cfg *DecodeConfig) {
+			cfg.Comma = '|'
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{{Col1: 1, Col2: 2.5}}, v)
+	})
+
+	t.Run("#4: invalid Comma and Comment", func(t *testing.T) {
+		var v []Item
+		_, err := Unmarshal([]byte("col1,col2"), &v, func(cfg *DecodeConfig) {
+			cfg.Comma = '§'
+		})
+		assert.ErrorIs(t, err, ErrConfigOptionInvalid)
+
+		_, err = Unmarshal([]byte("col1,col2"), &v, func(cfg *DecodeConfig) {
+			cfg.Comment = '"'
+		})
+		assert.ErrorIs(t, err, ErrConfigOptionInvalid)
+
+		_, err = Unmarshal([]byte("col1,col2"), &v, func(cfg *DecodeConfig) {
+			cfg.Comma = ';'
+			cfg.Comment = ';'
+		})
+		assert.ErrorIs(t, err, ErrConfigOptionInvalid)
+	})
+}
+
+type countingReader struct {
+	Reader
+	reads int
+}
+
+func (r *countingReader) Read() ([]string, error) {
+	r.reads++
+	return r.Reader.Read()
+}
+
+func Test_ValidateSchema(t *testing.T) {
+	type Item struct {
+		Col1 int    `csv:"col1"`
+		Col2 string `csv:"col2,optional"`
+		Col3 string `csv:"col3"`
+	}
+	newReader := func(data string) *countingReader {
+		return &countingReader{Reader: csv.NewReader(strings.NewReader(data))}
+	}
+
+	t.Run("#1: success without reading data rows", func(t *testing.T) {
+		r := newReader(gofn.MultilineString(
+			`col1,col3
+			abc,x
+			def,y`))
+		var v []Item
+		err := ValidateSchema(r, &v)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, r.reads)
+		assert.Nil(t, v)
+	})
+
+	t.Run("#2: required column missing", func(t *testing.T) {
+		var v []Item
+		err := ValidateSchema(newReader("col1,col2\n1,2"), &v)
+		assert.ErrorIs(t, err, ErrHeaderColumnRequired)
+		_, ok := err.(*Errors) // nolint: errorlint
+		assert.True(t, ok)
+	})
+
+	t.Run("#3: column order and uniqueness", func(t *testing.T) {
+		var v []Item
+		err := ValidateSchema(newReader("col3,col1"), &v)
+		assert.ErrorIs(t, err, ErrHeaderColumnOrderInvalid)
+
+		err = ValidateSchema(newReader("col3,col1"), &v, func(cfg *DecodeConfig) {
+			cfg.RequireColumnOrder = false
+		})
+		assert.Nil(t, err)
+
+		err = ValidateSchema(newReader("col1,col3,col3"), &v, func(cfg *DecodeConfig) {
+			cfg.RequireColumnOrder = false
+		})
+		assert.ErrorIs(t, err, ErrHeaderColumnDuplicated)
+	})
+
+	t.Run("#4: invalid output var", func(t *testing.T) {
+		err := ValidateSchema(newReader("col1,col3"), []Item{})
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+	})
 }
 
 func Test_Marshal(t *testing.T) {
@@ -52,6 +163,97 @@ func Test_Marshal(t *testing.T) {
 				false,100,200
 			`), string(data))
 	})
+
+	t.Run("#2: with Comma and UseCRLF", func(t *testing.T) {
+		v := []Item{
+			{Col1: 1, Col2: 2.5},
+		}
+		data, err := Marshal(v, func(cfg *EncodeConfig) {
+			cfg.Comma = ';'
+			cfg.UseCRLF = true
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, "ColX;col1;col2\r\nfalse;1;2.5\r\n", string(data))
+	})
+
+	t.Run("#3: Comma applied to user csv.Writer", func(t *testing.T) {
+		var buf strings.Builder
+		w := csv.NewWriter(&buf)
+		err := NewEncoder(w, func(cfg *EncodeConfig) {
+			cfg.Comma = '\t'
+		}).Encode([]Item{{Col1: 1, Col2: 2.5}})
+		assert.Nil(t, err)
+		w.Flush()
+		assert.Equal(t, "ColX\tcol1\tcol2\nfalse\t1\t2.5\n", buf.String())
+	})
+
+	t.Run("#4: invalid Comma", func(t *testing.T) {
+		_, err := Marshal([]Item{}, func(cfg *EncodeConfig) {
+			cfg.Comma = '\n'
+		})
+		assert.ErrorIs(t, err, ErrConfigOptionInvalid)
+	})
+}
+
+func Test_MarshalTemplate(t *testing.T) {
+	type Inline struct {
+		Sub1 int `csv:"sub1"`
+		Sub2 int `csv:"sub2,optional"`
+	}
+	type Item struct {
+		Col1 int    `csv:"col1"`
+		Col2 string `csv:"col2,optional"`
+		Fix  Inline `csv:"fix,inline,prefix=col3_"`
+		Col4 string `csv:"-"`
+	}
+
+	t.Run("#1: success", func(t *testing.T) {
+		data, err := MarshalTemplate(Item{})
+		assert.Nil(t, err)
+		assert.Equal(t, "col1,col2,col3_sub1,col3_sub2\n", string(data))
+
+		// Data of the input var are not encoded
+		data, err = MarshalTemplate([]*Item{{Col1: 1}})
+		assert.Nil(t, err)
+		assert.Equal(t, "col1,col2,col3_sub1,col3_sub2\n", string(data))
+	})
+
+	t.Run("#2: localized header", func(t *testing.T) {
+		type Item struct {
+			Col1 int    `csv:"col1"`
+			Col2 string `csv:"col2,optional"`
+		}
+		data, err := MarshalTemplate(&Item{}, func(cfg *EncodeConfig) {
+			cfg.LocalizeHeader = true
+			cfg.LocalizationFunc = localizeViVn
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, "cột-1,cột-2\n", string(data))
+	})
+
+	t.Run("#3: no header mode", func(t *testing.T) {
+		data, err := MarshalTemplate(Item{}, func(cfg *EncodeConfig) {
+			cfg.NoHeaderMode = true
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, "", string(data))
+	})
+
+	t.Run("#4: dynamic inline column", func(t *testing.T) {
+		type Item struct {
+			Col1 int               `csv:"col1"`
+			Dyn  InlineColumn[int] `csv:"dyn,inline"`
+		}
+		_, err := MarshalTemplate(Item{})
+		assert.ErrorIs(t, err, ErrHeaderDynamicTypeInvalid)
+	})
+
+	t.Run("#5: invalid type", func(t *testing.T) {
+		_, err := MarshalTemplate(nil)
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+		_, err = MarshalTemplate([]int{})
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+	})
 }
 
 func Test_GetHeaderDetails(t *testing.T) {
@@ -68,16 +270,121 @@ func Test_GetHeaderDetails(t *testing.T) {
 		assert.Equal(t, []ColumnDetail{
 			{Name: "col1", DataType: reflect.TypeOf(int(0)), OmitEmpty: true},
 			{Name: "col2", DataType: reflect.TypeOf(gofn.New("")), Optional: true},
-			{Name: "col5", DataType: reflect.TypeOf(InlineColumn[int]{}), Inline: true},
+			{Name: "col5", DataType: reflect.TypeOf(InlineColumn[int]{}), Inline: true,
+				Dynamic: true, ElementType: reflect.TypeOf(int(0))},
 		}, details)
 	})
 
-	t.Run("#2: invalid type", func(t *testing.T) {
+	t.Run("#2: embedded struct", func(t *testing.T) {
+		type Audit struct {
+			CreatedBy string `csv:"created_by"`
+			UpdatedBy string `csv:"updated_by,omitempty"`
+		}
+		type AuditPtr struct {
+			CreatedBy string `csv:"created_by"`
+		}
+		type Item struct {
+			Col1 int `csv:"col1"`
+			Audit
+			*AuditPtr `csv:",optional,prefix=ptr_"`
+		}
+		details, err := GetHeaderDetails(Item{}, "csv")
+		assert.Nil(t, err)
+		assert.Equal(t, []ColumnDetail{
+			{Name: "col1", DataType: reflect.TypeOf(int(0))},
+			{Name: "created_by", DataType: reflect.TypeOf("")},
+			{Name: "updated_by", DataType: reflect.TypeOf(""), OmitEmpty: true},
+			{Name: "ptr_created_by", DataType: reflect.TypeOf(""), Optional: true},
+		}, details)
+	})
+
+	t.Run("#3: invalid type", func(t *testing.T) {
 		_, err := GetHeaderDetails("abc", "csv")
 		assert.ErrorIs(t, err, ErrTypeInvalid)
 	})
 }
 
+func Test_GetFlatHeaderDetails(t *testing.T) {
+	type Inline struct {
+		Sub1 int    `csv:"sub1"`
+		Sub2 string `csv:"sub2,optional,omitempty"`
+		Sub3 bool   `csv:"-"`
+	}
+	type Audit struct {
+		CreatedBy string `csv:"created_by"`
+		Fix       Inline `csv:"fix,inline,prefix=fix_"`
+	}
+
+	t.Run("#1: fixed and dynamic inline columns", func(t *testing.T) {
+		type Item struct {
+			Col1 int                `csv:"col1"`
+			Fix  *Inline            `csv:"fix,inline"`
+			Dyn  InlineColumn[bool] `csv:"dyn,inline,prefix=d_"`
+		}
+		details, err := GetFlatHeaderDetails(&Item{}, "csv")
+		assert.Nil(t, err)
+		assert.Equal(t, []ColumnDetail{
+			{Name: "col1", DataType: reflect.TypeOf(int(0))},
+			{Name: "sub1", DataType: reflect.TypeOf(int(0)), Inline: true},
+			{Name: "sub2", DataType: reflect.TypeOf(""), Inline: true, Optional: true, OmitEmpty: true},
+			{Name: "dyn", DataType: reflect.TypeOf(InlineColumn[bool]{}), Inline: true, Prefix: "d_",
+				Dynamic: true, ElementType: reflect.TypeOf(true)},
+		}, details)
+
+		header, err := GetHeader(&Item{}, "csv")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"col1", "sub1", "sub2", "dyn"}, header)
+	})
+
+	t.Run("#2: inline column within embedded struct", func(t *testing.T) {
+		type Item struct {
+			Col1  int `csv:"col1"`
+			Audit `csv:",prefix=audit_"`
+		}
+		details, err := GetFlatHeaderDetails(Item{}, "csv")
+		assert.Nil(t, err)
+		assert.Equal(t, []ColumnDetail{
+			{Name: "col1", DataType: reflect.TypeOf(int(0))},
+			{Name: "audit_created_by", DataType: reflect.TypeOf("")},
+			{Name: "audit_fix_sub1", DataType: reflect.TypeOf(int(0)), Inline: true, Prefix: "audit_fix_"},
+			{Name: "audit_fix_sub2", DataType: reflect.TypeOf(""), Inline: true, Prefix: "audit_fix_",
+				Optional: true, OmitEmpty: true},
+		}, details)
+
+		header, err := GetHeader(Item{}, "csv")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"col1", "audit_created_by", "audit_fix_sub1", "audit_fix_sub2"}, header)
+	})
+
+	t.Run("#3: inline columns within optional embedded struct", func(t *testing.T) {
+		type Sub struct {
+			Sub1 int `csv:"sub1,aliases=s1|first"`
+		}
+		type Extra struct {
+			Fix Sub               `csv:"fix,inline,prefix=fix_"`
+			Dyn InlineColumn[int] `csv:"dyn,inline,prefix=d_"`
+		}
+		type Item struct {
+			Col1  int `csv:"col1"`
+			Extra `csv:",optional,omitempty,prefix=x_"`
+		}
+		details, err := GetFlatHeaderDetails(Item{}, "csv")
+		assert.Nil(t, err)
+		assert.Equal(t, []ColumnDetail{
+			{Name: "col1", DataType: reflect.TypeOf(int(0))},
+			{Name: "x_fix_sub1", Aliases: []string{"s1", "first"}, DataType: reflect.TypeOf(int(0)), Inline: true,
+				Prefix: "x_fix_", Optional: true, OmitEmpty: true},
+			{Name: "dyn", DataType: reflect.TypeOf(InlineColumn[int]{}), Inline: true, Prefix: "x_d_",
+				Optional: true, OmitEmpty: true, Dynamic: true, ElementType: reflect.TypeOf(int(0))},
+		}, details)
+	})
+
+	t.Run("#4: invalid type", func(t *testing.T) {
+		_, err := GetFlatHeaderDetails(0, "csv")
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+	})
+}
+
 func Test_GetHeader(t *testing.T) {
 	t.Run("#1: success", func(t *testing.T) {
 		type Item struct {
@@ -1,18 +1,98 @@
 package csvlib
 
 import (
+	"database/sql"
 	"encoding"
+	"errors"
 	"fmt"
+	"math"
 	"reflect"
 	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tiendc/gofn"
 )
 
 var (
 	textUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
 	csvUnmarshaler  = reflect.TypeOf((*CSVUnmarshaler)(nil)).Elem()
+	timeType        = reflect.TypeOf(time.Time{})
+	durationType    = reflect.TypeOf(time.Duration(0))
+	restColumnType  = reflect.TypeOf(map[string]string{})
+
+	// sqlNullTypes nullable types of package `database/sql`, they all have the value as the first field
+	// and the `Valid` flag as the second one
+	sqlNullTypes = map[reflect.Type]struct{}{
+		reflect.TypeOf(sql.NullString{}):  {},
+		reflect.TypeOf(sql.NullInt64{}):   {},
+		reflect.TypeOf(sql.NullInt32{}):   {},
+		reflect.TypeOf(sql.NullInt16{}):   {},
+		reflect.TypeOf(sql.NullByte{}):    {},
+		reflect.TypeOf(sql.NullFloat64{}): {},
+		reflect.TypeOf(sql.NullBool{}):    {},
+		reflect.TypeOf(sql.NullTime{}):    {},
+	}
+
+	// defaultDecodeTimeLayouts layouts to try in order when decoding time values without a specific layout
+	defaultDecodeTimeLayouts = []string{time.RFC3339, "2006-01-02", "2006-01-02 15:04:05"}
+
+	// defaultDecodeBoolTrueValues and defaultDecodeBoolFalseValues texts to be decoded as boolean values,
+	// they include the ones accepted by strconv.ParseBool (compared case-insensitively)
+	defaultDecodeBoolTrueValues  = []string{"1", "t", "true", "y", "yes", "on"}
+	defaultDecodeBoolFalseValues = []string{"0", "f", "false", "n", "no", "off"}
+)
+
+const (
+	// durationFormatSeconds format of duration values represented as numbers of seconds
+	durationFormatSeconds = "seconds"
 )
 
-func getDecodeFunc(typ reflect.Type) (DecodeFunc, error) {
+// decodeFuncConfig configuration for building decode functions
+type decodeFuncConfig struct {
+	timeLayouts       []string
+	format            string
+	sep               string
+	intBase           int
+	numberFormat      *NumberFormat
+	boolTrueValues    []string
+	boolFalseValues   []string
+	boolCaseSensitive bool
+	inferIfaceTypes   bool
+	typeDecodeFuncs   map[reflect.Type]DecodeFunc
+}
+
+func getDecodeFunc(typ reflect.Type, cfg *decodeFuncConfig) (DecodeFunc, error) {
+	if cfg.sep != "" {
+		return decodeSliceFunc(typ, cfg)
+	}
+	if cfg.intBase != 0 {
+		return decodeIntBaseFunc(typ, cfg.intBase)
+	}
+	if decodeFn := findDecodeFunc(cfg.typeDecodeFuncs, typ); decodeFn != nil {
+		return decodeFn, nil
+	}
+	if decodeFn := getRegisteredDecodeFunc(typ); decodeFn != nil {
+		return decodeFn, nil
+	}
+	if typ == timeType {
+		return decodeTimeFunc(cfg.timeLayouts), nil
+	}
+	if typ.Kind() == reflect.Pointer && typ.Elem() == timeType {
+		return decodePtrTimeFunc(cfg.timeLayouts), nil
+	}
+	if typ == durationType || (typ.Kind() == reflect.Pointer && typ.Elem() == durationType) {
+		if cfg.format != "" && cfg.format != durationFormatSeconds {
+			return nil, fmt.Errorf("%w: format=%s", ErrTagOptionInvalid, cfg.format)
+		}
+		if typ == durationType {
+			return decodeDurationFunc(cfg.format), nil
+		}
+		return decodePtrDurationFunc(cfg.format), nil
+	}
+	if isSQLNullType(indirectType(typ)) {
+		return decodeSQLNullFunc(typ, cfg)
+	}
 	if typ.Implements(csvUnmarshaler) {
 		return decodeCSVUnmarshaler, nil
 	}
@@ -25,6 +105,29 @@ func getDecodeFunc(typ reflect.Type) (DecodeFunc, error) {
 	if reflect.PointerTo(typ).Implements(textUnmarshaler) {
 		return decodePtrTextUnmarshaler, nil
 	}
+	// Float format only takes effect when encoding
+	if err := validateFormatTag(typ, cfg.format); err != nil {
+		return nil, err
+	}
+	if cfg.numberFormat != nil && isNumberType(typ) {
+		decodeFn, err := getDecodeFuncBaseType(typ)
+		if err != nil {
+			return nil, err
+		}
+		return decodeNumberFormatFunc(decodeFn, cfg.numberFormat), nil
+	}
+	if typ.Kind() == reflect.Bool {
+		return decodeBoolFunc(cfg.boolTrueValues, cfg.boolFalseValues, cfg.boolCaseSensitive), nil
+	}
+	if typ.Kind() == reflect.Pointer && typ.Elem().Kind() == reflect.Bool {
+		return decodePtrBoolFunc(cfg.boolTrueValues, cfg.boolFalseValues, cfg.boolCaseSensitive), nil
+	}
+	if cfg.inferIfaceTypes && indirectType(typ).Kind() == reflect.Interface && indirectType(typ).NumMethod() == 0 {
+		if typ.Kind() == reflect.Pointer {
+			return decodePtrInferredInterface, nil
+		}
+		return decodeInferredInterface, nil
+	}
 	return getDecodeFuncBaseType(typ)
 }
 
@@ -42,14 +145,14 @@ func getDecodeFuncBaseType(typ reflect.Type) (DecodeFunc, error) {
 		return decodeStr, nil
 	case reflect.Int, reflect.Int64, reflect.Int32, reflect.Int16, reflect.Int8:
 		if typeIsPtr {
-			return decodePtrIntFunc(typ.Bits()), nil
+			return decodePtrIntFunc(typ.Bits(), 10), nil //nolint:mnd
 		}
-		return decodeIntFunc(typ.Bits()), nil
+		return decodeIntFunc(typ.Bits(), 10), nil //nolint:mnd
 	case reflect.Uint, reflect.Uint64, reflect.Uint32, reflect.Uint16, reflect.Uint8:
 		if typeIsPtr {
-			return decodePtrUintFunc(typ.Bits()), nil
+			return decodePtrUintFunc(typ.Bits(), 10), nil //nolint:mnd
 		}
-		return decodeUintFunc(typ.Bits()), nil
+		return decodeUintFunc(typ.Bits(), 10), nil //nolint:mnd
 	case reflect.Bool:
 		if typeIsPtr {
 			return decodePtrBool, nil
@@ -126,8 +229,39 @@ func decodePtrBool(s string, v reflect.Value) error {
 	return decodeBool(s, initAndIndirectValue(v))
 }
 
-func decodeInt(s string, v reflect.Value, bits int) error {
-	n, err := strconv.ParseInt(s, 10, bits)
+func decodeBoolValues(s string, v reflect.Value, trueValues, falseValues []string, caseSensitive bool) error {
+	isToken := func(token string) bool { return strings.EqualFold(token, s) }
+	if caseSensitive {
+		isToken = func(token string) bool { return token == s }
+	}
+	switch {
+	case gofn.ContainBy(trueValues, isToken):
+		v.SetBool(true)
+	case gofn.ContainBy(falseValues, isToken):
+		v.SetBool(false)
+	default:
+		return &errorWithParams{
+			error:  fmt.Errorf("%w: %v (%s)", ErrDecodeValueType, v.Type(), s),
+			params: ParameterMap{"Token": s},
+		}
+	}
+	return nil
+}
+
+func decodeBoolFunc(trueValues, falseValues []string, caseSensitive bool) DecodeFunc {
+	return func(s string, v reflect.Value) error {
+		return decodeBoolValues(s, v, trueValues, falseValues, caseSensitive)
+	}
+}
+
+func decodePtrBoolFunc(trueValues, falseValues []string, caseSensitive bool) DecodeFunc {
+	return func(s string, v reflect.Value) error {
+		return decodeBoolValues(s, initAndIndirectValue(v), trueValues, falseValues, caseSensitive)
+	}
+}
+
+func decodeInt(s string, v reflect.Value, bits, base int) error {
+	n, err := strconv.ParseInt(s, base, bits)
 	if err != nil {
 		return fmt.Errorf("%w: %v (%s)", ErrDecodeValueType, v.Type(), s)
 	}
@@ -135,20 +269,20 @@ func decodeInt(s string, v reflect.Value, bits int) error {
 	return nil
 }
 
-func decodeIntFunc(bits int) DecodeFunc {
+func decodeIntFunc(bits, base int) DecodeFunc {
 	return func(s string, v reflect.Value) error {
-		return decodeInt(s, v, bits)
+		return decodeInt(s, v, bits, base)
 	}
 }
 
-func decodePtrIntFunc(bits int) DecodeFunc {
+func decodePtrIntFunc(bits, base int) DecodeFunc {
 	return func(s string, v reflect.Value) error {
-		return decodeInt(s, initAndIndirectValue(v), bits)
+		return decodeInt(s, initAndIndirectValue(v), bits, base)
 	}
 }
 
-func decodeUint(s string, v reflect.Value, bits int) error {
-	n, err := strconv.ParseUint(s, 10, bits)
+func decodeUint(s string, v reflect.Value, bits, base int) error {
+	n, err := strconv.ParseUint(s, base, bits)
 	if err != nil {
 		return fmt.Errorf("%w: %v (%s)", ErrDecodeValueType, v.Type(), s)
 	}
@@ -156,15 +290,45 @@ func decodeUint(s string, v reflect.Value, bits int) error {
 	return nil
 }
 
-func decodeUintFunc(bits int) DecodeFunc {
+func decodeUintFunc(bits, base int) DecodeFunc {
+	return func(s string, v reflect.Value) error {
+		return decodeUint(s, v, bits, base)
+	}
+}
+
+func decodePtrUintFunc(bits, base int) DecodeFunc {
 	return func(s string, v reflect.Value) error {
-		return decodeUint(s, v, bits)
+		return decodeUint(s, initAndIndirectValue(v), bits, base)
 	}
 }
 
-func decodePtrUintFunc(bits int) DecodeFunc {
+// decodeNumberFormatFunc converts the number text in the given format before decoding it
+func decodeNumberFormatFunc(decodeFn DecodeFunc, format *NumberFormat) DecodeFunc {
 	return func(s string, v reflect.Value) error {
-		return decodeUint(s, initAndIndirectValue(v), bits)
+		return decodeFn(format.parse(s), v)
+	}
+}
+
+// decodeIntBaseFunc gets decode function for integers in the given base (set via tag option `base`)
+func decodeIntBaseFunc(typ reflect.Type, base int) (DecodeFunc, error) {
+	if base == intBaseAuto {
+		base = 0
+	}
+	typeIsPtr := typ.Kind() == reflect.Pointer
+	typ = indirectType(typ)
+	switch typ.Kind() { // nolint: exhaustive
+	case reflect.Int, reflect.Int64, reflect.Int32, reflect.Int16, reflect.Int8:
+		if typeIsPtr {
+			return decodePtrIntFunc(typ.Bits(), base), nil
+		}
+		return decodeIntFunc(typ.Bits(), base), nil
+	case reflect.Uint, reflect.Uint64, reflect.Uint32, reflect.Uint16, reflect.Uint8:
+		if typeIsPtr {
+			return decodePtrUintFunc(typ.Bits(), base), nil
+		}
+		return decodeUintFunc(typ.Bits(), base), nil
+	default:
+		return nil, fmt.Errorf("%w: base tag is only accepted for integer column", ErrTagOptionInvalid)
 	}
 }
 
@@ -189,6 +353,132 @@ func decodePtrFloatFunc(bits int) DecodeFunc {
 	}
 }
 
+func decodeTime(s string, v reflect.Value, layouts []string) error {
+	for _, layout := range layouts {
+		t, err := time.Parse(layout, s)
+		if err == nil {
+			v.Set(reflect.ValueOf(t))
+			return nil
+		}
+	}
+	return &errorWithParams{
+		error:  fmt.Errorf("%w: %v (%s)", ErrDecodeValueType, v.Type(), s),
+		params: ParameterMap{"Layouts": layouts},
+	}
+}
+
+func decodeTimeFunc(layouts []string) DecodeFunc {
+	return func(s string, v reflect.Value) error {
+		return decodeTime(s, v, layouts)
+	}
+}
+
+func decodePtrTimeFunc(layouts []string) DecodeFunc {
+	return func(s string, v reflect.Value) error {
+		return decodeTime(s, initAndIndirectValue(v), layouts)
+	}
+}
+
+func decodeDuration(s string, v reflect.Value, format string) error {
+	var d time.Duration
+	if format == durationFormatSeconds {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return fmt.Errorf("%w: %v (%s)", ErrDecodeValueType, v.Type(), s)
+		}
+		d = time.Duration(f * float64(time.Second))
+	} else {
+		var err error
+		d, err = time.ParseDuration(s)
+		if err != nil {
+			return fmt.Errorf("%w: %v (%s)", ErrDecodeValueType, v.Type(), s)
+		}
+	}
+	v.SetInt(int64(d))
+	return nil
+}
+
+func decodeDurationFunc(format string) DecodeFunc {
+	return func(s string, v reflect.Value) error {
+		return decodeDuration(s, v, format)
+	}
+}
+
+func decodePtrDurationFunc(format string) DecodeFunc {
+	return func(s string, v reflect.Value) error {
+		return decodeDuration(s, initAndIndirectValue(v), format)
+	}
+}
+
+// decodeSQLNullFunc builds the decode function for the nullable types of package `database/sql`.
+// Empty text is decoded as an invalid value (`Valid` is `false`).
+func decodeSQLNullFunc(typ reflect.Type, cfg *decodeFuncConfig) (DecodeFunc, error) {
+	valueDecodeFunc, err := getDecodeFunc(indirectType(typ).Field(0).Type, cfg)
+	if err != nil {
+		return nil, err
+	}
+	decodeFn := func(s string, v reflect.Value) error {
+		if s == "" {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+		if err := valueDecodeFunc(s, v.Field(0)); err != nil {
+			return err
+		}
+		v.Field(1).SetBool(true)
+		return nil
+	}
+	if typ.Kind() == reflect.Pointer {
+		return func(s string, v reflect.Value) error {
+			return decodeFn(s, initAndIndirectValue(v))
+		}, nil
+	}
+	return decodeFn, nil
+}
+
+func decodeSliceFunc(typ reflect.Type, cfg *decodeFuncConfig) (DecodeFunc, error) {
+	if typ.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("%w: sep tag is only accepted for slice column", ErrTagOptionInvalid)
+	}
+	elemCfg := *cfg
+	elemCfg.sep = ""
+	elemDecodeFunc, err := getDecodeFunc(typ.Elem(), &elemCfg)
+	if err != nil {
+		return nil, err
+	}
+	return func(s string, v reflect.Value) error {
+		if s == "" {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+		elems := strings.Split(s, cfg.sep)
+		slice := reflect.MakeSlice(v.Type(), len(elems), len(elems))
+		for i, elem := range elems {
+			if err := elemDecodeFunc(elem, slice.Index(i)); err != nil {
+				return wrapSliceElementError(err, i)
+			}
+		}
+		v.Set(slice)
+		return nil
+	}, nil
+}
+
+// wrapSliceElementError wraps the error of a slice element with the index of the element
+func wrapSliceElementError(err error, index int) error {
+	params := ParameterMap{}
+	var errWithParams *errorWithParams
+	if errors.As(err, &errWithParams) {
+		for k, v := range errWithParams.params {
+			params[k] = v
+		}
+	}
+	params["ElementIndex"] = index
+	return &errorWithParams{
+		error:  fmt.Errorf("%w (element %d)", err, index),
+		params: params,
+	}
+}
+
 func decodeInterface(s string, v reflect.Value) error {
 	v.Set(reflect.ValueOf(s))
 	return nil
@@ -198,3 +488,28 @@ func decodePtrInterface(s string, v reflect.Value) error {
 	initAndIndirectValue(v).Set(reflect.ValueOf(s))
 	return nil
 }
+
+// inferInterfaceValue infers the value of the text in the order: int64, float64 (finite values only),
+// bool (the texts accepted by strconv.ParseBool), and string if none of them matches
+func inferInterfaceValue(s string) any {
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return i
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil && !math.IsInf(f, 0) && !math.IsNaN(f) {
+		return f
+	}
+	if b, err := strconv.ParseBool(s); err == nil {
+		return b
+	}
+	return s
+}
+
+func decodeInferredInterface(s string, v reflect.Value) error {
+	v.Set(reflect.ValueOf(inferInterfaceValue(s)))
+	return nil
+}
+
+func decodePtrInferredInterface(s string, v reflect.Value) error {
+	initAndIndirectValue(v).Set(reflect.ValueOf(inferInterfaceValue(s)))
+	return nil
+}
@@ -1,17 +1,33 @@
 package csvlib
 
 import (
+	"bufio"
+	"context"
 	"encoding/csv"
 	"errors"
 	"fmt"
 	"io"
 	"reflect"
 	"strings"
+	"sync/atomic"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/tiendc/gofn"
 )
 
+// MissingRequiredColumnPolicy policy to handle the non-optional columns missing from the input header
+type MissingRequiredColumnPolicy int
+
+const (
+	// MissingRequiredColumnFail fails the decoding with ErrHeaderColumnRequired
+	MissingRequiredColumnFail = MissingRequiredColumnPolicy(0)
+	// MissingRequiredColumnFillZero keeps the fields of the missing columns zero and reports the columns
+	// via DecodeResult.MissingRequiredColumns()
+	MissingRequiredColumnFillZero = MissingRequiredColumnPolicy(1)
+)
+
 // DecodeConfig configuration for decoding CSV data as structs
 type DecodeConfig struct {
 	// TagName tag name to parse the struct (default is `csv`)
@@ -20,6 +36,60 @@ type DecodeConfig struct {
 	// NoHeaderMode indicates the input data have no header (default is `false`)
 	NoHeaderMode bool
 
+	// SkipInitialRows number of rows to be discarded before the header, or before the first data row
+	// in NoHeaderMode (default is `0`). Row numbers still count from the beginning of the input.
+	SkipInitialRows int
+
+	// HeaderRowIndex 0-based index of the header row in the input (default is `0`). This is another way to
+	// express SkipInitialRows, only one of them can be set. It is not allowed in NoHeaderMode.
+	HeaderRowIndex int
+
+	// SkipRows number of rows to be discarded right after the header, or after the initial rows in
+	// NoHeaderMode (default is `0`). This is useful when the input has some metadata rows between the
+	// header and the data rows, or to resume a previously interrupted import by skipping the rows processed
+	// already. Row numbers still count from the beginning of the input.
+	SkipRows int
+
+	// CommentChar rows having the first field starting with this character are skipped (optional).
+	// Leading spaces of the field are ignored when TrimSpace is set. A quoted first field is never treated
+	// as a comment, the quoting is detected via the field positions of the csv.Reader, so it can't be detected
+	// for rows having a single field or for other Reader implementations. The header row must not start with
+	// this character.
+	CommentChar rune
+
+	// Comma delimiter of the fields, applied to the built-in csv.Reader used by Unmarshal, NewDecoderFromReader
+	// or passed to NewDecoder (default is `,`). Only single-byte delimiters are supported.
+	Comma rune
+
+	// Comment lines starting with this character are skipped by the built-in csv.Reader used by Unmarshal,
+	// NewDecoderFromReader or passed to NewDecoder (optional). Unlike CommentChar, the check is performed
+	// on the raw lines, so they are not counted as rows. Only single-byte characters are supported.
+	Comment rune
+
+	// SkipEmptyRows skip the data rows having all cells empty or whitespace (default is `false`).
+	// Blank lines are always skipped by the built-in csv.Reader. The skipped rows are not decoded,
+	// they are counted in DecodeResult.SkippedEmptyRows().
+	SkipEmptyRows bool
+
+	// StripBOM strip the UTF-8 byte order mark at the beginning of the input data (default is `false`).
+	// Files exported from spreadsheet apps often have it, which makes the first header column unrecognized.
+	StripBOM bool
+
+	// SourceCharset charset of the input data, the data are converted into UTF-8 before parsing (default is UTF-8).
+	// Supported charsets are `utf-8`, `utf-16le`, `utf-16be`, `windows-1252` and `iso-8859-1`.
+	// This is only applied to the decoders created via NewDecoderFromReader.
+	SourceCharset string
+
+	// TransformReader function to wrap the input reader before parsing, e.g. to convert the data from
+	// other charsets with package `golang.org/x/text/encoding` (optional). This is applied before SourceCharset,
+	// and only to the decoders created via NewDecoderFromReader.
+	TransformReader func(io.Reader) io.Reader
+
+	// AutoDetectDelimiter detect the delimiter of the input data among comma, semicolon, tab and pipe
+	// (default is `false`), see DetectDelimiter. This is only applied to the decoders created via
+	// NewDecoderFromReader, the detection errors are returned when decoding.
+	AutoDetectDelimiter bool
+
 	// StopOnError when error occurs, stop the processing (default is `true`)
 	StopOnError bool
 
@@ -39,16 +109,44 @@ type DecodeConfig struct {
 	//  }
 	ParseLocalizedHeader bool
 
+	// CaseInsensitiveHeader match the columns of the input header with the struct columns and their aliases
+	// case-insensitively (default is `false`). The matched columns keep the header text from the input data.
+	// Columns of the input header differing only in case are treated as duplicated.
+	CaseInsensitiveHeader bool
+
+	// DeduplicateHeaders rename the duplicated columns of the input header to `<name>_2`, `<name>_3`...
+	// before matching them with the struct columns (default is `false`). The renamed columns can match
+	// the struct columns of the new names or be treated as unrecognized columns. See DecodeResult.RenamedColumns().
+	DeduplicateHeaders bool
+
 	// AllowUnrecognizedColumns allow a column in the input data but not in the struct tag definition
 	// (default is "false")
 	AllowUnrecognizedColumns bool
 
+	// CaptureUnrecognizedValues keep the raw cell values of the unrecognized columns in the result
+	// (default is `false`), see DecodeResult.UnrecognizedColumnValues(). This has no effect in NoHeaderMode.
+	CaptureUnrecognizedValues bool
+
+	// MaxUnrecognizedValues maximum number of values to be captured for every unrecognized column when
+	// CaptureUnrecognizedValues is set (default is `1000`). Only the values of the first rows are kept.
+	MaxUnrecognizedValues int
+
+	// MissingRequiredColumnPolicy how to handle the non-optional columns missing from the input header
+	// (default is `MissingRequiredColumnFail`). With MissingRequiredColumnFillZero, the fields of the missing
+	// columns are left zero and the validators of the columns are not called.
+	MissingRequiredColumnPolicy MissingRequiredColumnPolicy
+
 	// TreatIncorrectStructureAsError treat incorrect data structure as error (default is `true`)
 	//
 	// For example: header has 5 columns, if there is a row having 6 columns, it will be treated as error
 	// and the decoding process will stop even StopOnError flag is false.
 	TreatIncorrectStructureAsError bool
 
+	// AllowTrailingEmptyColumns allow the rows having more cells than the header when all the extra cells
+	// are empty, e.g. `a,b,c,,` exported by spreadsheet apps (default is `false`). The extra cells are dropped.
+	// The number of the header columns is the number of the struct columns in NoHeaderMode.
+	AllowTrailingEmptyColumns bool
+
 	// DetectRowLine detect exact lines of rows (default is `false`)
 	//
 	// If turn this flag on, the input reader should be an instance of "encoding/csv" Reader
@@ -58,6 +156,131 @@ type DecodeConfig struct {
 	// LocalizationFunc localization function, required when ParseLocalizedHeader is true
 	LocalizationFunc LocalizationFunc
 
+	// HeaderNormalizeFunc function to normalize every column of the input header before matching them
+	// with the struct tags, e.g. to remove BOM or redundant spaces (optional).
+	// The original header is kept in DecodeResult.UnrecognizedColumns() and the errors.
+	HeaderNormalizeFunc func(string) string
+
+	// ColumnNameMap a map to translate the columns of the input header to the column names declared
+	// in the struct tags (optional). This is applied after HeaderNormalizeFunc.
+	// The original header is kept in DecodeResult.UnrecognizedColumns() and the errors.
+	ColumnNameMap map[string]string
+
+	// TimeLayout layout to decode time.Time values (optional).
+	// If not set, the decoder tries the layouts from DefaultTimeLayouts in order.
+	TimeLayout string
+
+	// DefaultTimeLayouts layouts to try in order when decoding time.Time values of columns
+	// having no specific layout (default is RFC3339, `2006-01-02`, `2006-01-02 15:04:05`).
+	// A column can have its own layout via the tag option `format`, e.g. `csv:"created_at,format=2006-01-02"`.
+	DefaultTimeLayouts []string
+
+	// BoolTrueValues texts to be decoded as `true` for bool fields, compared case-insensitively unless
+	// BoolCaseSensitive is `true` (default is `1`, `t`, `true`, `y`, `yes`, `on`)
+	BoolTrueValues []string
+
+	// BoolFalseValues texts to be decoded as `false` for bool fields, compared case-insensitively unless
+	// BoolCaseSensitive is `true` (default is `0`, `f`, `false`, `n`, `no`, `off`)
+	BoolFalseValues []string
+
+	// BoolCaseSensitive compare the cell texts with the bool values case-sensitively (default is `false`)
+	BoolCaseSensitive bool
+
+	// TypeDecodeFuncs decode functions for all fields of specific types, e.g. to decode all `time.Time` fields
+	// in a custom way without configuring every column (optional). Fields of pointer to the types are also
+	// decoded by the functions. DecodeColumnConfig.DecodeFunc takes precedence.
+	TypeDecodeFuncs map[reflect.Type]DecodeFunc
+
+	// RowFilterFunc function to filter rows before decoding (optional).
+	// The func is called with the raw data of a row and the header, if it returns `false`,
+	// the row is skipped entirely (not decoded, not counted as error). Rows having incorrect
+	// structure are not passed to this func.
+	RowFilterFunc RowFilterFunc
+
+	// RowFilterByRowFunc the same as RowFilterFunc, but the func is called with the row number instead of
+	// the header (optional), e.g. to skip subtotal rows interleaved in the data. The row number is the one
+	// reported by RowErrors.Row(). Filtered rows are still counted in DecodeResult.TotalRow().
+	RowFilterByRowFunc func(records []string, row int) bool
+
+	// RowValidatorFuncs validator functions to validate the whole decoded item of a row (optional),
+	// e.g. to check that a column's value is consistent with another one. The funcs are called with
+	// the decoded item (e.g. Student) only when all the cells of the row are decoded successfully.
+	// The errors are put in the RowErrors of the row as cell errors with column index -1.
+	// Use TypedRowValidator() to write the funcs without type assertions.
+	RowValidatorFuncs []ValidatorFunc
+
+	// UseStructValidator call the function `Validate() error` on the decoded items which implement
+	// StructValidator (default is `false`). Similar to the row validators, the function is called only
+	// when all the cells of the row are decoded successfully. An error implementing `Unwrap() []error`
+	// is split into separate errors of the row.
+	UseStructValidator bool
+
+	// OnRowDecodedFunc function to be called after a row is decoded successfully (optional).
+	// The func is called with the row number and a pointer to the decoded item (e.g. *Student), so it
+	// can modify the item such as computing derived fields. If the func returns an error, the error is
+	// added to the RowErrors of the row and StopOnError is respected.
+	OnRowDecodedFunc func(row int, v any) error
+
+	// OnRowErrorFunc function to be called every time a row has errors, before the errors are added to
+	// the result (optional). The func receives the same RowErrors object which ends up in the result
+	// errors, so it can modify the cell errors such as setting localization keys. If the func returns
+	// `true`, the decoding stops after the row even StopOnError is `false`.
+	OnRowErrorFunc func(rowErr *RowErrors) (stop bool)
+
+	// NullValues a list of cell texts to be treated as no value such as `N/A`, `NULL` (optional).
+	// When a cell text matches, the field gets its zero value and validators are skipped.
+	NullValues []string
+
+	// NullValuesIgnoreCase compare the cell texts with the null values case-insensitively (default is `false`)
+	NullValuesIgnoreCase bool
+
+	// InferInterfaceTypes decode the cells of `any` fields as values of the inferred types instead of strings
+	// (default is `false`). The types are tried in the order: int64, float64 (finite values only), bool
+	// (the texts accepted by strconv.ParseBool), then string. DecodeColumnConfig.DecodeFunc takes precedence.
+	InferInterfaceTypes bool
+
+	// NumberFormat format of numbers to decode int, uint and float columns, e.g. `1,234.56` or `1.234,56`
+	// (optional). Other columns are not affected.
+	NumberFormat *NumberFormat
+
+	// MaxRows maximum number of data rows to be decoded, the header is not counted (default is `0` - no limit).
+	// When the input has more rows, the remaining rows are ignored and DecodeResult.Truncated() returns `true`.
+	MaxRows int
+
+	// ErrorOnMaxRows return ErrMaxRowsExceeded instead of truncating the data when the input has more
+	// rows than MaxRows (default is `false`)
+	ErrorOnMaxRows bool
+
+	// MaxErrors maximum number of errors to be collected when StopOnError is `false` (default is `0` - no limit).
+	// When the number of row and cell errors reaches this value, the decoding stops after the current row
+	// and ErrTooManyErrors is added to the result errors, Errors.Truncated() returns `true` then.
+	MaxErrors int
+
+	// ProgressFunc function to report the decoding progress, e.g. to display a progress bar (optional).
+	// The func is called every ProgressInterval decoded rows and one final time when the decoding ends.
+	// `totalRows` is the number of data rows of the input, it is `-1` when the input is not read
+	// completely yet. The func is never called concurrently.
+	ProgressFunc func(processedRows, totalRows int)
+
+	// ProgressInterval number of decoded rows between calls of ProgressFunc (default is `1000`)
+	ProgressInterval int
+
+	// OnRowDecoded function to be called after every data row is processed, even when the row has errors
+	// (optional). `row` is the number of processed rows, `total` is the same as `totalRows` of ProgressFunc.
+	// The func is called in the decoding loop, it should not block (e.g. send to a buffered channel).
+	OnRowDecoded func(row int, total int)
+
+	// WorkerCount number of goroutines to decode rows concurrently when calling Decode (default is `1`).
+	// Preprocessor, validator and other custom functions must be safe for concurrent use when this is
+	// greater than 1. Rows are still decoded one by one when the struct has inline columns or when
+	// decoding into maps. DecodeOne and the other row-by-row functions are not affected.
+	WorkerCount int
+
+	// AppendToSlice append the decoded items to the existing items of the output slice instead of
+	// replacing them when calling Decode (default is `false`). The output slice is not modified when
+	// the decoding fails.
+	AppendToSlice bool
+
 	// columnConfigMap a map consists of configuration for specific columns
 	columnConfigMap map[string]*DecodeColumnConfig
 }
@@ -68,6 +291,9 @@ func defaultDecodeConfig() *DecodeConfig {
 		StopOnError:                    true,
 		RequireColumnOrder:             true,
 		TreatIncorrectStructureAsError: true,
+		ProgressInterval:               defaultProgressInterval,
+		MaxUnrecognizedValues:          defaultMaxUnrecognizedValues,
+		WorkerCount:                    1,
 	}
 }
 
@@ -83,8 +309,26 @@ func (c *DecodeConfig) ConfigureColumn(name string, fn func(*DecodeColumnConfig)
 	fn(columnCfg)
 }
 
+// columnConfig gets the configuration of the column by its name, or by the first of its aliases
+// having configuration
+func (c *DecodeConfig) columnConfig(name string, aliases []string) *DecodeColumnConfig {
+	if columnCfg, ok := c.columnConfigMap[name]; ok {
+		return columnCfg
+	}
+	for _, alias := range aliases {
+		if columnCfg, ok := c.columnConfigMap[alias]; ok {
+			return columnCfg
+		}
+	}
+	return nil
+}
+
 // DecodeColumnConfig configuration for decoding a specific column
 type DecodeColumnConfig struct {
+	// HeaderText text of the column in the input header, replaces the column name from the struct tag
+	// and takes precedence over DecodeConfig.ParseLocalizedHeader (optional)
+	HeaderText string
+
 	// TrimSpace if `true` and DecodeConfig.TrimSpace is `false`, only trim space this column
 	// (default is "false")
 	TrimSpace bool
@@ -96,12 +340,46 @@ type DecodeColumnConfig struct {
 	// DecodeFunc custom decode function (optional)
 	DecodeFunc DecodeFunc
 
+	// TimeLayout layout to decode time.Time values of this column, overrides DecodeConfig.TimeLayout (optional)
+	TimeLayout string
+
+	// BoolTrueValues texts to be decoded as `true`, overrides DecodeConfig.BoolTrueValues (optional)
+	BoolTrueValues []string
+
+	// BoolFalseValues texts to be decoded as `false`, overrides DecodeConfig.BoolFalseValues (optional)
+	BoolFalseValues []string
+
+	// Aliases alternative header names of the column, tried in order after the aliases set via struct tag
+	// when the column name is not found in the input header. Not applied for inline columns (optional)
+	Aliases []string
+
+	// DefaultValue value to be decoded when the column is missing from the input or the cell is empty
+	// and the column is not `omitempty`, overrides the tag option `default=` (optional)
+	DefaultValue string
+
+	// NullValues a list of cell texts to be treated as no value, overrides DecodeConfig.NullValues (optional)
+	NullValues []string
+
+	// OnErrorUseFallback when a cell fails to be decoded, decode FallbackValue instead (default is `false`).
+	// The original error is recorded in DecodeResult.Warnings() and the decoding continues normally.
+	// Validation errors are not affected.
+	OnErrorUseFallback bool
+
+	// FallbackValue value to be decoded when OnErrorUseFallback is `true` and a cell fails to be decoded.
+	// If this is empty, the field gets its zero value (optional)
+	FallbackValue string
+
 	// PreprocessorFuncs a list of functions will be called before decoding a cell value (optional)
 	PreprocessorFuncs []ProcessorFunc
 
 	// ValidatorFuncs a list of functions will be called after decoding (optional)
 	ValidatorFuncs []ValidatorFunc
 
+	// InlineValidatorFuncs a list of functions will be called once per row with the value of the whole
+	// dynamic inline column (e.g. `InlineColumn[int]`) after all its cells are decoded, e.g. ValidatorInlineLen.
+	// Only applied for dynamic inline columns (optional)
+	InlineValidatorFuncs []ValidatorFunc
+
 	// OnCellErrorFunc function will be called every time an error happens when decode a cell.
 	// This func can be helpful to set localization key and additional params for the error
 	// to localize the error message later on. (optional)
@@ -115,25 +393,147 @@ func defaultDecodeColumnConfig() *DecodeColumnConfig {
 // DecodeOption function to modify decoding config
 type DecodeOption func(cfg *DecodeConfig)
 
+const (
+	// decodeChunkSize number of rows to be read from the input at once when decoding
+	decodeChunkSize = 10000
+
+	// defaultProgressInterval number of decoded rows between calls of the progress func by default
+	defaultProgressInterval = 1000
+
+	// defaultMaxUnrecognizedValues number of values captured for every unrecognized column by default
+	defaultMaxUnrecognizedValues = 1000
+
+	// utf8BOM byte order mark of UTF-8 encoded data
+	utf8BOM = "\uFEFF"
+)
+
+// ColumnMapping mapping of an input column to the struct field receiving its data
+type ColumnMapping struct {
+	// FileColumn index of the column in the input data
+	FileColumn int
+	// HeaderText header of the column as read from the input data
+	HeaderText string
+	// HeaderKey name of the column declared in the struct tag (with prefix for inline columns)
+	HeaderKey string
+	// StructField name of the struct field, for inline columns, this is the name of the inline field.
+	// Empty for unrecognized columns.
+	StructField string
+	// Unrecognized the column is not declared in the struct and its data are ignored
+	Unrecognized bool
+}
+
 // DecodeResult decoding result
 type DecodeResult struct {
 	totalRow               int
+	filteredRows           int
+	skippedRows            int
+	skippedEmptyRows       int
+	truncated              bool
+	warnings               *Errors
+	fallbackCounts         map[string]int
+	parsedHeader           []string
+	structHeader           []string
+	usedAliases            map[string]string
+	renamedColumns         map[string]string
+	columnMapping          []ColumnMapping
 	unrecognizedColumns    []string
+	unrecognizedValues     map[string][]string
 	missingOptionalColumns []string
+	missingRequiredColumns []string
 }
 
+// TotalRow gets the total number of rows have been read from the input (including the header).
+// As the input data are read incrementally, this value is only accurate after all the data are decoded.
+// When decoding stops on an error, the rows after the stopping point are not counted.
 func (r *DecodeResult) TotalRow() int {
 	return r.totalRow
 }
 
+// FilteredRows gets the number of rows skipped by DecodeConfig.RowFilterFunc and DecodeConfig.RowFilterByRowFunc
+func (r *DecodeResult) FilteredRows() int {
+	return r.filteredRows
+}
+
+// SkippedEmptyRows gets the number of rows skipped by DecodeConfig.SkipEmptyRows
+func (r *DecodeResult) SkippedEmptyRows() int {
+	return r.skippedEmptyRows
+}
+
+// SkippedRows gets the number of rows skipped by DecodeConfig.SkipInitialRows, DecodeConfig.HeaderRowIndex,
+// DecodeConfig.SkipRows and DecodeConfig.CommentChar
+func (r *DecodeResult) SkippedRows() int {
+	return r.skippedRows
+}
+
+// Truncated returns `true` when the input has more data rows than DecodeConfig.MaxRows and
+// the remaining rows are ignored
+func (r *DecodeResult) Truncated() bool {
+	return r.truncated
+}
+
+// Warnings gets the errors of the cells decoded with the fallback values of their columns
+// (see DecodeColumnConfig.OnErrorUseFallback). Returns `nil` when there is no warning.
+func (r *DecodeResult) Warnings() *Errors {
+	return r.warnings
+}
+
+// FallbackCounts gets the number of cells decoded with the fallback values, keyed by column header
+func (r *DecodeResult) FallbackCounts() map[string]int {
+	return r.fallbackCounts
+}
+
+// UsedAliases gets the aliases used to match the columns in the input header.
+// The map is keyed by the column names declared in the struct tags.
+func (r *DecodeResult) UsedAliases() map[string]string {
+	return r.usedAliases
+}
+
+// RenamedColumns gets the columns of the input header renamed by DecodeConfig.DeduplicateHeaders.
+// The map is keyed by the new names, the values are the duplicated names.
+func (r *DecodeResult) RenamedColumns() map[string]string {
+	return r.renamedColumns
+}
+
+// ColumnMapping gets the mapping of the input columns to the struct fields in the input column order.
+// Returns `nil` when decoding into maps.
+func (r *DecodeResult) ColumnMapping() []ColumnMapping {
+	return r.columnMapping
+}
+
+// ParsedHeader gets the header as read from the input data, before HeaderNormalizeFunc and ColumnNameMap
+// are applied. Returns `nil` in NoHeaderMode.
+func (r *DecodeResult) ParsedHeader() []string {
+	return r.parsedHeader
+}
+
+// StructHeader gets the column names derived from the struct tags in struct order, including the columns
+// missing from the input data. A dynamic inline column is represented by its name only (with prefix).
+// Returns `nil` when decoding into maps.
+func (r *DecodeResult) StructHeader() []string {
+	return r.structHeader
+}
+
 func (r *DecodeResult) UnrecognizedColumns() []string {
 	return r.unrecognizedColumns
 }
 
+// UnrecognizedColumnValues gets the raw cell values of the unrecognized columns captured when
+// DecodeConfig.CaptureUnrecognizedValues is set, keyed by the names returned by UnrecognizedColumns().
+// At most DecodeConfig.MaxUnrecognizedValues values of the first rows are kept for every column.
+func (r *DecodeResult) UnrecognizedColumnValues() map[string][]string {
+	return r.unrecognizedValues
+}
+
 func (r *DecodeResult) MissingOptionalColumns() []string {
 	return r.missingOptionalColumns
 }
 
+// MissingRequiredColumns gets the non-optional columns missing from the input header, they are reported
+// only when DecodeConfig.MissingRequiredColumnPolicy is MissingRequiredColumnFillZero
+func (r *DecodeResult) MissingRequiredColumns() []string {
+	return r.missingRequiredColumns
+}
+
 // Decoder data structure of the default decoder
 type Decoder struct {
 	r                       Reader
@@ -141,12 +541,35 @@ type Decoder struct {
 	err                     *Errors
 	result                  *DecodeResult
 	finished                bool
-	rowsData                []*rowData
 	itemType                reflect.Type
-	shouldStop              bool
+	shouldStop              int32
 	hasDynamicInlineColumns bool
 	hasFixedInlineColumns   bool
 	colsMeta                []*decodeColumnMeta
+	missingColsMeta         []*decodeColumnMeta
+	inlineColsMeta          []*decodeColumnMeta
+	header                  []string
+	rawHeader               []string
+	structHeader            []string
+	nextRow                 int
+	readRows                int
+	errCount                int
+	processedRows           int
+	reportedRows            int
+	reportedTotal           int
+	readerEOF               bool
+	prepared                bool
+	resetPending            bool
+	restoreFieldsPerRecord  bool
+	trimFieldsPerRecord     bool
+	mapMode                 bool
+	firstRecordRead         bool
+	recordBOMLen            int
+	restField               *reflect.StructField
+	ioReaderErr             error
+	fromIOReader            bool
+	headerPeeked            bool
+	peekedHeader            []string
 }
 
 // NewDecoder creates a new Decoder object
@@ -162,13 +585,112 @@ func NewDecoder(r Reader, options ...DecodeOption) *Decoder {
 	}
 }
 
+// NewDecoderFromReader creates a new Decoder object reading CSV data from the given io.Reader.
+// The data are transformed via DecodeConfig.TransformReader and converted from DecodeConfig.SourceCharset
+// before being parsed by a `csv.Reader`, whose delimiter can be detected via DecodeConfig.AutoDetectDelimiter.
+// An unsupported charset fails the decoding with ErrConfigOptionInvalid.
+func NewDecoderFromReader(r io.Reader, options ...DecodeOption) *Decoder {
+	return NewDecoderFromReaderWithCSVOptions(r, nil, options...)
+}
+
+// NewDecoderFromReaderWithCSVOptions the same as NewDecoderFromReader, the given func is called to
+// tune the created `csv.Reader` (e.g. to set LazyQuotes). DecodeConfig.Comma and DecodeConfig.Comment
+// take precedence over the settings of the func.
+func NewDecoderFromReaderWithCSVOptions(r io.Reader, csvOpts func(*csv.Reader), options ...DecodeOption) *Decoder {
+	d := NewDecoder(nil, options...)
+	d.fromIOReader = true
+	if d.cfg.TransformReader != nil {
+		r = d.cfg.TransformReader(r)
+	}
+	r, err := newCharsetReader(r, d.cfg.SourceCharset)
+	if err != nil {
+		d.ioReaderErr = err
+		return d
+	}
+	comma := ','
+	if d.cfg.AutoDetectDelimiter {
+		br := bufio.NewReaderSize(r, delimiterSampleSize)
+		sample, err := br.Peek(delimiterSampleSize)
+		eof := errors.Is(err, io.EOF)
+		if err != nil && !eof {
+			d.ioReaderErr = err
+			return d
+		}
+		if comma, err = detectDelimiter(sample, eof, nil); err != nil {
+			d.ioReaderErr = err
+			return d
+		}
+		r = br
+	}
+	csvReader := csv.NewReader(r)
+	csvReader.Comma = comma
+	if csvOpts != nil {
+		csvOpts(csvReader)
+	}
+	d.r = csvReader
+	return d
+}
+
+// ReadHeader reads the header of the input data before decoding, e.g. to let users map the columns.
+// The header is cached, so the following Decode or DecodeOne call doesn't read it again. HeaderNormalizeFunc
+// and ColumnNameMap are applied to the returned header. In NoHeaderMode, the returned header is empty.
+// This func returns ErrUnexpected if the decoding has started.
+func (d *Decoder) ReadHeader() ([]string, error) {
+	if d.itemType != nil || d.finished || d.stopped() {
+		return nil, fmt.Errorf("%w: decoding already started", ErrUnexpected)
+	}
+	if !d.headerPeeked {
+		if err := d.validateConfig(); err != nil {
+			return nil, err
+		}
+		d.configureCSVReader()
+		d.result = &DecodeResult{}
+		fileHeader, err := d.readFileHeader()
+		if err != nil {
+			d.err.Add(err)
+			d.stop()
+			return nil, err
+		}
+		d.headerPeeked = true
+		d.peekedHeader = fileHeader
+	}
+	return append([]string(nil), d.peekedHeader...), nil
+}
+
 // Decode decode input data and store the result in the given variable.
 // The input var must be a pointer to a slice, e.g. `*[]Student` (recommended) or `*[]*Student`.
+// To decode data without a predefined struct, pass `*[]map[string]string`, each row becomes
+// a map keyed by the header columns (or by `col0`, `col1`... in NoHeaderMode).
 func (d *Decoder) Decode(v any) (*DecodeResult, error) {
+	return d.DecodeContext(context.Background(), v)
+}
+
+// DecodeContext the same as Decode, but the context is checked between rows.
+// When the context is done, the decoding stops and the context error is added to the result errors.
+// Errors collected so far are still accessible via Finish().
+func (d *Decoder) DecodeContext(ctx context.Context, v any) (*DecodeResult, error) {
+	return d.decode(ctx, v, false)
+}
+
+// Validate decode input data the same way as Decode, but the decoded items are discarded chunk by chunk
+// instead of being stored, e.g. to check a large file before uploading it. The given var must be of
+// the type accepted by Decode (e.g. `*[]Student`), it is not modified. The returned errors are the same
+// as the ones of Decode.
+func (d *Decoder) Validate(v any) (*DecodeResult, error) {
+	return d.ValidateContext(context.Background(), v)
+}
+
+// ValidateContext the same as Validate, but the context is checked between rows
+func (d *Decoder) ValidateContext(ctx context.Context, v any) (*DecodeResult, error) {
+	return d.decode(ctx, v, true)
+}
+
+// decode decode input data into the given var, the decoded items are discarded if validateOnly is `true`
+func (d *Decoder) decode(ctx context.Context, v any, validateOnly bool) (*DecodeResult, error) {
 	if d.finished {
 		return nil, ErrFinished
 	}
-	if d.shouldStop {
+	if d.stopped() {
 		return nil, ErrAlreadyFailed
 	}
 
@@ -176,7 +698,7 @@ func (d *Decoder) Decode(v any) (*DecodeResult, error) {
 	if d.itemType == nil {
 		if err := d.prepareDecode(val); err != nil {
 			d.err.Add(err)
-			d.shouldStop = true
+			d.stop()
 			return nil, d.err
 		}
 	} else {
@@ -187,40 +709,78 @@ func (d *Decoder) Decode(v any) (*DecodeResult, error) {
 		if itemType != d.itemType {
 			return nil, fmt.Errorf("%w: %v (expect %v)", ErrTypeUnmatched, itemType, d.itemType)
 		}
+		if d.resetPending {
+			if err = d.prepareDecodeAfterReset(); err != nil {
+				d.err.Add(err)
+				d.stop()
+				return nil, d.err
+			}
+		}
 	}
 
-	outSlice := reflect.MakeSlice(val.Type().Elem(), len(d.rowsData), len(d.rowsData))
+	sliceType := val.Type().Elem()
+	outSlice := reflect.MakeSlice(sliceType, 0, 0)
+	if d.cfg.AppendToSlice && !validateOnly {
+		outSlice = val.Elem()
+	}
 	itemKindIsPtr := d.itemType.Kind() == reflect.Pointer
-	row := 0
-	for !d.shouldStop && len(d.rowsData) > 0 {
-		// Reduce memory consumption by splitting the source data into chunks (10000 items each)
-		// After each chunk is processed, resize the slice to allow Go to free the memory when necessary
-		chunkSz := gofn.Min(10000, len(d.rowsData)) //nolint:mnd
-		chunk := d.rowsData[0:chunkSz]
-		d.rowsData = d.rowsData[chunkSz:]
-
-		for _, rowData := range chunk {
-			rowVal := outSlice.Index(row)
-			row++
+	chunk := make([]*rowData, 0, decodeChunkSize)
+	for !d.stopped() {
+		// Reduce memory consumption by reading the source data in chunks (10000 rows each).
+		// Only raw data of the current chunk is kept, processed rows can be freed by Go when necessary.
+		var err error
+		chunk, err = d.readRowDataChunk(chunk[:0], decodeChunkSize)
+		if err != nil {
+			d.err.Add(err)
+			d.stop()
+			d.reportProgress(true)
+			return nil, d.err
+		}
+		if len(chunk) == 0 {
+			break
+		}
+
+		start := outSlice.Len()
+		if validateOnly {
+			// Only the items of the current chunk are kept
+			start, outSlice = 0, reflect.MakeSlice(sliceType, len(chunk), len(chunk))
+		} else {
+			outSlice = reflect.AppendSlice(outSlice, reflect.MakeSlice(sliceType, len(chunk), len(chunk)))
+		}
+		if d.canDecodeInParallel() {
+			d.addProcessedRows(d.decodeChunkInParallel(ctx, chunk, outSlice, start))
+			continue
+		}
+		for i, rowData := range chunk {
+			if err := d.checkContext(ctx); err != nil {
+				break
+			}
+			rowVal := outSlice.Index(start + i)
 			if itemKindIsPtr {
 				rowVal.Set(reflect.New(d.itemType.Elem()))
 				rowVal = rowVal.Elem()
 			}
-			if err := d.decodeRow(rowData, rowVal); err != nil {
-				d.err.Add(err)
-				if d.cfg.StopOnError || d.shouldStop {
-					d.shouldStop = true
+			err := d.decodeRow(rowData, rowVal)
+			d.addRowWarnings(rowData)
+			d.addProcessedRows(1)
+			if err != nil {
+				d.addRowError(err)
+				if d.cfg.StopOnError || d.stopped() {
+					d.stop()
 					break
 				}
 			}
 		}
 	}
 
+	d.reportProgress(true)
 	if d.err.HasError() {
 		return d.result, d.err
 	}
-	val.Elem().Set(outSlice)
-	d.finished = len(d.rowsData) == 0
+	if !validateOnly {
+		val.Elem().Set(outSlice)
+	}
+	d.finished = true
 	return d.result, nil
 }
 
@@ -229,45 +789,254 @@ func (d *Decoder) Decode(v any) (*DecodeResult, error) {
 // This func returns error of the current row processing only, after finishing the last row decoding,
 // call Finish() to get the overall result and error.
 func (d *Decoder) DecodeOne(v any) error {
+	return d.DecodeOneContext(context.Background(), v)
+}
+
+// DecodeOneContext the same as DecodeOne, but the context is checked before decoding the row.
+// When the context is done, the context error is returned and the decoder can't decode more.
+func (d *Decoder) DecodeOneContext(ctx context.Context, v any) error {
+	_, err := d.decodeOne(ctx, v)
+	return err
+}
+
+// decodeOne decode the next one row data, returns the data of the decoded row
+func (d *Decoder) decodeOne(ctx context.Context, v any) (*rowData, error) {
 	if d.finished {
-		return ErrFinished
+		return nil, ErrFinished
 	}
-	if d.shouldStop {
-		return ErrAlreadyFailed
+	if d.stopped() {
+		return nil, ErrAlreadyFailed
 	}
 
 	rowVal := reflect.ValueOf(v)
 	rowVal, itemType, err := d.parseOutputVarOne(rowVal)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if d.itemType == nil {
 		if err := d.prepareDecode(reflect.New(reflect.SliceOf(itemType))); err != nil {
 			d.err.Add(err)
-			d.shouldStop = true
-			return err
+			d.stop()
+			return nil, err
 		}
 	} else {
 		if itemType != d.itemType {
-			return fmt.Errorf("%w: %v (expect %v)", ErrTypeUnmatched, itemType, d.itemType)
+			return nil, fmt.Errorf("%w: %v (expect %v)", ErrTypeUnmatched, itemType, d.itemType)
+		}
+		if d.resetPending {
+			if err = d.prepareDecodeAfterReset(); err != nil {
+				d.err.Add(err)
+				d.stop()
+				return nil, err
+			}
 		}
 	}
 
-	if len(d.rowsData) == 0 {
+	if err = d.checkContext(ctx); err != nil {
+		return nil, err
+	}
+	rowData, err := d.readNextRow()
+	if err != nil {
+		d.err.Add(err)
+		d.stop()
+		return nil, err
+	}
+	if rowData == nil {
 		d.finished = true
-		return ErrFinished
+		d.reportProgress(true)
+		return nil, ErrFinished
 	}
-	rowData := d.rowsData[0]
-	d.rowsData = d.rowsData[1:]
 	err = d.decodeRow(rowData, rowVal)
+	d.addRowWarnings(rowData)
+	d.addProcessedRows(1)
 	if err != nil {
-		d.err.Add(err)
+		d.addRowError(err)
 		if d.cfg.StopOnError {
-			d.shouldStop = true
+			d.stop()
 		}
 	}
-	d.finished = len(d.rowsData) == 0
-	return err
+	return rowData, err
+}
+
+// DecodeStream decodes the input data row by row in a separate goroutine and sends the decoded
+// items to the returned channel. Rows are read and decoded incrementally, so the whole input data
+// is never loaded into memory at once. Type `T` must be a struct type, e.g. `Student`.
+//
+// Rows having errors are not sent to the item channel. The error channel receives at most one error
+// which is the overall decoding error (the same one returned by Finish()). When the context is done,
+// the processing stops and the decoder fails (following decoding calls return ErrAlreadyFailed), the error
+// is the *Errors containing the context error and the errors of rows collected so far rather than the bare
+// context error (use errors.Is to check it), Finish() is not called and returns the same error.
+// Both channels are closed when the processing completes.
+// The decoder must not be used by any other goroutine until the channels are closed.
+func DecodeStream[T any](ctx context.Context, d *Decoder) (<-chan T, <-chan error) {
+	itemCh := make(chan T)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(errCh)
+		defer close(itemCh)
+
+		for {
+			var item T
+			if err := d.DecodeOneContext(ctx, &item); err != nil {
+				if ctx.Err() != nil && d.stopped() {
+					// The decoder is not finished, Finish() can still be called to get the result
+					errCh <- d.err
+					return
+				}
+				if errors.Is(err, ErrFinished) || d.stopped() {
+					break
+				}
+				if _, ok := err.(*RowErrors); ok { // nolint: errorlint
+					continue
+				}
+				errCh <- err
+				return
+			}
+			select {
+			case itemCh <- item:
+			case <-ctx.Done():
+				_ = d.checkContext(ctx)
+				errCh <- d.err
+				return
+			}
+		}
+
+		if _, err := d.Finish(); err != nil {
+			errCh <- err
+		}
+	}()
+
+	return itemCh, errCh
+}
+
+// DecodeEach decodes the input data row by row and calls the given function for every successfully
+// decoded item. Type `T` must be a struct type, e.g. `Student`. Rows are read and decoded incrementally,
+// so the whole input data is never loaded into memory at once.
+//
+// If the function returns an error, the decoding stops and the error is added to the result errors
+// as a RowErrors of the current row. This func calls Finish() at the end and returns its result.
+func DecodeEach[T any](d *Decoder, fn func(T) error) (*DecodeResult, error) {
+	for {
+		var item T
+		rowData, err := d.decodeOne(context.Background(), &item)
+		if err != nil {
+			if errors.Is(err, ErrFinished) || d.stopped() {
+				break
+			}
+			if _, ok := err.(*RowErrors); ok { // nolint: errorlint
+				continue
+			}
+			return nil, err
+		}
+		if err = fn(item); err != nil {
+			rowErr := NewRowErrors(rowData.row, rowData.line)
+			rowErr.Add(err)
+			d.err.Add(rowErr)
+			d.stop()
+			break
+		}
+	}
+	return d.Finish()
+}
+
+// DecodeEachBatch decodes the input data row by row and calls the given function with every batch of
+// at most `batchSize` successfully decoded items, e.g. to insert them into a database in bulk.
+// Type `T` must be a struct type, e.g. `Student`. Only the items of the current batch are kept in memory.
+//
+// When the decoding stops due to a row error, the function is still called with the items decoded before
+// the error. If the function returns an error, the decoding stops and the error is added to the result
+// errors. This func calls Finish() at the end and returns its result.
+func DecodeEachBatch[T any](d *Decoder, batchSize int, fn func([]T) error) (*DecodeResult, error) {
+	if batchSize <= 0 {
+		return nil, fmt.Errorf("%w: batch size must be positive", ErrConfigOptionInvalid)
+	}
+	batch := make([]T, 0, batchSize)
+	flush := func() bool {
+		if len(batch) == 0 {
+			return true
+		}
+		err := fn(batch)
+		batch = make([]T, 0, batchSize)
+		if err != nil {
+			d.err.Add(err)
+			d.stop()
+			return false
+		}
+		return true
+	}
+	for {
+		var item T
+		err := d.DecodeOne(&item)
+		if err != nil {
+			if errors.Is(err, ErrFinished) || d.stopped() {
+				break
+			}
+			if _, ok := err.(*RowErrors); ok { // nolint: errorlint
+				continue
+			}
+			return nil, err
+		}
+		batch = append(batch, item)
+		if len(batch) == batchSize && !flush() {
+			return d.Finish()
+		}
+	}
+	flush()
+	return d.Finish()
+}
+
+// Header gets the header as read from the input data (see DecodeResult.ParsedHeader).
+// Returns `nil` before the first decoding call or in NoHeaderMode.
+func (d *Decoder) Header() []string {
+	if d.result == nil {
+		return nil
+	}
+	return d.result.parsedHeader
+}
+
+// Reset resets the decoder to decode data from the new reader.
+// The parsed struct metadata and the built column decoders are kept to be reused for decoding the new
+// data of the same item type. The header of the new data must match the header of the previous data,
+// otherwise the first decoding call will fail.
+func (d *Decoder) Reset(r Reader) {
+	d.r = r
+	d.err = NewErrors()
+	d.firstRecordRead = false
+	d.finished = false
+	atomic.StoreInt32(&d.shouldStop, 0)
+	d.readerEOF = false
+	d.readRows = 0
+	d.errCount = 0
+	d.processedRows = 0
+	d.reportedRows = 0
+	d.reportedTotal = 0
+	d.headerPeeked = false
+	d.peekedHeader = nil
+	if !d.prepared {
+		// Previous preparation failed or has not been performed, start over
+		d.result = nil
+		d.itemType = nil
+		d.colsMeta = nil
+		d.missingColsMeta = nil
+		d.inlineColsMeta = nil
+		d.header = nil
+		d.rawHeader = nil
+		d.structHeader = nil
+		d.hasDynamicInlineColumns = false
+		d.hasFixedInlineColumns = false
+		d.mapMode = false
+		d.restField = nil
+		return
+	}
+	d.result = &DecodeResult{
+		structHeader:           d.structHeader,
+		unrecognizedColumns:    d.result.unrecognizedColumns,
+		missingOptionalColumns: d.result.missingOptionalColumns,
+		missingRequiredColumns: d.result.missingRequiredColumns,
+	}
+	d.resetPending = true
 }
 
 // Finish decoding, after calling this func, you can't decode more even there is data
@@ -282,18 +1051,27 @@ func (d *Decoder) Finish() (*DecodeResult, error) {
 // prepareDecode prepare for decoding by parsing the struct tags and build column decoders.
 // This step is performed one time only before the first row decoding.
 func (d *Decoder) prepareDecode(v reflect.Value) error {
-	d.result = &DecodeResult{}
+	if !d.headerPeeked {
+		d.result = &DecodeResult{}
+	}
 	itemType, err := d.parseOutputVar(v)
 	if err != nil {
 		return err
 	}
 	d.itemType = itemType
+	d.mapMode = isStringMapType(itemType)
 
 	if err = d.validateConfig(); err != nil {
 		return err
 	}
+	d.configureCSVReader()
 
-	if err = d.parseColumnsMeta(itemType); err != nil { // typ: []Item, itemTyp: Item
+	if d.mapMode {
+		err = d.parseColumnsMetaForMap()
+	} else {
+		err = d.parseColumnsMeta(itemType) // typ: []Item, itemTyp: Item
+	}
+	if err != nil {
 		return err
 	}
 
@@ -301,18 +1079,118 @@ func (d *Decoder) prepareDecode(v reflect.Value) error {
 		return err
 	}
 
-	if err = d.readRowData(); err != nil {
+	d.result.structHeader = d.structHeader
+	for _, colMeta := range d.colsMeta {
+		d.header = append(d.header, colMeta.headerText)
+	}
+	d.buildColumnMapping()
+	d.prepareRowReading()
+	d.prepared = true
+	return nil
+}
+
+// prepareDecodeAfterReset prepare for decoding the new input set by Reset().
+// The header of the new input is validated against the cached columns metadata.
+func (d *Decoder) prepareDecodeAfterReset() error {
+	d.resetPending = false
+	d.configureCSVReader()
+	fileHeader, err := d.readFileHeader()
+	if err != nil {
 		return err
 	}
+	if d.mapMode {
+		if err = d.buildColumnsMetaForMap(fileHeader); err != nil {
+			return err
+		}
+		d.header = fileHeader
+	} else if !d.cfg.NoHeaderMode {
+		if err = d.validateHeaderUnchanged(fileHeader); err != nil {
+			return err
+		}
+	}
+	d.buildColumnMapping()
+	d.prepareRowReading()
+	return nil
+}
 
-	totalRow := len(d.rowsData)
-	if !d.cfg.NoHeaderMode {
-		totalRow++
+// configureCSVReader apply DecodeConfig.Comma and DecodeConfig.Comment to the reader if it is a csv.Reader
+func (d *Decoder) configureCSVReader() {
+	csvReader, ok := d.r.(*csv.Reader)
+	if !ok {
+		return
 	}
-	d.result.totalRow = totalRow
-	d.err.totalRow = totalRow
+	if d.cfg.Comma != 0 {
+		csvReader.Comma = d.cfg.Comma
+	}
+	if d.cfg.Comment != 0 {
+		csvReader.Comment = d.cfg.Comment
+	}
+}
+
+// buildColumnMapping build the mapping of the input columns to the struct fields for the result
+func (d *Decoder) buildColumnMapping() {
+	if d.mapMode {
+		return
+	}
+	mapping := make([]ColumnMapping, 0, len(d.colsMeta))
 	for _, colMeta := range d.colsMeta {
-		d.err.header = append(d.err.header, colMeta.headerText)
+		m := ColumnMapping{
+			FileColumn:   colMeta.column,
+			HeaderText:   d.getRawHeader(colMeta.column, colMeta.headerText),
+			HeaderKey:    colMeta.headerKey,
+			Unrecognized: colMeta.unrecognized,
+		}
+		switch {
+		case colMeta.rest:
+			m.StructField = d.restField.Name
+		case !colMeta.unrecognized:
+			m.StructField = colMeta.targetField.Name
+		}
+		mapping = append(mapping, m)
+	}
+	d.result.columnMapping = mapping
+}
+
+// prepareRowReading prepare for reading data rows of the input
+func (d *Decoder) prepareRowReading() {
+	d.nextRow = 1 + d.result.skippedRows
+	if !d.cfg.NoHeaderMode {
+		d.nextRow++
+	}
+	d.setTotalRow(d.nextRow - 1)
+	d.err.header = d.header
+	if d.rawHeader != nil {
+		d.err.header = d.rawHeader
+	}
+	// In NoHeaderMode, comment rows and empty rows can be the first rows of the input, prevent the built-in
+	// csv.Reader from taking their number of fields as the expected one
+	if csvReader, ok := d.r.(*csv.Reader); ok && d.cfg.NoHeaderMode && (d.cfg.CommentChar != 0 || d.cfg.SkipEmptyRows) {
+		d.restoreFieldsPerRecord = csvReader.FieldsPerRecord == 0
+	}
+	// In NoHeaderMode, the built-in csv.Reader takes the number of fields of the first row as the expected one,
+	// it must not count the trailing empty cells dropped by AllowTrailingEmptyColumns
+	if csvReader, ok := d.r.(*csv.Reader); ok && d.cfg.NoHeaderMode && d.cfg.AllowTrailingEmptyColumns {
+		d.trimFieldsPerRecord = csvReader.FieldsPerRecord == 0
+	}
+}
+
+// validateHeaderUnchanged validate to make sure the file header matches the cached header
+func (d *Decoder) validateHeaderUnchanged(fileHeader []string) error {
+	mapIndex := make(map[string]int, len(d.header))
+	for i, h := range d.header {
+		mapIndex[h] = i
+	}
+	for i, h := range fileHeader {
+		index, ok := mapIndex[h]
+		if !ok {
+			return fmt.Errorf("%w: \"%s\"", ErrHeaderColumnUnrecognized, h)
+		}
+		if index != i {
+			return fmt.Errorf("%w: %v (expect %v)", ErrHeaderColumnOrderInvalid, fileHeader, d.header)
+		}
+	}
+	if len(fileHeader) < len(d.header) {
+		return fmt.Errorf("%w: \"%s\"", ErrHeaderColumnRequired, d.header[len(fileHeader)])
 	}
 	return nil
 }
@@ -327,6 +1205,9 @@ func (d *Decoder) decodeRow(rowData *rowData, rowVal reflect.Value) error {
 		rowErr.Add(d.handleCellError(rowData.err, "", nil))
 		return rowErr
 	}
+	if d.mapMode {
+		return d.decodeRowAsMap(rowData, rowVal)
+	}
 
 	if d.hasDynamicInlineColumns || d.hasFixedInlineColumns {
 		for _, colMeta := range colsMeta {
@@ -337,48 +1218,254 @@ func (d *Decoder) decodeRow(rowData *rowData, rowVal reflect.Value) error {
 	}
 
 	var cellErrs []error
+	var restValues map[string]string
 	for col, cellText := range rowData.records {
+		if col >= len(colsMeta) {
+			// Extra cells in NoHeaderMode are treated the same as unrecognized columns
+			if cfg.AllowUnrecognizedColumns {
+				break
+			}
+			cellErrs = append(cellErrs, d.handleCellError(
+				fmt.Errorf("%w: row %d", ErrDecodeRowFieldCount, rowData.row), "", nil))
+			if cfg.StopOnError {
+				d.stop()
+			}
+			break
+		}
 		colMeta := colsMeta[col]
 		if colMeta.unrecognized {
 			continue
 		}
-		if cfg.TrimSpace || colMeta.trimSpace {
-			cellText = strings.TrimSpace(cellText)
+		if colMeta.rest {
+			if restValues == nil {
+				restValues = make(map[string]string, len(rowData.records)-col)
+			}
+			restValues[d.getRawHeader(col, colMeta.headerText)] = d.preprocessCell(cellText, colMeta)
+			continue
 		}
-		for _, fn := range colMeta.preprocessorFuncs {
-			cellText = fn(cellText)
+		cellText = d.preprocessCell(cellText, colMeta)
+		outVal := d.getColumnValue(colMeta, rowVal)
+		cellErrs = append(cellErrs, d.decodeCell(rowData, cellText, rowData.records[col], colMeta, outVal)...)
+	}
+	if d.restField != nil {
+		fieldByIndexInit(rowVal, d.restField.Index).Set(reflect.ValueOf(restValues))
+	}
+	// Missing optional columns which have default values
+	for _, colMeta := range d.missingColsMeta {
+		outVal := d.getColumnValue(colMeta, rowVal)
+		cellErrs = append(cellErrs, d.decodeCell(rowData, colMeta.defaultValue, colMeta.defaultValue, colMeta, outVal)...)
+	}
+	if len(d.inlineColsMeta) > 0 && !d.stopped() {
+		cellErrs = append(cellErrs, d.validateInlineColumns(rowVal)...)
+	}
+	if len(cellErrs) == 0 {
+		cellErrs = d.callPostDecodeHook(rowVal)
+	}
+	if len(cellErrs) == 0 {
+		cellErrs = d.callRowValidator(rowVal)
+	}
+	if len(cellErrs) == 0 && (len(cfg.RowValidatorFuncs) > 0 || cfg.UseStructValidator) {
+		cellErrs = d.validateRow(rowVal)
+	}
+	if len(cellErrs) > 0 {
+		rowErr := NewRowErrors(rowData.row, rowData.line)
+		rowErr.Add(cellErrs...)
+		return rowErr
+	}
+	return d.callOnRowDecodedFunc(rowData, rowVal)
+}
+
+// callOnRowDecodedFunc call DecodeConfig.OnRowDecodedFunc on the successfully decoded row
+func (d *Decoder) callOnRowDecodedFunc(rowData *rowData, rowVal reflect.Value) error {
+	if d.cfg.OnRowDecodedFunc == nil {
+		return nil
+	}
+	if err := d.cfg.OnRowDecodedFunc(rowData.row, rowVal.Addr().Interface()); err != nil {
+		if d.cfg.StopOnError {
+			d.stop()
 		}
+		rowErr := NewRowErrors(rowData.row, rowData.line)
+		rowErr.Add(err)
+		return rowErr
+	}
+	return nil
+}
 
-		outVal := rowVal.Field(colMeta.targetField.Index[0])
-		if colMeta.inlineColumnMeta != nil {
-			outVal = colMeta.inlineColumnMeta.decodeGetColumnValue(outVal)
+// preprocessCell trim space and apply the preprocessors on the cell text
+func (d *Decoder) preprocessCell(cellText string, colMeta *decodeColumnMeta) string {
+	if d.cfg.TrimSpace || colMeta.trimSpace {
+		cellText = strings.TrimSpace(cellText)
+	}
+	for _, fn := range colMeta.preprocessorFuncs {
+		cellText = fn(cellText)
+	}
+	return cellText
+}
+
+// getColumnValue gets the target field of the column from the row value
+func (d *Decoder) getColumnValue(colMeta *decodeColumnMeta, rowVal reflect.Value) reflect.Value {
+	outVal := fieldByIndexInit(rowVal, colMeta.targetField.Index)
+	if colMeta.inlineColumnMeta != nil {
+		outVal = colMeta.inlineColumnMeta.decodeGetColumnValue(outVal)
+	}
+	return outVal
+}
+
+// decodeCell decode a cell text and write the result to the given target value.
+// `value` is the original cell text which is used to build cell errors.
+func (d *Decoder) decodeCell(rowData *rowData, cellText, value string, colMeta *decodeColumnMeta,
+	outVal reflect.Value) []error {
+	nullValues := colMeta.nullValues
+	if nullValues == nil {
+		nullValues = d.cfg.NullValues
+	}
+	isNull := len(nullValues) > 0 && d.isNullValue(nullValues, cellText)
+	if !isNull && cellText == "" && !colMeta.omitempty && colMeta.defaultValue != "" {
+		cellText = colMeta.defaultValue
+		value = colMeta.defaultValue
+	}
+	// Required column must have a non-empty value in every cell, null values are treated as empty
+	if colMeta.required && (isNull || strings.TrimSpace(cellText) == "") {
+		if d.cfg.StopOnError || colMeta.stopOnError {
+			rowData.stopping = true
+			d.stop()
 		}
+		return []error{d.handleCellError(ErrValidationRequired, value, colMeta)}
+	}
+	if isNull {
+		outVal.Set(reflect.Zero(outVal.Type()))
+		return nil
+	}
 
-		var errs []error
-		hasDecodeErr := false
-		if !colMeta.omitempty || cellText != "" {
-			if err := colMeta.decodeFunc(cellText, outVal); err != nil {
+	var errs []error
+	hasDecodeErr := false
+	if !colMeta.omitempty || cellText != "" {
+		if err := colMeta.decodeFunc(cellText, outVal); err != nil {
+			if colMeta.onErrorUseFallback && d.decodeFallbackValue(colMeta, outVal) == nil {
+				rowData.warnings = append(rowData.warnings, d.handleCellError(err, value, colMeta))
+			} else {
 				errs = []error{err}
 				hasDecodeErr = true
 			}
 		}
-		if !hasDecodeErr && len(colMeta.validatorFuncs) > 0 {
-			errs = d.validateParsedCell(outVal, colMeta)
+	}
+	if !hasDecodeErr && len(colMeta.validatorFuncs) > 0 {
+		errs = d.validateParsedCell(outVal, colMeta)
+	}
+
+	cellErrs := make([]error, 0, len(errs))
+	for _, err := range errs {
+		cellErrs = append(cellErrs, d.handleCellError(err, value, colMeta))
+		if d.cfg.StopOnError || colMeta.stopOnError {
+			rowData.stopping = true
+			d.stop()
+			break
 		}
-		for _, err := range errs {
-			cellErrs = append(cellErrs, d.handleCellError(err, rowData.records[col], colMeta))
-			if cfg.StopOnError || colMeta.stopOnError {
-				d.shouldStop = true
-				break
-			}
+	}
+	return cellErrs
+}
+
+// decodeFallbackValue decode the fallback value of the column, the zero value is used when it is empty
+func (d *Decoder) decodeFallbackValue(colMeta *decodeColumnMeta, outVal reflect.Value) error {
+	if colMeta.fallbackValue == "" {
+		outVal.Set(reflect.Zero(outVal.Type()))
+		return nil
+	}
+	return colMeta.decodeFunc(colMeta.fallbackValue, outVal)
+}
+
+// addRowError add the error of a row to the result errors.
+// The decoding stops when DecodeConfig.OnRowErrorFunc asks for or when the number of errors
+// reaches DecodeConfig.MaxErrors, returns `true` in those cases.
+func (d *Decoder) addRowError(err error) (stopping bool) {
+	if rowErr, ok := err.(*RowErrors); ok && d.cfg.OnRowErrorFunc != nil { // nolint: errorlint
+		if d.cfg.OnRowErrorFunc(rowErr) {
+			stopping = true
+			d.stop()
 		}
 	}
-	if len(cellErrs) > 0 {
-		rowErr := NewRowErrors(rowData.row, rowData.line)
-		rowErr.Add(cellErrs...)
-		return rowErr
+	d.err.Add(err)
+	if d.cfg.MaxErrors <= 0 || d.err.truncated {
+		return stopping
 	}
-	return nil
+	if rowErr, ok := err.(*RowErrors); ok { // nolint: errorlint
+		d.errCount += rowErr.TotalError()
+	} else {
+		d.errCount++
+	}
+	if d.errCount >= d.cfg.MaxErrors {
+		d.err.truncated = true
+		d.err.Add(fmt.Errorf("%w: %d", ErrTooManyErrors, d.cfg.MaxErrors))
+		stopping = true
+		d.stop()
+	}
+	return stopping
+}
+
+// addProcessedRows count the processed rows, DecodeConfig.OnRowDecoded is called for every row
+func (d *Decoder) addProcessedRows(n int) {
+	for i := 0; i < n; i++ {
+		d.processedRows++
+		if d.cfg.OnRowDecoded != nil {
+			d.cfg.OnRowDecoded(d.processedRows, d.totalDataRows())
+		}
+	}
+	d.reportProgress(false)
+}
+
+// totalDataRows gets the number of data rows of the input, returns `-1` when the input is not read completely
+func (d *Decoder) totalDataRows() int {
+	if d.readerEOF {
+		return d.readRows
+	}
+	return -1
+}
+
+// reportProgress call DecodeConfig.ProgressFunc when the number of processed rows reaches the next
+// interval, or when the decoding ends (`final` is `true`)
+func (d *Decoder) reportProgress(final bool) {
+	if d.cfg.ProgressFunc == nil {
+		return
+	}
+	interval := d.cfg.ProgressInterval
+	if !final && d.processedRows/interval == d.reportedRows/interval {
+		return
+	}
+	totalRows := d.totalDataRows()
+	// Skip the final report if it is the same as the last one
+	if final && d.processedRows > 0 && d.processedRows == d.reportedRows && totalRows == d.reportedTotal {
+		return
+	}
+	d.reportedRows, d.reportedTotal = d.processedRows, totalRows
+	d.cfg.ProgressFunc(d.processedRows, totalRows)
+}
+
+// addRowWarnings add the warnings of the decoded row to the result
+func (d *Decoder) addRowWarnings(rowData *rowData) {
+	if len(rowData.warnings) == 0 {
+		return
+	}
+	result := d.result
+	if result.warnings == nil {
+		result.warnings = NewErrors()
+		result.warnings.header = d.err.header
+		result.fallbackCounts = map[string]int{}
+	}
+	rowErr := NewRowErrors(rowData.row, rowData.line)
+	rowErr.Add(rowData.warnings...)
+	result.warnings.Add(rowErr)
+	result.warnings.totalRow = result.totalRow
+	for _, err := range rowData.warnings {
+		result.fallbackCounts[err.(*CellError).Header()]++ // nolint: errorlint,forcetypeassert
+	}
+}
+
+// isNullValue checks if the cell text is one of the null values
+func (d *Decoder) isNullValue(nullValues []string, cellText string) bool {
+	if d.cfg.NullValuesIgnoreCase {
+		return gofn.ContainBy(nullValues, func(v string) bool { return strings.EqualFold(v, cellText) })
+	}
+	return gofn.Contain(nullValues, cellText)
 }
 
 // validateParsedCell validate a cell value after decoding
@@ -389,7 +1476,7 @@ func (d *Decoder) validateParsedCell(v reflect.Value, colMeta *decodeColumnMeta)
 		err := validatorFunc(vAsIface)
 		if err != nil {
 			if _, ok := err.(*CellError); !ok { // nolint: errorlint
-				err = NewCellError(err, colMeta.column, colMeta.headerText)
+				err = NewCellError(err, colMeta.column, d.getRawHeader(colMeta.column, colMeta.headerText))
 			}
 			errs = append(errs, err)
 			if d.cfg.StopOnError || colMeta.stopOnError {
@@ -400,12 +1487,109 @@ func (d *Decoder) validateParsedCell(v reflect.Value, colMeta *decodeColumnMeta)
 	return errs
 }
 
+// validateInlineColumns validate the values of dynamic inline columns after all cells of the row are decoded
+func (d *Decoder) validateInlineColumns(rowVal reflect.Value) []error {
+	var errs []error
+	for _, colMeta := range d.inlineColsMeta {
+		vAsIface := fieldByIndexInit(rowVal, colMeta.targetField.Index).Interface()
+		for _, validatorFunc := range colMeta.rowValidatorFuncs {
+			err := validatorFunc(vAsIface)
+			if err == nil {
+				continue
+			}
+			if _, ok := err.(*CellError); !ok { // nolint: errorlint
+				err = NewCellError(err, -1, colMeta.parentKey)
+			}
+			errs = append(errs, d.handleCellError(err, "", colMeta))
+			if d.cfg.StopOnError || colMeta.stopOnError {
+				d.stop()
+				return errs
+			}
+		}
+	}
+	return errs
+}
+
+// callPostDecodeHook call the function `PostDecode() error` on the decoded item if it implements PostDecodeHook
+func (d *Decoder) callPostDecodeHook(rowVal reflect.Value) []error {
+	hook, ok := rowVal.Addr().Interface().(PostDecodeHook)
+	if !ok {
+		return nil
+	}
+	if err := hook.PostDecode(); err != nil {
+		if d.cfg.StopOnError {
+			d.stop()
+		}
+		return []error{d.handleCellError(err, "", nil)}
+	}
+	return nil
+}
+
+// callRowValidator call the function `ValidateRow() error` on the decoded item if it implements RowValidator
+func (d *Decoder) callRowValidator(rowVal reflect.Value) []error {
+	validator, ok := rowVal.Addr().Interface().(RowValidator)
+	if !ok {
+		return nil
+	}
+	if err := validator.ValidateRow(); err != nil {
+		if d.cfg.StopOnError {
+			d.stop()
+		}
+		return []error{d.handleCellError(err, "", nil)}
+	}
+	return nil
+}
+
+// validateRow validate the decoded item of a row with the row validators and
+// the item's own Validate() function when DecodeConfig.UseStructValidator is set
+func (d *Decoder) validateRow(rowVal reflect.Value) []error {
+	var errs []error
+	vAsIface := rowVal.Interface()
+	for _, validatorFunc := range d.cfg.RowValidatorFuncs {
+		err := validatorFunc(vAsIface)
+		if err == nil {
+			continue
+		}
+		errs = append(errs, d.handleCellError(err, "", nil))
+		if d.cfg.StopOnError {
+			d.stop()
+			return errs
+		}
+	}
+	if !d.cfg.UseStructValidator {
+		return errs
+	}
+	validator, ok := rowVal.Addr().Interface().(StructValidator)
+	if !ok {
+		return errs
+	}
+	err := validator.Validate()
+	if err == nil {
+		return errs
+	}
+	itemErrs := []error{err}
+	if multiErr, ok := err.(interface{ Unwrap() []error }); ok { // nolint: errorlint
+		itemErrs = multiErr.Unwrap()
+	}
+	for _, err := range itemErrs {
+		errs = append(errs, d.handleCellError(err, "", nil))
+	}
+	if d.cfg.StopOnError {
+		d.stop()
+	}
+	return errs
+}
+
 // handleCellError build cell error for the given error and call the onCellErrorFunc
 func (d *Decoder) handleCellError(err error, value string, colMeta *decodeColumnMeta) error {
 	cellErr, ok := err.(*CellError) // nolint: errorlint
 	if !ok {
 		if colMeta != nil {
-			cellErr = NewCellError(err, colMeta.column, colMeta.headerText)
+			cellErr = NewCellError(err, colMeta.column, d.getRawHeader(colMeta.column, colMeta.headerText))
+			var errWithParams *errorWithParams
+			if errors.As(err, &errWithParams) {
+				cellErr.SetFields(errWithParams.params)
+			}
 		} else {
 			// This is error that not relate to any column (e.g. RwoFieldCount error)
 			cellErr = NewCellError(err, -1, "")
@@ -433,89 +1617,250 @@ func (d *Decoder) parseOutputVar(v reflect.Value) (itemType reflect.Type, err er
 		return
 	}
 
-	itemType = typ.Elem()
-	if indirectType(itemType).Kind() != reflect.Struct {
-		err = fmt.Errorf("%w: %v", ErrTypeInvalid, itemType.Kind())
-		return
+	itemType = typ.Elem()
+	if isStringMapType(itemType) {
+		return
+	}
+	if indirectType(itemType).Kind() != reflect.Struct {
+		err = fmt.Errorf("%w: %v", ErrTypeInvalid, itemType.Kind())
+		return
+	}
+	return
+}
+
+func (d *Decoder) parseOutputVarOne(v reflect.Value) (val reflect.Value, itemType reflect.Type, err error) {
+	itemType = v.Type()
+	if itemType.Kind() != reflect.Pointer || itemType.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, nil, fmt.Errorf("%w: must be a pointer to struct", ErrTypeInvalid)
+	}
+	itemType = itemType.Elem()
+	if v.IsNil() {
+		return reflect.Value{}, nil, fmt.Errorf("%w: must be a non-nil pointer", ErrValueNil)
+	}
+	val = v.Elem()
+	return
+}
+
+// readRowDataChunk read data of at most `size` next rows from the input and append them to the buffer
+func (d *Decoder) readRowDataChunk(buf []*rowData, size int) ([]*rowData, error) {
+	for len(buf) < size {
+		rowData, err := d.readNextRow()
+		if err != nil {
+			return buf, err
+		}
+		if rowData == nil {
+			break
+		}
+		buf = append(buf, rowData)
+	}
+	return buf, nil
+}
+
+// readNextRow read data of the next row from the input, returns `nil` when there is no more data.
+// If you use `encoding/csv` Reader, we can determine the lines of rows (via Reader.FieldPos func).
+// Otherwise, `line` will be set to `-1` which mean undetected.
+func (d *Decoder) readNextRow() (*rowData, error) {
+	if d.readerEOF {
+		return nil, nil
+	}
+	cfg, r := d.cfg, d.r
+	getLine, ableToGetLine := r.(interface {
+		FieldPos(field int) (line, column int) // Reader from "encoding/csv" provides this func
+	})
+	if !cfg.DetectRowLine {
+		ableToGetLine = false
+		getLine = nil
+	}
+
+	records, err := d.readDataRecord()
+	for {
+		if (err == nil || errors.Is(err, csv.ErrFieldCount)) && d.isCommentRow(records) {
+			d.result.skippedRows++
+			if d.restoreFieldsPerRecord {
+				r.(*csv.Reader).FieldsPerRecord = 0
+			}
+		} else if (err == nil || errors.Is(err, csv.ErrFieldCount)) && cfg.SkipEmptyRows && isEmptyRow(records) {
+			d.result.skippedEmptyRows++
+			if d.restoreFieldsPerRecord {
+				r.(*csv.Reader).FieldsPerRecord = 0
+			}
+		} else if err == nil && d.isFilteredRow(records) {
+			d.result.filteredRows++
+		} else {
+			break
+		}
+		d.setTotalRow(d.nextRow)
+		d.nextRow++
+		records, err = d.readDataRecord()
+	}
+	d.restoreFieldsPerRecord = false
+	d.trimFieldsPerRecord = false
+	if errors.Is(err, io.EOF) {
+		d.readerEOF = true
+		return nil, nil
+	}
+	if cfg.MaxRows > 0 && d.readRows >= cfg.MaxRows {
+		if cfg.ErrorOnMaxRows {
+			return nil, fmt.Errorf("%w: %d", ErrMaxRowsExceeded, cfg.MaxRows)
+		}
+		d.result.truncated = true
+		d.readerEOF = true
+		return nil, nil
+	}
+	d.readRows++
+	row := d.nextRow
+	d.nextRow++
+	d.setTotalRow(row)
+
+	line := -1
+	if err == nil {
+		if cfg.CaptureUnrecognizedValues && !cfg.NoHeaderMode {
+			d.captureUnrecognizedValues(records)
+		}
+		if ableToGetLine {
+			line, _ = getLine.FieldPos(0)
+		}
+		return &rowData{records: records, line: line, row: row}, nil
+	}
+	if errors.Is(err, csv.ErrFieldCount) {
+		err = fmt.Errorf("%w: row %d", ErrDecodeRowFieldCount, row)
+		if cfg.TreatIncorrectStructureAsError || cfg.StopOnError {
+			return nil, err
+		}
+		if ableToGetLine {
+			line, _ = getLine.FieldPos(0)
+		}
+		return &rowData{row: row, line: line, err: err}, nil
+	}
+	if errors.Is(err, csv.ErrQuote) || errors.Is(err, csv.ErrBareQuote) {
+		err = fmt.Errorf("%w: row %d", ErrDecodeQuoteInvalid, row)
+		if cfg.TreatIncorrectStructureAsError || cfg.StopOnError {
+			return nil, err
+		}
+		// NOTE: it seems when invalid quote, calling getLine will panic
+		return &rowData{row: row, line: line, err: err}, nil
+	}
+	return nil, err
+}
+
+// captureUnrecognizedValues keep the raw values of the unrecognized columns of the row in the result
+func (d *Decoder) captureUnrecognizedValues(records []string) {
+	for _, colMeta := range d.colsMeta {
+		if !colMeta.unrecognized || colMeta.column >= len(records) {
+			continue
+		}
+		name := d.getRawHeader(colMeta.column, colMeta.headerText)
+		values := d.result.unrecognizedValues[name]
+		if len(values) >= d.cfg.MaxUnrecognizedValues {
+			continue
+		}
+		if d.result.unrecognizedValues == nil {
+			d.result.unrecognizedValues = map[string][]string{}
+		}
+		d.result.unrecognizedValues[name] = append(values, records[colMeta.column])
+	}
+}
+
+// readDataRecord reads the next data row. When AllowTrailingEmptyColumns is set, the empty cells
+// beyond the header columns are dropped and the field count error caused by them is cleared.
+func (d *Decoder) readDataRecord() ([]string, error) {
+	records, err := d.readRecord()
+	if !d.cfg.AllowTrailingEmptyColumns || (err != nil && !errors.Is(err, csv.ErrFieldCount)) {
+		return records, err
+	}
+	numCols := len(d.header)
+	if numCols == 0 || len(records) <= numCols {
+		return records, err
+	}
+	for _, cellText := range records[numCols:] {
+		if cellText != "" {
+			return records, err
+		}
+	}
+	if d.trimFieldsPerRecord {
+		d.r.(*csv.Reader).FieldsPerRecord = numCols // nolint: forcetypeassert
+	}
+	return records[:numCols], nil
+}
+
+// isEmptyRow checks if all the cells of the given row are empty or whitespace
+func isEmptyRow(records []string) bool {
+	for _, cell := range records {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
 	}
-	return
+	return true
 }
 
-func (d *Decoder) parseOutputVarOne(v reflect.Value) (val reflect.Value, itemType reflect.Type, err error) {
-	itemType = v.Type()
-	if itemType.Kind() != reflect.Pointer || itemType.Elem().Kind() != reflect.Struct {
-		return reflect.Value{}, nil, fmt.Errorf("%w: must be a pointer to struct", ErrTypeInvalid)
+// isCommentRow checks if the given row is a comment row
+func (d *Decoder) isCommentRow(records []string) bool {
+	if d.cfg.CommentChar == 0 || len(records) == 0 {
+		return false
 	}
-	itemType = itemType.Elem()
-	if v.IsNil() {
-		return reflect.Value{}, nil, fmt.Errorf("%w: must be a non-nil pointer", ErrValueNil)
+	firstField := records[0]
+	if d.cfg.TrimSpace {
+		firstField = strings.TrimLeftFunc(firstField, unicode.IsSpace)
 	}
-	val = v.Elem()
-	return
+	return strings.HasPrefix(firstField, string(d.cfg.CommentChar)) && !d.isFirstFieldQuoted(records)
 }
 
-// readRowData read data of all rows from the input to struct type.
-// If you use `encoding/csv` Reader, we can determine the lines of rows (via Reader.FieldPos func).
-// Otherwise, `line` will be set to `-1` which mean undetected.
-func (d *Decoder) readRowData() error {
-	cfg, r := d.cfg, d.r
-	row := 2
-	if d.cfg.NoHeaderMode {
-		row = 1
-	}
-	getLine, ableToGetLine := r.(interface {
-		FieldPos(field int) (line, column int) // Reader from "encoding/csv" provides this func
-	})
-	if !cfg.DetectRowLine {
-		ableToGetLine = false
-		getLine = nil
+// isFirstFieldQuoted checks if the first field of the row just read by a csv.Reader is quoted.
+// An unquoted field takes exactly its length up to the next delimiter, a quoted field takes at least
+// 2 more bytes for the quotes. Returns `false` when this can't be detected (e.g. the row has a single field).
+func (d *Decoder) isFirstFieldQuoted(records []string) bool {
+	csvReader, ok := d.r.(*csv.Reader)
+	if !ok || len(records) < 2 {
+		return false
+	}
+	line0, col0 := csvReader.FieldPos(0)
+	line1, col1 := csvReader.FieldPos(1)
+	if line1 != line0 {
+		return true // Only quoted fields can contain line breaks
+	}
+	comma := csvReader.Comma
+	if comma == 0 {
+		comma = ','
+	}
+	width := col1 - col0 - utf8.RuneLen(comma)
+	// With csv.Reader.TrimLeadingSpace, the spaces before the second field are counted too
+	return width >= d.recordBOMLen+len(records[0])+2 // nolint: mnd
+}
+
+// isFilteredRow checks if the row is skipped by DecodeConfig.RowFilterFunc or DecodeConfig.RowFilterByRowFunc
+func (d *Decoder) isFilteredRow(records []string) bool {
+	if d.cfg.RowFilterFunc != nil && !d.cfg.RowFilterFunc(records, d.header) {
+		return true
 	}
-	rowDataItems := make([]*rowData, 0, 10000) //nolint:mnd
+	return d.cfg.RowFilterByRowFunc != nil && !d.cfg.RowFilterByRowFunc(records, d.nextRow)
+}
 
-	for ; ; row++ {
-		records, err := r.Read()
-		line := -1
-		if err == nil {
-			if ableToGetLine {
-				line, _ = getLine.FieldPos(0)
-			}
-			rowDataItems = append(rowDataItems, &rowData{
-				records: records,
-				line:    line,
-				row:     row,
-			})
-			continue
-		}
-		if errors.Is(err, io.EOF) {
-			break
-		}
-		if errors.Is(err, csv.ErrFieldCount) {
-			err = fmt.Errorf("%w: row %d", ErrDecodeRowFieldCount, row)
-			if cfg.TreatIncorrectStructureAsError || cfg.StopOnError {
-				return err
-			}
-			if ableToGetLine {
-				line, _ = getLine.FieldPos(0)
-			}
-			rowDataItems = append(rowDataItems, &rowData{row: row, line: line, err: err})
-			continue
-		}
-		if errors.Is(err, csv.ErrQuote) || errors.Is(err, csv.ErrBareQuote) {
-			err = fmt.Errorf("%w: row %d", ErrDecodeQuoteInvalid, row)
-			if cfg.TreatIncorrectStructureAsError || cfg.StopOnError {
-				return err
-			}
-			// NOTE: it seems when invalid quote, calling getLine will panic
-			rowDataItems = append(rowDataItems, &rowData{row: row, line: line, err: err})
-			continue
-		}
+// stop marks the decoding process as should stop, this is safe to be called from multiple goroutines
+func (d *Decoder) stop() {
+	atomic.StoreInt32(&d.shouldStop, 1)
+}
+
+// stopped checks if the decoding process should stop
+func (d *Decoder) stopped() bool {
+	return atomic.LoadInt32(&d.shouldStop) == 1
+}
+
+// checkContext check the context, if it is done, the decoder will stop with the context error
+func (d *Decoder) checkContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		d.err.Add(err)
+		d.stop()
 		return err
 	}
-
-	d.rowsData = rowDataItems
 	return nil
 }
 
+// setTotalRow set the total number of rows have been read from the input
+func (d *Decoder) setTotalRow(totalRow int) {
+	d.result.totalRow = totalRow
+	d.err.totalRow = totalRow
+}
+
 // parseColumnsMeta parse struct metadata
 func (d *Decoder) parseColumnsMeta(itemType reflect.Type) error {
 	cfg, result := d.cfg, d.result
@@ -529,26 +1874,64 @@ func (d *Decoder) parseColumnsMeta(itemType reflect.Type) error {
 		return err
 	}
 	if len(fileHeader) == 0 {
-		d.colsMeta = colsMetaFromStruct
-		return nil
+		if d.restField != nil {
+			return fmt.Errorf("%w: rest column is not accepted in NoHeaderMode", ErrTagOptionInvalid)
+		}
+		d.colsMeta, err = d.buildColumnsMetaByIndex(colsMetaFromStruct)
+		return err
+	}
+
+	matchKey := func(s string) string { return s }
+	if cfg.CaseInsensitiveHeader {
+		if err = d.validateHeaderCaseInsensitive(fileHeader); err != nil {
+			return err
+		}
+		matchKey = strings.ToLower
 	}
 
 	mapColMetaFromStruct := make(map[string]*decodeColumnMeta, len(colsMetaFromStruct))
 	for _, colMeta := range colsMetaFromStruct {
-		mapColMetaFromStruct[colMeta.headerText] = colMeta
+		mapColMetaFromStruct[matchKey(colMeta.headerText)] = colMeta
+	}
+	for _, colMeta := range colsMetaFromStruct {
+		for _, alias := range colMeta.aliases {
+			if _, ok := mapColMetaFromStruct[matchKey(alias)]; ok {
+				return fmt.Errorf("%w: alias \"%s\" duplicated", ErrHeaderColumnDuplicated, alias)
+			}
+			mapColMetaFromStruct[matchKey(alias)] = colMeta
+		}
 	}
 
 	colsMeta := make([]*decodeColumnMeta, 0, len(fileHeader))
-	for _, headerText := range fileHeader {
-		colMeta := mapColMetaFromStruct[headerText]
-		if colMeta == nil {
-			if !cfg.AllowUnrecognizedColumns {
-				return fmt.Errorf("%w: \"%s\"", ErrHeaderColumnUnrecognized, headerText)
+	matchedColsMeta := make(map[*decodeColumnMeta]struct{}, len(fileHeader))
+	for i, headerText := range fileHeader {
+		colMeta := mapColMetaFromStruct[matchKey(headerText)]
+		if colMeta != nil {
+			// A column with aliases can match only one column in the input header
+			if _, ok := matchedColsMeta[colMeta]; ok {
+				return fmt.Errorf("%w: \"%s\" matches column \"%s\" which is already matched",
+					ErrHeaderColumnDuplicated, d.getRawHeader(i, headerText), colMeta.headerKey)
+			}
+			matchedColsMeta[colMeta] = struct{}{}
+			if headerText != colMeta.headerText {
+				// The column is matched by an alias, or by the name in different case
+				if matchKey(headerText) != matchKey(colMeta.headerText) {
+					if result.usedAliases == nil {
+						result.usedAliases = map[string]string{}
+					}
+					result.usedAliases[colMeta.headerKey] = headerText
+				}
+				colMeta.headerText = headerText
+			}
+		} else {
+			if !cfg.AllowUnrecognizedColumns && d.restField == nil {
+				return fmt.Errorf("%w: \"%s\"", ErrHeaderColumnUnrecognized, d.getRawHeader(i, headerText))
 			}
 			colMeta = &decodeColumnMeta{
 				headerKey:    headerText,
 				headerText:   headerText,
-				unrecognized: true,
+				unrecognized: d.restField == nil,
+				rest:         d.restField != nil,
 			}
 		}
 		colMeta.column = len(colsMeta)
@@ -559,15 +1942,24 @@ func (d *Decoder) parseColumnsMeta(itemType reflect.Type) error {
 	for _, colMeta := range colsMeta {
 		mapColMeta[colMeta.headerText] = colMeta
 		if colMeta.unrecognized {
-			result.unrecognizedColumns = append(result.unrecognizedColumns, colMeta.headerText)
+			result.unrecognizedColumns = append(result.unrecognizedColumns,
+				d.getRawHeader(colMeta.column, colMeta.headerText))
 		}
 	}
 	for _, colMeta := range colsMetaFromStruct {
 		if _, ok := mapColMeta[colMeta.headerText]; !ok {
 			if !colMeta.optional {
-				return fmt.Errorf("%w: \"%s\"", ErrHeaderColumnRequired, colMeta.headerText)
+				if cfg.MissingRequiredColumnPolicy != MissingRequiredColumnFillZero {
+					return fmt.Errorf("%w: \"%s\"", ErrHeaderColumnRequired, colMeta.headerText)
+				}
+				result.missingRequiredColumns = append(result.missingRequiredColumns, colMeta.headerText)
+				continue
 			}
 			result.missingOptionalColumns = append(result.missingOptionalColumns, colMeta.headerText)
+			if colMeta.defaultValue != "" {
+				colMeta.column = -1
+				d.missingColsMeta = append(d.missingColsMeta, colMeta)
+			}
 		}
 	}
 
@@ -585,14 +1977,35 @@ func (d *Decoder) parseColumnsMeta(itemType reflect.Type) error {
 	return nil
 }
 
+// validateHeaderCaseInsensitive validate to make sure the columns of the file header are unique
+// case-insensitively, as they can't be matched with the struct columns unambiguously otherwise
+func (d *Decoder) validateHeaderCaseInsensitive(fileHeader []string) error {
+	mapCheckUniq := make(map[string]int, len(fileHeader))
+	for i, h := range fileHeader {
+		hh := strings.ToLower(h)
+		if j, ok := mapCheckUniq[hh]; ok {
+			return fmt.Errorf("%w: \"%s\" and \"%s\" differ only in case", ErrHeaderColumnDuplicated,
+				d.getRawHeader(j, fileHeader[j]), d.getRawHeader(i, h))
+		}
+		mapCheckUniq[hh] = i
+	}
+	return nil
+}
+
 // validateColumnsMeta validate struct metadata
 func (d *Decoder) validateColumnsMeta(colsMeta, colsMetaFromStruct []*decodeColumnMeta) error {
 	cfg := d.cfg
-	// Make sure all column options valid
+	// Make sure all column options valid. Dynamic inline columns having inline validators are accepted
+	// even when the input has no column for them, as the validators are still called.
+	matchColKey := func(colKey string) func(*decodeColumnMeta) bool {
+		return func(colMeta *decodeColumnMeta) bool {
+			return colMeta.headerKey == colKey || colMeta.parentKey == colKey ||
+				gofn.Contain(colMeta.aliases, colKey)
+		}
+	}
 	for colKey := range cfg.columnConfigMap {
-		if !gofn.ContainBy(colsMetaFromStruct, func(colMeta *decodeColumnMeta) bool {
-			return colMeta.headerKey == colKey || colMeta.parentKey == colKey
-		}) {
+		if !gofn.ContainBy(colsMetaFromStruct, matchColKey(colKey)) &&
+			!gofn.ContainBy(d.inlineColsMeta, matchColKey(colKey)) {
 			return fmt.Errorf("%w: column \"%s\" not found", ErrConfigOptionInvalid, colKey)
 		}
 	}
@@ -604,42 +2017,275 @@ func (d *Decoder) validateColumnsMeta(colsMeta, colsMetaFromStruct []*decodeColu
 	return nil
 }
 
+// buildColumnsMetaByIndex build columns metadata in order of the `index` tags of the struct fields.
+// This is applied for NoHeaderMode only, when there is no `index` tag, the struct order is used.
+func (d *Decoder) buildColumnsMetaByIndex(colsMetaFromStruct []*decodeColumnMeta) ([]*decodeColumnMeta, error) {
+	if !gofn.ContainBy(colsMetaFromStruct, func(colMeta *decodeColumnMeta) bool { return colMeta.index >= 0 }) {
+		return colsMetaFromStruct, nil
+	}
+
+	maxIndex := -1
+	mapColMeta := make(map[int]*decodeColumnMeta, len(colsMetaFromStruct))
+	for _, colMeta := range colsMetaFromStruct {
+		if colMeta.index < 0 {
+			return nil, fmt.Errorf("%w: column \"%s\" requires index tag", ErrTagOptionInvalid, colMeta.headerKey)
+		}
+		if _, ok := mapColMeta[colMeta.index]; ok {
+			return nil, fmt.Errorf("%w: index %d duplicated", ErrTagOptionInvalid, colMeta.index)
+		}
+		mapColMeta[colMeta.index] = colMeta
+		maxIndex = gofn.Max(maxIndex, colMeta.index)
+	}
+
+	colsMeta := make([]*decodeColumnMeta, 0, maxIndex+1)
+	for i := 0; i <= maxIndex; i++ {
+		colMeta := mapColMeta[i]
+		if colMeta == nil {
+			if !d.cfg.AllowUnrecognizedColumns {
+				return nil, fmt.Errorf("%w: column index %d", ErrHeaderColumnUnrecognized, i)
+			}
+			colMeta = &decodeColumnMeta{index: i, unrecognized: true}
+		}
+		colMeta.column = i
+		colsMeta = append(colsMeta, colMeta)
+	}
+	return colsMeta, nil
+}
+
+// skipRows discard the next rows of the input
+func (d *Decoder) skipRows(count int) error {
+	if count <= 0 {
+		return nil
+	}
+	// Built-in csv.Reader takes the number of fields of the first row as the expected number
+	// of fields of all rows. Restore it after skipping, so the skipped rows are not taken into account.
+	csvReader, _ := d.r.(*csv.Reader)
+	if csvReader != nil && csvReader.FieldsPerRecord == 0 {
+		defer func() { csvReader.FieldsPerRecord = 0 }()
+	}
+	for i := 0; i < count; i++ {
+		if _, err := d.readRecord(); err != nil && !errors.Is(err, csv.ErrFieldCount) {
+			return err
+		}
+		d.result.skippedRows++
+	}
+	return nil
+}
+
+// readRecord reads the next record from the input reader.
+// The UTF-8 BOM is stripped from the first record when StripBOM is set.
+func (d *Decoder) readRecord() ([]string, error) {
+	records, err := d.r.Read()
+	d.recordBOMLen = 0
+	if !d.firstRecordRead {
+		d.firstRecordRead = true
+		if d.cfg.StripBOM && len(records) > 0 && strings.HasPrefix(records[0], utf8BOM) {
+			records[0] = records[0][len(utf8BOM):]
+			d.recordBOMLen = len(utf8BOM)
+		}
+	}
+	return records, err
+}
+
+// getRawHeader gets the original text of the header column before normalized
+func (d *Decoder) getRawHeader(column int, headerText string) string {
+	if column >= 0 && column < len(d.rawHeader) {
+		return d.rawHeader[column]
+	}
+	return headerText
+}
+
 func (d *Decoder) readFileHeader() (fileHeader []string, err error) {
+	if d.headerPeeked {
+		d.headerPeeked = false
+		return d.peekedHeader, nil
+	}
+	if err = d.skipRows(d.cfg.SkipInitialRows + d.cfg.HeaderRowIndex); err != nil {
+		return nil, err
+	}
 	if !d.cfg.NoHeaderMode {
-		fileHeader, err = d.r.Read()
+		fileHeader, err = d.readRecord()
 		if err != nil {
 			return nil, err
 		}
+		if d.isCommentRow(fileHeader) {
+			return nil, fmt.Errorf("%w: header must not be a comment row", ErrHeaderColumnInvalid)
+		}
+		d.result.parsedHeader = fileHeader
+		if d.cfg.HeaderNormalizeFunc != nil || len(d.cfg.ColumnNameMap) > 0 {
+			d.rawHeader = fileHeader
+			fileHeader = make([]string, len(d.rawHeader))
+			for i, h := range d.rawHeader {
+				if d.cfg.HeaderNormalizeFunc != nil {
+					h = d.cfg.HeaderNormalizeFunc(h)
+				}
+				if mappedName, ok := d.cfg.ColumnNameMap[h]; ok {
+					h = mappedName
+				}
+				fileHeader[i] = h
+			}
+		}
+		if d.cfg.DeduplicateHeaders {
+			fileHeader = d.deduplicateHeader(fileHeader)
+		}
 	}
 	if err = validateHeader(fileHeader); err != nil {
 		return nil, err
 	}
-	return
+	// The input may end before all the rows are skipped, that means there is no data row
+	if err = d.skipRows(d.cfg.SkipRows); err != nil {
+		if !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		d.readerEOF = true
+	}
+	return fileHeader, nil
+}
+
+// deduplicateHeader rename the duplicated columns of the file header to `<name>_2`, `<name>_3`...,
+// skipping the names used by the other columns. The renamed columns are recorded in the result.
+func (d *Decoder) deduplicateHeader(fileHeader []string) []string {
+	matchKey := func(s string) string { return s }
+	if d.cfg.CaseInsensitiveHeader {
+		matchKey = strings.ToLower
+	}
+	usedKeys := make(map[string]struct{}, len(fileHeader))
+	for _, h := range fileHeader {
+		usedKeys[matchKey(h)] = struct{}{}
+	}
+
+	var header []string
+	counts := make(map[string]int, len(fileHeader))
+	for i, h := range fileHeader {
+		key := matchKey(h)
+		counts[key]++
+		if counts[key] == 1 {
+			continue
+		}
+		newName := fmt.Sprintf("%s_%d", h, counts[key])
+		for {
+			if _, used := usedKeys[matchKey(newName)]; !used {
+				break
+			}
+			counts[key]++
+			newName = fmt.Sprintf("%s_%d", h, counts[key])
+		}
+		usedKeys[matchKey(newName)] = struct{}{}
+		if header == nil {
+			header = make([]string, len(fileHeader))
+			copy(header, fileHeader)
+		}
+		header[i] = newName
+		if d.result.renamedColumns == nil {
+			d.result.renamedColumns = map[string]string{}
+		}
+		d.result.renamedColumns[newName] = h
+	}
+	if header == nil {
+		return fileHeader
+	}
+	return header
 }
 
 func (d *Decoder) parseColumnsMetaFromStructType(itemType reflect.Type, fileHeader []string) (
+	colsMeta []*decodeColumnMeta, err error) {
+	colsMeta, err = d.parseColumnsMetaFromStructFields(indirectType(itemType), &embeddedStruct{})
+	if err != nil {
+		return nil, err
+	}
+	d.structHeader = make([]string, 0, len(colsMeta))
+	for _, colMeta := range colsMeta {
+		d.structHeader = append(d.structHeader, colMeta.headerKey)
+	}
+	if err = d.validateHeaderUniqueness(colsMeta); err != nil {
+		return nil, err
+	}
+	for _, colMeta := range colsMeta {
+		if len(colMeta.rowValidatorFuncs) == 0 {
+			continue
+		}
+		if colMeta.inlineColumnMeta == nil || colMeta.inlineColumnMeta.inlineType != inlineColumnStructDynamic {
+			return nil, fmt.Errorf("%w: InlineValidatorFuncs is only accepted for dynamic inline column \"%s\"",
+				ErrConfigOptionInvalid, colMeta.headerKey)
+		}
+		d.inlineColsMeta = append(d.inlineColsMeta, colMeta)
+	}
+
+	if d.hasFixedInlineColumns || d.hasDynamicInlineColumns {
+		if err = d.validateConfigOnInlineColumns(fileHeader); err != nil {
+			return nil, err
+		}
+		// Parse dynamic inline columns based on file header
+		if d.hasDynamicInlineColumns {
+			colsMeta, err = d.parseDynamicInlineColumns(colsMeta, fileHeader)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Correct column index (0-index)
+	for i, colMeta := range colsMeta {
+		colMeta.column = i
+	}
+	return colsMeta, err
+}
+
+// parseColumnsMetaFromStructFields parse columns metadata from the fields of the struct type.
+// Fields of embedded structs are parsed recursively as if they are fields of the parent struct.
+func (d *Decoder) parseColumnsMetaFromStructFields(typ reflect.Type, parent *embeddedStruct) (
 	colsMeta []*decodeColumnMeta, err error) {
 	cfg := d.cfg
-	itemType = indirectType(itemType)
-	numFields := itemType.NumField()
+	numFields := typ.NumField()
 	for i := 0; i < numFields; i++ {
-		field := itemType.Field(i)
+		field := parent.structField(typ, i)
 		tag, err := parseTag(cfg.TagName, field)
 		if err != nil {
 			return nil, err
 		}
+		if isEmbeddedStructField(field, tag) {
+			embeddedColsMeta, err := d.parseColumnsMetaFromStructFields(indirectType(field.Type),
+				parent.embed(field, tag))
+			if err != nil {
+				return nil, err
+			}
+			colsMeta = append(colsMeta, embeddedColsMeta...)
+			continue
+		}
 		if tag == nil || tag.ignored {
 			continue
 		}
+		if tag.rest {
+			if d.restField != nil {
+				return nil, fmt.Errorf("%w: only one rest column is accepted", ErrTagOptionInvalid)
+			}
+			if field.Type != restColumnType {
+				return nil, fmt.Errorf("%w: rest column must be of type %v", ErrTagOptionInvalid, restColumnType)
+			}
+			d.restField = &field
+			continue
+		}
 
+		headerKey := parent.prefix + tag.name
+		if tag.inline {
+			// Prefix of embedded struct is applied to the inline columns via the inline prefix
+			headerKey = tag.name
+		}
 		colMeta := &decodeColumnMeta{
-			column:      len(colsMeta),
-			headerKey:   tag.name,
-			headerText:  tag.name,
-			prefix:      tag.prefix,
-			optional:    tag.optional,
-			omitempty:   tag.omitEmpty,
-			targetField: field,
+			column:       len(colsMeta),
+			headerKey:    headerKey,
+			headerText:   headerKey,
+			prefix:       parent.prefix + tag.prefix,
+			aliases:      tag.aliases,
+			format:       tag.format,
+			sep:          tag.sep,
+			base:         tag.base,
+			defaultValue: tag.defValue,
+			optional:     tag.optional || parent.optional,
+			required:     tag.required,
+			omitempty:    tag.omitEmpty || parent.omitEmpty,
+			index:        tag.index,
+			targetField:  field,
 		}
 
 		if tag.inline {
@@ -651,35 +2297,14 @@ func (d *Decoder) parseColumnsMetaFromStructType(itemType reflect.Type, fileHead
 			continue
 		}
 
-		colMeta.copyConfig(cfg.columnConfigMap[colMeta.headerKey])
+		colMeta.copyConfig(cfg.columnConfig(colMeta.headerKey, tag.aliases))
 		if err = colMeta.localizeHeader(cfg); err != nil {
 			return nil, err
 		}
 
 		colsMeta = append(colsMeta, colMeta)
 	}
-	if err = d.validateHeaderUniqueness(colsMeta); err != nil {
-		return nil, err
-	}
-
-	if d.hasFixedInlineColumns || d.hasDynamicInlineColumns {
-		if err = d.validateConfigOnInlineColumns(fileHeader); err != nil {
-			return nil, err
-		}
-		// Parse dynamic inline columns based on file header
-		if d.hasDynamicInlineColumns {
-			colsMeta, err = d.parseDynamicInlineColumns(colsMeta, fileHeader)
-			if err != nil {
-				return nil, err
-			}
-		}
-	}
-
-	// Correct column index (0-index)
-	for i, colMeta := range colsMeta {
-		colMeta.column = i
-	}
-	return colsMeta, err
+	return colsMeta, nil
 }
 
 func (d *Decoder) parseInlineColumn(field reflect.StructField, parentCol *decodeColumnMeta) (
@@ -717,13 +2342,18 @@ func (d *Decoder) parseInlineColumnFixedType(typ reflect.Type, parent *decodeCol
 
 		headerKey := parent.prefix + tag.name
 		colMeta := &decodeColumnMeta{
-			column:      len(colsMeta),
-			headerKey:   headerKey,
-			headerText:  headerKey,
-			parentKey:   parent.headerKey,
-			optional:    tag.optional,
-			omitempty:   tag.omitEmpty,
-			targetField: parent.targetField,
+			column:       len(colsMeta),
+			headerKey:    headerKey,
+			headerText:   headerKey,
+			parentKey:    parent.headerKey,
+			format:       tag.format,
+			sep:          tag.sep,
+			base:         tag.base,
+			defaultValue: tag.defValue,
+			optional:     tag.optional,
+			required:     tag.required || parent.required,
+			omitempty:    tag.omitEmpty,
+			targetField:  parent.targetField,
 			inlineColumnMeta: &inlineColumnMeta{
 				inlineType:  inlineColumnStructFixed,
 				targetField: field,
@@ -828,22 +2458,34 @@ func (d *Decoder) parseDynamicInlineColumns(colsMetaFromStruct []*decodeColumnMe
 // the actual type at decoding, and it will be slower.
 func (d *Decoder) buildColumnDecoders() error {
 	for _, colMeta := range d.colsMeta {
-		if colMeta.decodeFunc != nil || colMeta.unrecognized {
-			continue
-		}
-		dataType := colMeta.targetField.Type
-		if colMeta.inlineColumnMeta != nil {
-			dataType = colMeta.inlineColumnMeta.dataType
+		if err := d.buildColumnDecoder(colMeta); err != nil {
+			return err
 		}
-		decodeFunc, err := getDecodeFunc(dataType)
-		if err != nil {
+	}
+	for _, colMeta := range d.missingColsMeta {
+		if err := d.buildColumnDecoder(colMeta); err != nil {
 			return err
 		}
-		colMeta.decodeFunc = decodeFunc
 	}
 	return nil
 }
 
+func (d *Decoder) buildColumnDecoder(colMeta *decodeColumnMeta) error {
+	if colMeta.decodeFunc != nil || colMeta.unrecognized || colMeta.rest {
+		return nil
+	}
+	dataType := colMeta.targetField.Type
+	if colMeta.inlineColumnMeta != nil {
+		dataType = colMeta.inlineColumnMeta.dataType
+	}
+	decodeFunc, err := getDecodeFunc(dataType, colMeta.buildDecodeFuncConfig(d.cfg))
+	if err != nil {
+		return err
+	}
+	colMeta.decodeFunc = decodeFunc
+	return nil
+}
+
 // validateHeaderUniqueness validate to make sure header columns are unique
 func (d *Decoder) validateHeaderUniqueness(colsMeta []*decodeColumnMeta) error {
 	mapCheckUniq := make(map[string]struct{}, len(colsMeta))
@@ -873,7 +2515,7 @@ func (d *Decoder) validateHeaderOrder(colsMeta, colsMetaFromStruct []*decodeColu
 
 	header := make([]string, 0, len(colsMeta))
 	for _, colMeta := range colsMeta {
-		if colMeta.unrecognized {
+		if colMeta.unrecognized || colMeta.rest {
 			continue
 		}
 		header = append(header, colMeta.headerText)
@@ -881,7 +2523,8 @@ func (d *Decoder) validateHeaderOrder(colsMeta, colsMetaFromStruct []*decodeColu
 
 	headerFromStruct := make([]string, 0, len(colsMetaFromStruct))
 	for _, colMeta := range colsMetaFromStruct {
-		if colMeta.optional && mapColMeta[colMeta.headerText] == nil {
+		// Missing columns are validated separately
+		if mapColMeta[colMeta.headerText] == nil {
 			continue
 		}
 		headerFromStruct = append(headerFromStruct, colMeta.headerText)
@@ -895,9 +2538,68 @@ func (d *Decoder) validateHeaderOrder(colsMeta, colsMetaFromStruct []*decodeColu
 
 // validateConfig validate the configuration sent from user
 func (d *Decoder) validateConfig() error {
+	if d.ioReaderErr != nil {
+		return d.ioReaderErr
+	}
+	if !d.fromIOReader && (d.cfg.SourceCharset != "" || d.cfg.TransformReader != nil || d.cfg.AutoDetectDelimiter) {
+		return fmt.Errorf("%w: SourceCharset, TransformReader and AutoDetectDelimiter require NewDecoderFromReader",
+			ErrConfigOptionInvalid)
+	}
 	if d.cfg.ParseLocalizedHeader && d.cfg.LocalizationFunc == nil {
 		return fmt.Errorf("%w: localization function required", ErrConfigOptionInvalid)
 	}
+	if err := validateCSVDelimiter("Comma", d.cfg.Comma); err != nil {
+		return err
+	}
+	if err := validateCSVDelimiter("Comment", d.cfg.Comment); err != nil {
+		return err
+	}
+	if d.cfg.Comma != 0 && d.cfg.Comma == d.cfg.Comment {
+		return fmt.Errorf("%w: Comma and Comment must be different", ErrConfigOptionInvalid)
+	}
+	if d.cfg.Comma != 0 && d.cfg.AutoDetectDelimiter {
+		return fmt.Errorf("%w: only one of Comma and AutoDetectDelimiter can be set", ErrConfigOptionInvalid)
+	}
+	if d.cfg.CaptureUnrecognizedValues && d.cfg.MaxUnrecognizedValues <= 0 {
+		return fmt.Errorf("%w: MaxUnrecognizedValues must be positive", ErrConfigOptionInvalid)
+	}
+	if d.cfg.SkipInitialRows < 0 {
+		return fmt.Errorf("%w: SkipInitialRows must not be negative", ErrConfigOptionInvalid)
+	}
+	if d.cfg.HeaderRowIndex < 0 {
+		return fmt.Errorf("%w: HeaderRowIndex must not be negative", ErrConfigOptionInvalid)
+	}
+	if d.cfg.HeaderRowIndex > 0 && d.cfg.SkipInitialRows > 0 {
+		return fmt.Errorf("%w: only one of HeaderRowIndex and SkipInitialRows can be set", ErrConfigOptionInvalid)
+	}
+	if d.cfg.HeaderRowIndex > 0 && d.cfg.NoHeaderMode {
+		return fmt.Errorf("%w: HeaderRowIndex is not allowed in NoHeaderMode", ErrConfigOptionInvalid)
+	}
+	if d.cfg.SkipRows < 0 {
+		return fmt.Errorf("%w: SkipRows must not be negative", ErrConfigOptionInvalid)
+	}
+	if d.cfg.NumberFormat != nil {
+		if err := d.cfg.NumberFormat.validate(); err != nil {
+			return err
+		}
+	}
+	if d.cfg.MaxRows < 0 {
+		return fmt.Errorf("%w: MaxRows must not be negative", ErrConfigOptionInvalid)
+	}
+	if d.cfg.MaxErrors < 0 {
+		return fmt.Errorf("%w: MaxErrors must not be negative", ErrConfigOptionInvalid)
+	}
+	if d.cfg.MissingRequiredColumnPolicy != MissingRequiredColumnFail &&
+		d.cfg.MissingRequiredColumnPolicy != MissingRequiredColumnFillZero {
+		return fmt.Errorf("%w: MissingRequiredColumnPolicy %d invalid", ErrConfigOptionInvalid,
+			d.cfg.MissingRequiredColumnPolicy)
+	}
+	if d.cfg.ProgressFunc != nil && d.cfg.ProgressInterval <= 0 {
+		return fmt.Errorf("%w: ProgressInterval must be positive", ErrConfigOptionInvalid)
+	}
+	if d.cfg.WorkerCount < 0 {
+		return fmt.Errorf("%w: WorkerCount must not be negative", ErrConfigOptionInvalid)
+	}
 
 	return nil
 }
@@ -912,7 +2614,7 @@ func (d *Decoder) validateConfigOnInlineColumns(fileHeader []string) error {
 	if d.hasDynamicInlineColumns && !cfg.RequireColumnOrder {
 		return ErrHeaderDynamicRequireColumnOrder
 	}
-	if d.hasDynamicInlineColumns && cfg.AllowUnrecognizedColumns {
+	if d.hasDynamicInlineColumns && (cfg.AllowUnrecognizedColumns || d.restField != nil) {
 		return ErrHeaderDynamicNotAllowUnrecognizedColumns
 	}
 	if d.hasDynamicInlineColumns && cfg.ParseLocalizedHeader {
@@ -924,24 +2626,42 @@ func (d *Decoder) validateConfigOnInlineColumns(fileHeader []string) error {
 // rowData input data of each row
 // `line` can be different from `row`, as a row can be in multiple rows and empty lines are skipped
 type rowData struct {
-	records []string
-	line    int
-	row     int
-	err     error
+	records  []string
+	line     int
+	row      int
+	err      error
+	warnings []error
+	// stopping the row has an error which stops the decoding (e.g. of a column with StopOnError)
+	stopping bool
 }
 
 // decodeColumnMeta metadata for decoding a specific column
 type decodeColumnMeta struct {
 	column       int
+	index        int
 	headerKey    string
 	headerText   string
+	aliases      []string
 	parentKey    string
 	prefix       string
 	optional     bool
+	required     bool
 	unrecognized bool
+	rest         bool
 	omitempty    bool
 	trimSpace    bool
 	stopOnError  bool
+	timeLayout   string
+	format       string
+	boolTrue     []string
+	boolFalse    []string
+	sep          string
+	base         int
+	defaultValue string
+	nullValues   []string
+
+	onErrorUseFallback bool
+	fallbackValue      string
 
 	targetField      reflect.StructField
 	inlineColumnMeta *inlineColumnMeta
@@ -949,10 +2669,15 @@ type decodeColumnMeta struct {
 	decodeFunc        DecodeFunc
 	preprocessorFuncs []ProcessorFunc
 	validatorFuncs    []ValidatorFunc
+	rowValidatorFuncs []ValidatorFunc
 	onCellErrorFunc   OnCellErrorFunc
 }
 
 func (m *decodeColumnMeta) localizeHeader(cfg *DecodeConfig) error {
+	if columnCfg := cfg.columnConfig(m.headerKey, m.aliases); columnCfg != nil && columnCfg.HeaderText != "" {
+		m.headerText = columnCfg.HeaderText
+		return nil
+	}
 	if cfg.ParseLocalizedHeader {
 		headerText, err := cfg.LocalizationFunc(m.headerKey, nil)
 		if err != nil {
@@ -970,7 +2695,46 @@ func (m *decodeColumnMeta) copyConfig(columnCfg *DecodeColumnConfig) {
 	m.trimSpace = columnCfg.TrimSpace
 	m.stopOnError = columnCfg.StopOnError
 	m.decodeFunc = columnCfg.DecodeFunc
+	m.timeLayout = columnCfg.TimeLayout
+	m.boolTrue = columnCfg.BoolTrueValues
+	m.boolFalse = columnCfg.BoolFalseValues
+	if columnCfg.DefaultValue != "" {
+		m.defaultValue = columnCfg.DefaultValue
+	}
+	m.nullValues = columnCfg.NullValues
+	m.onErrorUseFallback = columnCfg.OnErrorUseFallback
+	m.fallbackValue = columnCfg.FallbackValue
 	m.validatorFuncs = columnCfg.ValidatorFuncs
+	m.rowValidatorFuncs = columnCfg.InlineValidatorFuncs
 	m.preprocessorFuncs = columnCfg.PreprocessorFuncs
 	m.onCellErrorFunc = columnCfg.OnCellErrorFunc
+	if m.inlineColumnMeta == nil && len(columnCfg.Aliases) > 0 {
+		m.aliases = append(append([]string{}, m.aliases...), columnCfg.Aliases...)
+	}
+}
+
+func (m *decodeColumnMeta) buildDecodeFuncConfig(cfg *DecodeConfig) *decodeFuncConfig {
+	timeLayouts := defaultDecodeTimeLayouts
+	switch {
+	case m.timeLayout != "":
+		timeLayouts = []string{m.timeLayout}
+	case m.format != "":
+		timeLayouts = []string{m.format}
+	case cfg.TimeLayout != "":
+		timeLayouts = []string{cfg.TimeLayout}
+	case len(cfg.DefaultTimeLayouts) > 0:
+		timeLayouts = cfg.DefaultTimeLayouts
+	}
+	return &decodeFuncConfig{
+		timeLayouts:       timeLayouts,
+		format:            m.format,
+		sep:               m.sep,
+		intBase:           m.base,
+		numberFormat:      cfg.NumberFormat,
+		boolTrueValues:    firstNonEmpty(m.boolTrue, cfg.BoolTrueValues, defaultDecodeBoolTrueValues),
+		boolFalseValues:   firstNonEmpty(m.boolFalse, cfg.BoolFalseValues, defaultDecodeBoolFalseValues),
+		boolCaseSensitive: cfg.BoolCaseSensitive,
+		inferIfaceTypes:   cfg.InferInterfaceTypes,
+		typeDecodeFuncs:   cfg.TypeDecodeFuncs,
+	}
 }
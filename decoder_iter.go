@@ -0,0 +1,39 @@
+//go:build go1.23
+
+package csvlib
+
+import (
+	"errors"
+	"iter"
+)
+
+// Iter returns an iterator which decodes the input data row by row. Type `T` must be a struct type,
+// e.g. `Student`. Rows are read and decoded incrementally, so the whole input data is never loaded
+// into memory at once.
+//
+// Each iteration yields the decoded item and the error of the row. When a row fails, the iteration
+// continues if the decoder is allowed to (see DecodeConfig.StopOnError). Call Finish() after
+// the iteration to get the overall result and error.
+//
+//	for student, err := range csvlib.Iter[Student](decoder) {
+//	    ...
+//	}
+func Iter[T any](d *Decoder) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for {
+			var item T
+			err := d.DecodeOne(&item)
+			if errors.Is(err, ErrFinished) {
+				return
+			}
+			if !yield(item, err) {
+				return
+			}
+			if err != nil {
+				if _, ok := err.(*RowErrors); !ok || d.stopped() { // nolint: errorlint
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,97 @@
+//go:build go1.23
+
+package csvlib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tiendc/gofn"
+)
+
+func Test_Iter(t *testing.T) {
+	type Item struct {
+		Col1 int     `csv:"col1"`
+		Col2 float32 `csv:"col2"`
+	}
+
+	t.Run("#1: iterate until finishes", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2
+			1,2.123
+			100,200`)
+
+		d := makeDecoder(data)
+		var v []Item
+		for item, err := range Iter[Item](d) {
+			assert.Nil(t, err)
+			v = append(v, item)
+		}
+		assert.Equal(t, []Item{{Col1: 1, Col2: 2.123}, {Col1: 100, Col2: 200}}, v)
+		ret, err := d.Finish()
+		assert.Nil(t, err)
+		assert.Equal(t, 3, ret.TotalRow())
+	})
+
+	t.Run("#2: consumer breaks the loop", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2
+			1,2.123
+			2,200
+			3,300`)
+
+		d := makeDecoder(data)
+		var v []Item
+		for item := range Iter[Item](d) {
+			v = append(v, item)
+			break
+		}
+		assert.Equal(t, []Item{{Col1: 1, Col2: 2.123}}, v)
+		var item Item
+		assert.Nil(t, d.DecodeOne(&item))
+		assert.Equal(t, Item{Col1: 2, Col2: 200}, item)
+	})
+
+	t.Run("#3: per-row errors with StopOnError = false", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2
+			1,2.123
+			abc,200
+			3,300`)
+
+		d := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.StopOnError = false
+		})
+		var v []Item
+		numErrs := 0
+		for item, err := range Iter[Item](d) {
+			if err != nil {
+				assert.ErrorIs(t, err, ErrDecodeValueType)
+				numErrs++
+				continue
+			}
+			v = append(v, item)
+		}
+		assert.Equal(t, 1, numErrs)
+		assert.Equal(t, []Item{{Col1: 1, Col2: 2.123}, {Col1: 3, Col2: 300}}, v)
+		_, err := d.Finish()
+		assert.Equal(t, 1, err.(*Errors).TotalRowError())
+	})
+
+	t.Run("#4: stop on the first error", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2
+			abc,2.123
+			2,200`)
+
+		d := makeDecoder(data)
+		numIters := 0
+		for _, err := range Iter[Item](d) {
+			assert.ErrorIs(t, err, ErrDecodeValueType)
+			numIters++
+		}
+		assert.Equal(t, 1, numIters)
+		_, err := d.Finish()
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+	})
+}
@@ -0,0 +1,123 @@
+package csvlib
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// DecodeMap decodes the input data as a slice of maps, each row becomes a map keyed by the header columns.
+// In NoHeaderMode, the keys are `col0`, `col1`... by the column indexes, they are also the names to configure the columns.
+func DecodeMap(r Reader, options ...DecodeOption) ([]map[string]string, *DecodeResult, error) {
+	return NewDecoder(r, options...).DecodeToMaps()
+}
+
+// UnmarshalToMaps convenient method to decode CSV data as a slice of maps, see DecodeMap
+func UnmarshalToMaps(data []byte, options ...DecodeOption) ([]map[string]string, *DecodeResult, error) {
+	return NewDecoder(csv.NewReader(bytes.NewReader(data)), options...).DecodeToMaps()
+}
+
+// DecodeToMaps decodes the remaining data as a slice of maps, the same as calling Decode with
+// `*[]map[string]string`. The column configs (e.g. preprocessors, validators) are applied by header columns.
+func (d *Decoder) DecodeToMaps() ([]map[string]string, *DecodeResult, error) {
+	var v []map[string]string
+	result, err := d.Decode(&v)
+	if err != nil {
+		return nil, result, err
+	}
+	return v, result, nil
+}
+
+const (
+	// noHeaderMapKeyPrefix prefix of the map keys in NoHeaderMode, followed by the column indexes
+	noHeaderMapKeyPrefix = "col"
+)
+
+// isStringMapType checks if the given type is a map having both key and value of string kind
+func isStringMapType(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Map && typ.Key().Kind() == reflect.String && typ.Elem().Kind() == reflect.String
+}
+
+// parseColumnsMetaForMap parse the file header and build columns metadata for decoding rows as maps.
+// As there is no struct definition, optional columns, column order, and unrecognized columns are not checked.
+func (d *Decoder) parseColumnsMetaForMap() error {
+	fileHeader, err := d.readFileHeader()
+	if err != nil {
+		return err
+	}
+	return d.buildColumnsMetaForMap(fileHeader)
+}
+
+func (d *Decoder) buildColumnsMetaForMap(fileHeader []string) error {
+	d.colsMeta = make([]*decodeColumnMeta, 0, len(fileHeader))
+	for col, headerText := range fileHeader {
+		colMeta, err := d.newColumnMetaForMap(col, headerText)
+		if err != nil {
+			return err
+		}
+		d.colsMeta = append(d.colsMeta, colMeta)
+	}
+	return nil
+}
+
+func (d *Decoder) newColumnMetaForMap(col int, headerText string) (*decodeColumnMeta, error) {
+	colMeta := &decodeColumnMeta{
+		column:     col,
+		index:      col,
+		headerKey:  headerText,
+		headerText: headerText,
+	}
+	colMeta.copyConfig(d.cfg.columnConfigMap[headerText])
+	if colMeta.decodeFunc == nil {
+		decodeFunc, err := getDecodeFunc(d.itemType.Elem(), colMeta.buildDecodeFuncConfig(d.cfg))
+		if err != nil {
+			return nil, err
+		}
+		colMeta.decodeFunc = decodeFunc
+	}
+	return colMeta, nil
+}
+
+// decodeRowAsMap decode row data as a map and write it to the row target value
+func (d *Decoder) decodeRowAsMap(rowData *rowData, rowVal reflect.Value) error {
+	keyType, elemType := d.itemType.Key(), d.itemType.Elem()
+	mapVal := reflect.MakeMapWithSize(d.itemType, len(rowData.records))
+
+	var cellErrs []error
+	for col, cellText := range rowData.records {
+		if col >= len(d.colsMeta) {
+			if !d.cfg.NoHeaderMode {
+				cellErrs = append(cellErrs, d.handleCellError(
+					fmt.Errorf("%w: row %d", ErrDecodeRowFieldCount, rowData.row), "", nil))
+				if d.cfg.StopOnError {
+					d.stop()
+				}
+				break
+			}
+			// In NoHeaderMode, columns are determined when the data come
+			colMeta, err := d.newColumnMetaForMap(col, noHeaderMapKeyPrefix+strconv.Itoa(col))
+			if err != nil {
+				return err
+			}
+			d.colsMeta = append(d.colsMeta, colMeta)
+		}
+		colMeta := d.colsMeta[col]
+		cellText = d.preprocessCell(cellText, colMeta)
+		outVal := reflect.New(elemType).Elem()
+		cellErrs = append(cellErrs, d.decodeCell(rowData, cellText, rowData.records[col], colMeta, outVal)...)
+		mapVal.SetMapIndex(reflect.ValueOf(colMeta.headerText).Convert(keyType), outVal)
+	}
+	rowVal.Set(mapVal)
+
+	if len(cellErrs) == 0 && len(d.cfg.RowValidatorFuncs) > 0 {
+		cellErrs = d.validateRow(rowVal)
+	}
+	if len(cellErrs) > 0 {
+		rowErr := NewRowErrors(rowData.row, rowData.line)
+		rowErr.Add(cellErrs...)
+		return rowErr
+	}
+	return d.callOnRowDecodedFunc(rowData, rowVal)
+}
@@ -0,0 +1,192 @@
+package csvlib
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tiendc/gofn"
+)
+
+func Test_Decode_asMap(t *testing.T) {
+	t.Run("#1: success", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2,col3
+			1, abc ,
+			2,def,xyz`)
+
+		var v []map[string]string
+		ret, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.TrimSpace = true
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, 3, ret.TotalRow())
+		assert.Equal(t, []map[string]string{
+			{"col1": "1", "col2": "abc", "col3": ""},
+			{"col1": "2", "col2": "def", "col3": "xyz"},
+		}, v)
+	})
+
+	t.Run("#2: with column config", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2
+			1,abc
+			2,`)
+
+		var v []map[string]string
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.ConfigureColumn("col2", func(cfg *DecodeColumnConfig) {
+				cfg.PreprocessorFuncs = []ProcessorFunc{ProcessorUpper}
+				cfg.DefaultValue = "N/A"
+			})
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []map[string]string{{"col1": "1", "col2": "ABC"}, {"col1": "2", "col2": "N/A"}}, v)
+	})
+
+	t.Run("#3: duplicated header", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2,col1
+			1,abc,2`)
+
+		var v []map[string]string
+		_, err := makeDecoder(data).Decode(&v)
+		assert.ErrorIs(t, err, ErrHeaderColumnDuplicated)
+	})
+
+	t.Run("#4: no header mode", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`1,abc
+			2,def`)
+
+		var v []map[string]string
+		ret, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.NoHeaderMode = true
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, ret.TotalRow())
+		assert.Equal(t, []map[string]string{{"col0": "1", "col1": "abc"}, {"col0": "2", "col1": "def"}}, v)
+	})
+
+	t.Run("#5: stop on error", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2
+			1,abc
+			2
+			3,def
+			4`)
+
+		var v []map[string]string
+		ret, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.TreatIncorrectStructureAsError = false
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeRowFieldCount)
+		assert.Nil(t, ret)
+		assert.Equal(t, 0, len(v))
+
+		_, err = makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.TreatIncorrectStructureAsError = false
+			cfg.StopOnError = false
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeRowFieldCount)
+		assert.Equal(t, 2, err.(*Errors).TotalRowError()) // nolint: errorlint
+	})
+
+	t.Run("#6: map of custom string types", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2
+			1,abc`)
+
+		var v []map[StrType]StrType
+		_, err := makeDecoder(data).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []map[StrType]StrType{{"col1": "1", "col2": "abc"}}, v)
+	})
+
+	t.Run("#7: invalid map type", func(t *testing.T) {
+		var v []map[string]int
+		_, err := makeDecoder("col1").Decode(&v)
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+	})
+}
+
+func Test_DecodeMap(t *testing.T) {
+	data := gofn.MultilineString(
+		`col1,col2
+		1,abc`)
+
+	v, ret, err := DecodeMap(csv.NewReader(strings.NewReader(data)))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, ret.TotalRow())
+	assert.Equal(t, []map[string]string{{"col1": "1", "col2": "abc"}}, v)
+
+	_, _, err = DecodeMap(csv.NewReader(strings.NewReader("col1,col1")))
+	assert.ErrorIs(t, err, ErrHeaderColumnDuplicated)
+}
+
+func Test_UnmarshalToMaps(t *testing.T) {
+	t.Run("#1: success", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2
+			1, abc
+			2,def`)
+
+		v, ret, err := UnmarshalToMaps([]byte(data), func(cfg *DecodeConfig) {
+			cfg.TrimSpace = true
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 3, ret.TotalRow())
+		assert.Equal(t, []map[string]string{{"col1": "1", "col2": "abc"}, {"col1": "2", "col2": "def"}}, v)
+	})
+
+	t.Run("#2: with column validators", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`status,note
+			Active,abc
+			deleted,def
+			INACTIVE,xyz`)
+
+		v, _, err := UnmarshalToMaps([]byte(data), func(cfg *DecodeConfig) {
+			cfg.StopOnError = false
+			cfg.ConfigureColumn("status", func(cfg *DecodeColumnConfig) {
+				cfg.ValidatorFuncs = []ValidatorFunc{ValidatorINFold("active", "inactive")}
+			})
+		})
+		assert.Nil(t, v)
+		assert.ErrorIs(t, err, ErrValidationIN)
+		rowErrs := err.(*Errors).ErrorsByRow() // nolint: errorlint
+		assert.Equal(t, 1, len(rowErrs))
+		cellErr := rowErrs[3].CellErrors()[0]
+		assert.Equal(t, "status", cellErr.Header())
+		assert.Equal(t, "deleted", cellErr.Value())
+	})
+}
+
+func Test_Decoder_DecodeToMaps(t *testing.T) {
+	t.Run("#1: no header mode", func(t *testing.T) {
+		d := makeDecoder(gofn.MultilineString(
+			`1,abc
+			2,def`), func(cfg *DecodeConfig) {
+			cfg.NoHeaderMode = true
+		})
+		v, ret, err := d.DecodeToMaps()
+		assert.Nil(t, err)
+		assert.Equal(t, 2, ret.TotalRow())
+		assert.Equal(t, []map[string]string{{"col0": "1", "col1": "abc"}, {"col0": "2", "col1": "def"}}, v)
+	})
+
+	t.Run("#2: configure columns by keys in no header mode", func(t *testing.T) {
+		d := makeDecoder(gofn.MultilineString(
+			`1,abc
+			2,def`), func(cfg *DecodeConfig) {
+			cfg.NoHeaderMode = true
+			cfg.ConfigureColumn("col1", func(cfg *DecodeColumnConfig) {
+				cfg.PreprocessorFuncs = []ProcessorFunc{strings.ToUpper}
+			})
+		})
+		v, _, err := d.DecodeToMaps()
+		assert.Nil(t, err)
+		assert.Equal(t, []map[string]string{{"col0": "1", "col1": "ABC"}, {"col0": "2", "col1": "DEF"}}, v)
+	})
+}
@@ -0,0 +1,88 @@
+package csvlib
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"github.com/tiendc/gofn"
+)
+
+// canDecodeInParallel checks if rows can be decoded concurrently.
+// Inline columns keep the state of the current row, and the map mode builds columns lazily,
+// the rows in those cases are always decoded one by one.
+func (d *Decoder) canDecodeInParallel() bool {
+	return d.cfg.WorkerCount > 1 && !d.mapMode && !d.hasDynamicInlineColumns && !d.hasFixedInlineColumns
+}
+
+// decodeChunkInParallel decode rows of the chunk concurrently by a pool of workers.
+// Every row is written to its pre-allocated item of the output slice, so the order of rows is kept.
+// Errors and warnings of rows are added to the result in order of rows after all workers finish.
+// Returns the number of rows handled in order, up to and including the row that stops the decoding.
+func (d *Decoder) decodeChunkInParallel(ctx context.Context, chunk []*rowData, outSlice reflect.Value, start int) int {
+	itemKindIsPtr := d.itemType.Kind() == reflect.Pointer
+	rowErrs := make([]error, len(chunk))
+	decoded := make([]bool, len(chunk))
+	stopping := make([]bool, len(chunk))
+	nextIndex := int64(-1)
+	var ctxErr error
+	var ctxErrOnce sync.Once
+	var wg sync.WaitGroup
+
+	workerCount := gofn.Min(d.cfg.WorkerCount, len(chunk))
+	wg.Add(workerCount)
+	for w := 0; w < workerCount; w++ {
+		go func() {
+			defer wg.Done()
+			for !d.stopped() {
+				i := int(atomic.AddInt64(&nextIndex, 1))
+				if i >= len(chunk) {
+					return
+				}
+				if err := ctx.Err(); err != nil {
+					ctxErrOnce.Do(func() { ctxErr = err })
+					d.stop()
+					return
+				}
+				rowVal := outSlice.Index(start + i)
+				if itemKindIsPtr {
+					rowVal.Set(reflect.New(d.itemType.Elem()))
+					rowVal = rowVal.Elem()
+				}
+				err := d.decodeRow(chunk[i], rowVal)
+				decoded[i] = true
+				if err != nil {
+					rowErrs[i] = err
+					stopping[i] = d.cfg.StopOnError || chunk[i].stopping
+					if stopping[i] {
+						d.stop()
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	handledRows := 0
+	for i, err := range rowErrs {
+		// Rows are taken in order, all rows before the one stopping the decoding are decoded already.
+		// The first row not decoded is where the context is done, it and the rows after are ignored.
+		if !decoded[i] {
+			break
+		}
+		handledRows++
+		d.addRowWarnings(chunk[i])
+		if err == nil {
+			continue
+		}
+		// Other workers may have stopped at later rows, only the stopping caused by this row counts
+		if d.addRowError(err) || stopping[i] {
+			break
+		}
+	}
+	if ctxErr != nil {
+		d.err.Add(ctxErr)
+	}
+	return handledRows
+}
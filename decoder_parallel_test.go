@@ -0,0 +1,252 @@
+package csvlib
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tiendc/gofn"
+)
+
+func Test_Decode_withWorkerCount(t *testing.T) {
+	type Item struct {
+		Col1 int    `csv:"col1"`
+		Col2 string `csv:"col2"`
+	}
+	makeData := func(totalRow int, invalidRows ...int) string {
+		var sb strings.Builder
+		sb.WriteString("col1,col2\n")
+		for i := 0; i < totalRow; i++ {
+			if gofn.Contain(invalidRows, i) {
+				sb.WriteString(fmt.Sprintf("x,v%d\n", i))
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("%d,v%d\n", i, i))
+		}
+		return sb.String()
+	}
+
+	t.Run("#1: output ordering", func(t *testing.T) {
+		totalRow := 2*decodeChunkSize + 123
+		var v []Item
+		ret, err := makeDecoder(makeData(totalRow), func(cfg *DecodeConfig) {
+			cfg.WorkerCount = 8
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, totalRow+1, ret.TotalRow())
+		assert.Equal(t, totalRow, len(v))
+		for i := range v {
+			assert.Equal(t, Item{Col1: i, Col2: fmt.Sprintf("v%d", i)}, v[i])
+		}
+	})
+
+	t.Run("#2: pointer items", func(t *testing.T) {
+		var v []*Item
+		_, err := makeDecoder(makeData(100), func(cfg *DecodeConfig) {
+			cfg.WorkerCount = 4
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, 100, len(v))
+		assert.Equal(t, &Item{Col1: 99, Col2: "v99"}, v[99])
+	})
+
+	t.Run("#3: concurrent errors are collected in order", func(t *testing.T) {
+		var v []Item
+		_, err := makeDecoder(makeData(1000, 10, 500, 501, 999), func(cfg *DecodeConfig) {
+			cfg.WorkerCount = 8
+			cfg.StopOnError = false
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		rowErrs := err.(*Errors).Unwrap() // nolint: errorlint
+		assert.Equal(t, 4, len(rowErrs))
+		for i, row := range []int{12, 502, 503, 1001} {
+			assert.Equal(t, row, rowErrs[i].(*RowErrors).Row()) // nolint: errorlint
+		}
+	})
+
+	t.Run("#4: stop on first error", func(t *testing.T) {
+		var v []Item
+		_, err := makeDecoder(makeData(5000, 100, 101, 4000), func(cfg *DecodeConfig) {
+			cfg.WorkerCount = 8
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		rowErrs := err.(*Errors).Unwrap() // nolint: errorlint
+		assert.Equal(t, 1, len(rowErrs))
+		assert.Equal(t, 102, rowErrs[0].(*RowErrors).Row()) // nolint: errorlint
+		assert.Equal(t, 0, len(v))
+	})
+
+	t.Run("#5: context canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		var v []Item
+		_, err := makeDecoder(makeData(100), func(cfg *DecodeConfig) {
+			cfg.WorkerCount = 4
+		}).DecodeContext(ctx, &v)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("#6: inline columns are decoded serially", func(t *testing.T) {
+		type Item struct {
+			Col1 int               `csv:"col1"`
+			Sub  InlineColumn[int] `csv:"sub,inline"`
+		}
+		data := gofn.MultilineString(
+			`col1,sub1,sub2
+			1,2,3
+			4,5,6`)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.WorkerCount = 4
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []int{2, 3}, v[0].Sub.Values)
+		assert.Equal(t, []int{5, 6}, v[1].Sub.Values)
+	})
+
+	t.Run("#7: warnings are collected in order", func(t *testing.T) {
+		var v []Item
+		ret, err := makeDecoder(makeData(1000, 10, 500, 501, 999), func(cfg *DecodeConfig) {
+			cfg.WorkerCount = 8
+			cfg.ConfigureColumn("col1", func(cfg *DecodeColumnConfig) {
+				cfg.OnErrorUseFallback = true
+			})
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]int{"col1": 4}, ret.FallbackCounts())
+		rowErrs := ret.Warnings().Unwrap()
+		for i, row := range []int{12, 502, 503, 1001} {
+			assert.Equal(t, row, rowErrs[i].(*RowErrors).Row()) // nolint: errorlint
+		}
+	})
+
+	t.Run("#8: stop when errors reach the limit", func(t *testing.T) {
+		var v []Item
+		_, err := makeDecoder(makeData(1000, 10, 500, 501, 999), func(cfg *DecodeConfig) {
+			cfg.WorkerCount = 8
+			cfg.StopOnError = false
+			cfg.MaxErrors = 2
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrTooManyErrors)
+		rowErrs := err.(*Errors).Unwrap() // nolint: errorlint
+		assert.Equal(t, 3, len(rowErrs))
+		assert.Equal(t, 502, rowErrs[1].(*RowErrors).Row()) // nolint: errorlint
+	})
+
+	t.Run("#9: stop by row error func", func(t *testing.T) {
+		var v []Item
+		var rows []int
+		_, err := makeDecoder(makeData(1000, 10, 500, 501, 999), func(cfg *DecodeConfig) {
+			cfg.WorkerCount = 8
+			cfg.StopOnError = false
+			cfg.OnRowErrorFunc = func(rowErr *RowErrors) bool {
+				rows = append(rows, rowErr.Row())
+				return rowErr.Row() > 500
+			}
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		assert.Equal(t, []int{12, 502}, rows)
+		assert.Equal(t, 2, err.(*Errors).TotalRowError()) // nolint: errorlint
+	})
+
+	t.Run("#10: invalid worker count", func(t *testing.T) {
+		var v []Item
+		_, err := makeDecoder(makeData(1), func(cfg *DecodeConfig) {
+			cfg.WorkerCount = -1
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrConfigOptionInvalid)
+	})
+
+	t.Run("#11: progress counts only the handled rows", func(t *testing.T) {
+		for _, workerCount := range []int{1, 4} {
+			var rows []int
+			var finalProgress int
+			var v []Item
+			_, err := makeDecoder(makeData(50, 3), func(cfg *DecodeConfig) {
+				cfg.WorkerCount = workerCount
+				cfg.OnRowDecoded = func(row int, total int) {
+					rows = append(rows, row)
+				}
+				cfg.ProgressFunc = func(processedRows, totalRows int) {
+					finalProgress = processedRows
+				}
+			}).Decode(&v)
+			assert.ErrorIs(t, err, ErrDecodeValueType)
+			assert.Equal(t, []int{1, 2, 3, 4}, rows)
+			assert.Equal(t, 4, finalProgress)
+		}
+	})
+
+	t.Run("#12: stop by column after errors of other columns", func(t *testing.T) {
+		errStop := errors.New("stop")
+		decode := func(workerCount int) ([]int, []Item, error) {
+			var rows []int
+			var v []Item
+			_, err := makeDecoder(makeData(200, 5), func(cfg *DecodeConfig) {
+				cfg.WorkerCount = workerCount
+				cfg.StopOnError = false
+				cfg.OnRowDecoded = func(row int, total int) {
+					rows = append(rows, row)
+				}
+				cfg.ConfigureColumn("col2", func(cfg *DecodeColumnConfig) {
+					cfg.StopOnError = true
+					cfg.ValidatorFuncs = []ValidatorFunc{func(v any) error {
+						if v == "v20" {
+							return errStop
+						}
+						return nil
+					}}
+				})
+			}).Decode(&v)
+			return rows, v, err
+		}
+
+		serialRows, _, serialErr := decode(1)
+		for _, workerCount := range []int{4, 8} {
+			rows, _, err := decode(workerCount)
+			assert.ErrorIs(t, err, ErrDecodeValueType)
+			assert.ErrorIs(t, err, errStop)
+			assert.Equal(t, serialErr, err)
+			assert.Equal(t, serialRows, rows)
+			assert.Equal(t, 21, len(rows))
+			rowErrs := err.(*Errors).Unwrap() // nolint: errorlint
+			assert.Equal(t, 2, len(rowErrs))
+			assert.Equal(t, 7, rowErrs[0].(*RowErrors).Row())  // nolint: errorlint
+			assert.Equal(t, 22, rowErrs[1].(*RowErrors).Row()) // nolint: errorlint
+		}
+	})
+
+	t.Run("#13: context canceled after errors of rows", func(t *testing.T) {
+		for _, workerCount := range []int{1, 4} {
+			ctx, cancel := context.WithCancel(context.Background())
+			var v []Item
+			decodedRows := 0
+			_, err := makeDecoder(makeData(200, 5), func(cfg *DecodeConfig) {
+				cfg.WorkerCount = workerCount
+				cfg.StopOnError = false
+				cfg.OnRowDecoded = func(row int, total int) {
+					decodedRows = row
+				}
+				cfg.ConfigureColumn("col2", func(cfg *DecodeColumnConfig) {
+					cfg.ValidatorFuncs = []ValidatorFunc{func(v any) error {
+						if v == "v30" {
+							cancel()
+						}
+						return nil
+					}}
+				})
+			}).DecodeContext(ctx, &v)
+			assert.ErrorIs(t, err, ErrDecodeValueType)
+			assert.ErrorIs(t, err, context.Canceled)
+			rowErrs := err.(*Errors).Unwrap() // nolint: errorlint
+			assert.Equal(t, 2, len(rowErrs))
+			assert.Equal(t, 7, rowErrs[0].(*RowErrors).Row()) // nolint: errorlint
+			// Rows before the one the context is found done are all decoded
+			assert.GreaterOrEqual(t, decodedRows, 31)
+		}
+	})
+}
@@ -1,11 +1,17 @@
 package csvlib
 
 import (
+	"bytes"
+	"context"
+	"database/sql"
 	"encoding/csv"
 	"errors"
+	"io"
 	"reflect"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/tiendc/gofn"
@@ -153,6 +159,38 @@ func Test_Decode_withOptionalColumn(t *testing.T) {
 		assert.Nil(t, ret)
 		assert.ErrorIs(t, err, ErrHeaderColumnRequired)
 	})
+
+	t.Run("#3: missing required column filled with zero", func(t *testing.T) {
+		type Item struct {
+			Col1 int     `csv:"col1,required"`
+			Col2 float32 `csv:"col2"`
+			Col3 string  `csv:"col3"`
+		}
+		data := gofn.MultilineString(
+			`col2
+			2.123
+			200`)
+
+		var v []Item
+		ret, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.MissingRequiredColumnPolicy = MissingRequiredColumnFillZero
+			cfg.ConfigureColumn("col1", func(cfg *DecodeColumnConfig) {
+				cfg.ValidatorFuncs = []ValidatorFunc{ValidatorGT(0)}
+			})
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"col1", "col3"}, ret.MissingRequiredColumns())
+		assert.Equal(t, 0, len(ret.MissingOptionalColumns()))
+		assert.Equal(t, []Item{{Col2: 2.123}, {Col2: 200}}, v)
+	})
+
+	t.Run("#4: invalid policy", func(t *testing.T) {
+		var v []Item
+		_, err := makeDecoder("col1,col2", func(cfg *DecodeConfig) {
+			cfg.MissingRequiredColumnPolicy = MissingRequiredColumnPolicy(10)
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrConfigOptionInvalid)
+	})
 }
 
 func Test_Decode_withUnrecognizedColumn(t *testing.T) {
@@ -185,6 +223,146 @@ func Test_Decode_withUnrecognizedColumn(t *testing.T) {
 		assert.Nil(t, ret)
 		assert.ErrorIs(t, err, ErrHeaderColumnUnrecognized)
 	})
+
+	t.Run("#3: capture values of unrecognized columns", func(t *testing.T) {
+		var v []Item
+		ret, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.AllowUnrecognizedColumns = true
+			cfg.CaptureUnrecognizedValues = true
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string][]string{"col-x": {"a", "c"}, "col-y": {"b", "d"}}, ret.UnrecognizedColumnValues())
+
+		// Values are not captured by default
+		ret, err = makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.AllowUnrecognizedColumns = true
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Nil(t, ret.UnrecognizedColumnValues())
+	})
+
+	t.Run("#4: captured values are limited", func(t *testing.T) {
+		var v []Item
+		ret, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.AllowUnrecognizedColumns = true
+			cfg.CaptureUnrecognizedValues = true
+			cfg.MaxUnrecognizedValues = 1
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string][]string{"col-x": {"a"}, "col-y": {"b"}}, ret.UnrecognizedColumnValues())
+	})
+
+	t.Run("#5: invalid limit", func(t *testing.T) {
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.AllowUnrecognizedColumns = true
+			cfg.CaptureUnrecognizedValues = true
+			cfg.MaxUnrecognizedValues = 0
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrConfigOptionInvalid)
+	})
+}
+
+func Test_Decode_withParsedHeader(t *testing.T) {
+	t.Run("#1: optional and dynamic inline columns", func(t *testing.T) {
+		type Item struct {
+			Col1 int               `csv:"col1"`
+			Col2 string            `csv:"col2,optional"`
+			Dyn  InlineColumn[int] `csv:"dyn,inline,prefix=d_"`
+			Col3 string            `csv:"col3"`
+		}
+		data := gofn.MultilineString(
+			`col1,d_x,d_y,col3
+			1,3,4,abc`)
+
+		d := makeDecoder(data)
+		assert.Nil(t, d.Header())
+		var v []Item
+		ret, err := d.Decode(&v)
+		assert.Nil(t, err)
+		header := []string{"col1", "d_x", "d_y", "col3"}
+		assert.Equal(t, header, ret.ParsedHeader())
+		assert.Equal(t, header, d.Header())
+		assert.Equal(t, []string{"col1", "col2", "d_dyn", "col3"}, ret.StructHeader())
+		assert.Equal(t, []string{"col2"}, ret.MissingOptionalColumns())
+	})
+
+	t.Run("#2: unrecognized and fixed inline columns", func(t *testing.T) {
+		type Inline struct {
+			Sub1 int `csv:"sub1"`
+			Sub2 int `csv:"sub2,optional"`
+		}
+		type Item struct {
+			Col1 int    `csv:"col1"`
+			Fix  Inline `csv:"fix,inline,prefix=f_"`
+			Col2 string `csv:"col2,optional"`
+			Col3 string `csv:"col3"`
+		}
+		data := gofn.MultilineString(
+			`col1,f_sub1,colX,col3
+			1,2,x,abc`)
+
+		var v []Item
+		ret, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.AllowUnrecognizedColumns = true
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"col1", "f_sub1", "colX", "col3"}, ret.ParsedHeader())
+		assert.Equal(t, []string{"col1", "f_sub1", "f_sub2", "col2", "col3"}, ret.StructHeader())
+		assert.Equal(t, []string{"colX"}, ret.UnrecognizedColumns())
+		assert.Equal(t, []string{"f_sub2", "col2"}, ret.MissingOptionalColumns())
+	})
+
+	t.Run("#3: header before normalization", func(t *testing.T) {
+		type Item struct {
+			Col1 int `csv:"col1"`
+			Col2 int `csv:"col2"`
+		}
+		data := gofn.MultilineString(
+			`COL1 ,Column 2
+			1,2`)
+
+		var v []Item
+		ret, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.HeaderNormalizeFunc = func(s string) string { return strings.ToLower(strings.TrimSpace(s)) }
+			cfg.ColumnNameMap = map[string]string{"column 2": "col2"}
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"COL1 ", "Column 2"}, ret.ParsedHeader())
+		assert.Equal(t, []string{"col1", "col2"}, ret.StructHeader())
+	})
+
+	t.Run("#4: no header mode", func(t *testing.T) {
+		type Item struct {
+			Col1 int `csv:"col1"`
+			Col2 int `csv:"col2,optional"`
+		}
+		var v []Item
+		ret, err := makeDecoder("1,2", func(cfg *DecodeConfig) {
+			cfg.NoHeaderMode = true
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Nil(t, ret.ParsedHeader())
+		assert.Equal(t, []string{"col1", "col2"}, ret.StructHeader())
+	})
+
+	t.Run("#5: after reset", func(t *testing.T) {
+		type Item struct {
+			Col1 int `csv:"col1"`
+			Col2 int `csv:"col2"`
+		}
+		d := makeDecoder("col1,col2\n1,2")
+		var v Item
+		assert.Nil(t, d.DecodeOne(&v))
+		assert.Equal(t, []string{"col1", "col2"}, d.Header())
+
+		d.Reset(csv.NewReader(strings.NewReader("col1,col2\n3,4")))
+		assert.Nil(t, d.DecodeOne(&v))
+		ret, err := d.Finish()
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"col1", "col2"}, ret.ParsedHeader())
+		assert.Equal(t, []string{"col1", "col2"}, ret.StructHeader())
+	})
 }
 
 func Test_Decode_withPreprocessor(t *testing.T) {
@@ -690,6 +868,65 @@ func Test_Decode_withDynamicInlineColumn(t *testing.T) {
 		assert.Nil(t, ret)
 		assert.Nil(t, v)
 	})
+
+	t.Run("#7: with inline validator", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,sub1,sub2,col2
+			1,111,11,abcxyz123
+			1000,222,22,abc123`)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.ConfigureColumn("sub1", func(cfg *DecodeColumnConfig) {
+				cfg.InlineValidatorFuncs = []ValidatorFunc{ValidatorInlineLen[int](2, 12)}
+			})
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(v))
+
+		v = nil
+		_, err = makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.StopOnError = false
+			cfg.ConfigureColumn("sub1", func(cfg *DecodeColumnConfig) {
+				cfg.InlineValidatorFuncs = []ValidatorFunc{ValidatorInlineLen[int](4, 12)}
+			})
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrValidationInlineLen)
+		assert.Equal(t, 2, err.(*Errors).TotalError())
+		cellErr := err.(*Errors).Unwrap()[0].(*RowErrors).Unwrap()[0].(*CellError) // nolint: errorlint
+		assert.Equal(t, -1, cellErr.Column())
+		assert.Equal(t, "sub1", cellErr.Header())
+	})
+
+	t.Run("#8: with inline validator when there is no inline column", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2
+			1,abcxyz123
+			1000,abc123`)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.ConfigureColumn("sub1", func(cfg *DecodeColumnConfig) {
+				cfg.InlineValidatorFuncs = []ValidatorFunc{ValidatorInlineLen[int](1, -1)}
+			})
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrValidationInlineLen)
+		assert.Equal(t, 1, err.(*Errors).TotalError())
+	})
+
+	t.Run("#9: inline validator set for non-inline column", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,sub1,sub2,col2
+			1,111,11,abcxyz123`)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.ConfigureColumn("col1", func(cfg *DecodeColumnConfig) {
+				cfg.InlineValidatorFuncs = []ValidatorFunc{ValidatorInlineLen[int](1, -1)}
+			})
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrConfigOptionInvalid)
+	})
 }
 
 func Test_Decode_withLocalization(t *testing.T) {
@@ -742,6 +979,71 @@ func Test_Decode_withLocalization(t *testing.T) {
 	})
 }
 
+func Test_Decode_withHeaderText(t *testing.T) {
+	type Item struct {
+		Col1 int    `csv:"col1"`
+		ID   string `csv:"internal_id"`
+	}
+
+	t.Run("#1: header text of column", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,Customer ID
+			1,abc`)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.ConfigureColumn("internal_id", func(cfg *DecodeColumnConfig) {
+				cfg.HeaderText = "Customer ID"
+			})
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{{Col1: 1, ID: "abc"}}, v)
+
+		// The column name from the struct tag is not accepted anymore
+		_, err = makeDecoder("col1,internal_id\n1,abc", func(cfg *DecodeConfig) {
+			cfg.ConfigureColumn("internal_id", func(cfg *DecodeColumnConfig) {
+				cfg.HeaderText = "Customer ID"
+			})
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrHeaderColumnUnrecognized)
+	})
+
+	t.Run("#2: localization is bypassed", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col-1,Customer ID
+			1,abc`)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.ParseLocalizedHeader = true
+			cfg.LocalizationFunc = localizeEnUs // no translation for `internal_id`
+			cfg.ConfigureColumn("internal_id", func(cfg *DecodeColumnConfig) {
+				cfg.HeaderText = "Customer ID"
+			})
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{{Col1: 1, ID: "abc"}}, v)
+	})
+
+	t.Run("#3: header text configured by alias", func(t *testing.T) {
+		type Item struct {
+			Email string `csv:"email,aliases=e_mail"`
+		}
+		data := gofn.MultilineString(
+			`Customer Email
+			a@x.com`)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.ConfigureColumn("e_mail", func(cfg *DecodeColumnConfig) {
+				cfg.HeaderText = "Customer Email"
+			})
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{{Email: "a@x.com"}}, v)
+	})
+}
+
 func Test_Decode_withCustomUnmarshaler(t *testing.T) {
 	t.Run("#1: no decode func matching", func(t *testing.T) {
 		data := gofn.MultilineString(
@@ -1023,78 +1325,3320 @@ func Test_Decode_specialTypes(t *testing.T) {
 	})
 }
 
-func Test_Decode_incorrectStructure(t *testing.T) {
+func Test_Decode_withInferInterfaceTypes(t *testing.T) {
 	type Item struct {
-		ColX bool `csv:",optional"`
-		ColY bool
-		Col1 int     `csv:"col1"`
-		Col2 float32 `csv:"col2"`
+		Col1 any  `csv:"col1"`
+		Col2 *any `csv:"col2"`
 	}
+	data := gofn.MultilineString(
+		`col1,col2
+		1,2.5
+		-1e3,true
+		abc,
+		NaN,Inf
+		t,99999999999999999999`)
+
+	t.Run("#1: infer types", func(t *testing.T) {
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.InferInterfaceTypes = true
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{
+			{Col1: int64(1), Col2: gofn.New[any](2.5)},
+			{Col1: float64(-1000), Col2: gofn.New[any](true)},
+			{Col1: "abc", Col2: gofn.New[any]("")},
+			{Col1: "NaN", Col2: gofn.New[any]("Inf")},
+			{Col1: true, Col2: gofn.New[any](float64(1e20))},
+		}, v)
+	})
 
-	t.Run("#1: row field count not match header", func(t *testing.T) {
+	t.Run("#2: column decode func takes precedence", func(t *testing.T) {
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.InferInterfaceTypes = true
+			cfg.ConfigureColumn("col1", func(cfg *DecodeColumnConfig) {
+				cfg.DecodeFunc = func(s string, v reflect.Value) error {
+					v.Set(reflect.ValueOf(len(s)))
+					return nil
+				}
+			})
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, v[0].Col1)
+		assert.Equal(t, 4, v[1].Col1)
+		assert.Equal(t, gofn.New[any](2.5), v[0].Col2)
+	})
+}
+
+func Test_Decode_withTime(t *testing.T) {
+	type Item struct {
+		Col1 time.Time  `csv:"col1"`
+		Col2 *time.Time `csv:"col2,omitempty"`
+	}
+	tz := time.FixedZone("", 7*3600)
+
+	t.Run("#1: default layouts", func(t *testing.T) {
 		data := gofn.MultilineString(
 			`col1,col2
-			1,1.1
-			1000,2.2,invalid,
-			2,2.2,abc,123
-			3`)
+			2020-01-02T10:20:30+07:00,2020-01-02
+			2020-01-02 10:20:30,`)
 
 		var v []Item
-		ret, err := makeDecoder(data).Decode(&v)
-		assert.Nil(t, ret)
-		assert.ErrorIs(t, err, ErrDecodeRowFieldCount)
+		_, err := makeDecoder(data).Decode(&v)
+		assert.Nil(t, err)
+		assert.True(t, time.Date(2020, 1, 2, 10, 20, 30, 0, tz).Equal(v[0].Col1))
+		assert.Equal(t, time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC), *v[0].Col2)
+		assert.Equal(t, time.Date(2020, 1, 2, 10, 20, 30, 0, time.UTC), v[1].Col1)
+		assert.Nil(t, v[1].Col2)
 	})
 
-	t.Run("#2: row field count not match header (TreatAsError = false)", func(t *testing.T) {
+	t.Run("#2: global and column layouts", func(t *testing.T) {
 		data := gofn.MultilineString(
 			`col1,col2
-			1,1.1
-			1000,2.2,invalid,
-			2,2.2,abc,123
-			3`)
+			02/01/2020 +0700,2020.01.02`)
 
 		var v []Item
-		ret, err := makeDecoder(data, func(cfg *DecodeConfig) {
-			cfg.TreatIncorrectStructureAsError = false
-			cfg.StopOnError = false
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.TimeLayout = "02/01/2006 -0700"
+			cfg.ConfigureColumn("col2", func(cfg *DecodeColumnConfig) {
+				cfg.TimeLayout = "2006.01.02"
+			})
 		}).Decode(&v)
-		assert.Equal(t, 5, ret.TotalRow())
-		assert.Equal(t, 3, err.(*Errors).TotalError())
-		assert.ErrorIs(t, err.(*Errors).Unwrap()[0], ErrDecodeRowFieldCount)
-		assert.ErrorIs(t, err.(*Errors).Unwrap()[1], ErrDecodeRowFieldCount)
-		assert.ErrorIs(t, err.(*Errors).Unwrap()[2], ErrDecodeRowFieldCount)
+		assert.Nil(t, err)
+		assert.True(t, time.Date(2020, 1, 2, 0, 0, 0, 0, tz).Equal(v[0].Col1))
+		assert.Equal(t, time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC), *v[0].Col2)
 	})
 
-	t.Run("#3: invalid field quote", func(t *testing.T) {
+	t.Run("#3: invalid value", func(t *testing.T) {
 		data := gofn.MultilineString(
 			`col1,col2
-			1,1.1
-			"1000"",2.2,
-			2,2.2""`)
+			2020/01/02,`)
 
 		var v []Item
-		ret, err := makeDecoder(data).Decode(&v)
-		assert.Nil(t, ret)
-		assert.Equal(t, 1, err.(*Errors).TotalError())
-		assert.ErrorIs(t, err, ErrDecodeQuoteInvalid)
+		_, err := makeDecoder(data).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		cellErr := err.(*Errors).Unwrap()[0].(*RowErrors).Unwrap()[0].(*CellError) // nolint: errorlint
+		assert.Equal(t, defaultDecodeTimeLayouts, cellErr.Fields()["Layouts"])
 	})
 
-	t.Run("#4: invalid field quote (TreatAsError = false)", func(t *testing.T) {
+	t.Run("#4: format tag and default layouts", func(t *testing.T) {
+		type Item struct {
+			Col1 time.Time  `csv:"col1,format=02/01/2006"`
+			Col2 *time.Time `csv:"col2"`
+		}
 		data := gofn.MultilineString(
 			`col1,col2
-			1,1.1
-			"1000"",2.2
-			2,2.2
-			3,3.3`)
+			02/01/2020,2020.01.02
+			03/01/2020,2020/01/03`)
 
 		var v []Item
-		ret, err := makeDecoder(data, func(cfg *DecodeConfig) {
-			cfg.TreatIncorrectStructureAsError = false
-			cfg.StopOnError = false
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.DefaultTimeLayouts = []string{"2006.01.02", "2006/01/02"}
 		}).Decode(&v)
-		assert.Equal(t, 3, ret.TotalRow())
-		assert.Equal(t, 1, err.(*Errors).TotalError())
-		assert.ErrorIs(t, err, ErrDecodeQuoteInvalid)
+		assert.Nil(t, err)
+		assert.Equal(t, time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC), v[0].Col1)
+		assert.Equal(t, time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC), *v[0].Col2)
+		assert.Equal(t, time.Date(2020, 1, 3, 0, 0, 0, 0, time.UTC), v[1].Col1)
+		assert.Equal(t, time.Date(2020, 1, 3, 0, 0, 0, 0, time.UTC), *v[1].Col2)
+	})
+
+	t.Run("#5: format tag invalid value", func(t *testing.T) {
+		type Item struct {
+			Col1 time.Time `csv:"col1,format=02/01/2006"`
+		}
+		data := gofn.MultilineString(
+			`col1
+			2020-01-02`)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.ConfigureColumn("col1", func(cfg *DecodeColumnConfig) {
+				cfg.OnCellErrorFunc = func(e *CellError) {
+					e.SetLocalizationKey("{{.Value}} does not match {{index .Layouts 0}}")
+				}
+			})
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		r, _ := NewRenderer(err.(*Errors)) // nolint: errorlint
+		msg, _, _ := r.Render()
+		assert.Contains(t, msg, "2020-01-02 does not match 02/01/2006")
+	})
+
+	t.Run("#6: format tag on pointer and float fields", func(t *testing.T) {
+		type Item struct {
+			Col1 *time.Time `csv:"col1,omitempty,format=2006-01-02"`
+			Col2 float64    `csv:"col2,format=.2f"`
+		}
+		data := gofn.MultilineString(
+			`col1,col2
+			2020-01-02,1.2345
+			,1`)
+
+		var v []Item
+		_, err := makeDecoder(data).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{{Col1: gofn.New(time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)), Col2: 1.2345}, {Col2: 1}}, v)
+
+		_, err = makeDecoder("col1,col2\n02/01/2020,1").Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+	})
+
+	t.Run("#7: format tag not accepted for the type", func(t *testing.T) {
+		type Item struct {
+			Col1 string `csv:"col1,format=2006-01-02"`
+		}
+		var v []Item
+		_, err := makeDecoder("col1\nabc").Decode(&v)
+		assert.ErrorIs(t, err, ErrTagOptionInvalid)
+
+		type Item2 struct {
+			Col1 float64 `csv:"col1,format=2006-01-02"`
+		}
+		var v2 []Item2
+		_, err = makeDecoder("col1\n1").Decode(&v2)
+		assert.ErrorIs(t, err, ErrTagOptionInvalid)
+	})
+}
+
+func Test_Decode_withTypeDecodeFuncs(t *testing.T) {
+	type Item struct {
+		Col1 time.Time  `csv:"col1"`
+		Col2 *time.Time `csv:"col2,omitempty"`
+		Col3 time.Time  `csv:"col3"`
+		Col4 int        `csv:"col4"`
+	}
+	decodeUnixTime := func(s string, v reflect.Value) error {
+		sec, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(time.Unix(sec, 0).UTC()))
+		return nil
+	}
+	data := gofn.MultilineString(
+		`col1,col2,col3,col4
+		60,120,180,1
+		0,,2020-01-02,2`)
+
+	t.Run("#1: all fields of the type", func(t *testing.T) {
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.TypeDecodeFuncs = map[reflect.Type]DecodeFunc{timeType: decodeUnixTime}
+		}).Decode(&v)
+		assert.ErrorIs(t, err, strconv.ErrSyntax)
+
+		_, err = makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.TypeDecodeFuncs = map[reflect.Type]DecodeFunc{timeType: decodeUnixTime}
+			cfg.ConfigureColumn("col3", func(cfg *DecodeColumnConfig) {
+				cfg.DecodeFunc = func(s string, v reflect.Value) error {
+					v.Set(reflect.ValueOf(time.Time{}))
+					return nil
+				}
+			})
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{
+			{Col1: time.Unix(60, 0).UTC(), Col2: gofn.New(time.Unix(120, 0).UTC()), Col4: 1},
+			{Col1: time.Unix(0, 0).UTC(), Col4: 2},
+		}, v)
+	})
+
+	t.Run("#2: config takes precedence over the global registry", func(t *testing.T) {
+		RegisterDecodeFunc(timeType, func(s string, v reflect.Value) error {
+			return ErrDecodeValueType
+		})
+		t.Cleanup(ClearRegisteredFuncs)
+
+		var v []Item
+		_, err := makeDecoder(gofn.MultilineString(
+			`col1,col2,col3,col4
+			60,120,180,1`), func(cfg *DecodeConfig) {
+			cfg.TypeDecodeFuncs = map[reflect.Type]DecodeFunc{timeType: decodeUnixTime}
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, time.Unix(180, 0).UTC(), v[0].Col3)
+	})
+}
+
+func Test_Decode_withDuration(t *testing.T) {
+	t.Run("#1: success", func(t *testing.T) {
+		type Item struct {
+			Col1 time.Duration  `csv:"col1"`
+			Col2 *time.Duration `csv:"col2,omitempty"`
+			Col3 time.Duration  `csv:"col3,format=seconds"`
+		}
+		data := gofn.MultilineString(
+			`col1,col2,col3
+			1h30m,250ms,90
+			0s,,1.5`)
+
+		var v []Item
+		_, err := makeDecoder(data).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{
+			{Col1: 90 * time.Minute, Col2: gofn.New(250 * time.Millisecond), Col3: 90 * time.Second},
+			{Col1: 0, Col2: nil, Col3: 1500 * time.Millisecond},
+		}, v)
+	})
+
+	t.Run("#2: invalid value", func(t *testing.T) {
+		type Item struct {
+			Col1 time.Duration `csv:"col1"`
+			Col2 time.Duration `csv:"col2,format=seconds"`
+		}
+		data := gofn.MultilineString(
+			`col1,col2
+			90,1m`)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.StopOnError = false
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		rowErr := err.(*Errors).Unwrap()[0].(*RowErrors) // nolint: errorlint
+		assert.Equal(t, 2, rowErr.TotalCellError())
+		assert.Contains(t, rowErr.Unwrap()[0].Error(), "(90)")
+		assert.Contains(t, rowErr.Unwrap()[1].Error(), "(1m)")
+	})
+
+	t.Run("#3: invalid format", func(t *testing.T) {
+		type Item struct {
+			Col1 time.Duration `csv:"col1,format=minutes"`
+		}
+		data := gofn.MultilineString(
+			`col1
+			1`)
+
+		var v []Item
+		_, err := makeDecoder(data).Decode(&v)
+		assert.ErrorIs(t, err, ErrTagOptionInvalid)
+	})
+}
+
+func Test_Decode_withSQLNullTypes(t *testing.T) {
+	type Item struct {
+		Col1 sql.NullString  `csv:"col1"`
+		Col2 sql.NullInt64   `csv:"col2"`
+		Col3 sql.NullInt32   `csv:"col3"`
+		Col4 sql.NullFloat64 `csv:"col4"`
+		Col5 sql.NullBool    `csv:"col5"`
+		Col6 *sql.NullInt64  `csv:"col6"`
+		Col7 *sql.NullString `csv:"col7,omitempty"`
+		Col8 []sql.NullInt64 `csv:"col8,sep=;"`
+		Col9 sql.NullTime    `csv:"col9"`
+	}
+
+	t.Run("#1: success", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2,col3,col4,col5,col6,col7,col8,col9
+			abc,123,-12,1.5,yes,0,x,1;;3,2024-01-02
+			,,,,,,,,`)
+
+		var v []Item
+		_, err := makeDecoder(data).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{
+			{
+				Col1: sql.NullString{String: "abc", Valid: true},
+				Col2: sql.NullInt64{Int64: 123, Valid: true},
+				Col3: sql.NullInt32{Int32: -12, Valid: true},
+				Col4: sql.NullFloat64{Float64: 1.5, Valid: true},
+				Col5: sql.NullBool{Bool: true, Valid: true},
+				Col6: &sql.NullInt64{Int64: 0, Valid: true},
+				Col7: &sql.NullString{String: "x", Valid: true},
+				Col8: []sql.NullInt64{{Int64: 1, Valid: true}, {}, {Int64: 3, Valid: true}},
+				Col9: sql.NullTime{Time: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), Valid: true},
+			},
+			{Col6: &sql.NullInt64{}},
+		}, v)
+	})
+
+	t.Run("#2: invalid value", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2,col3,col4,col5,col6,col7,col8,col9
+			abc,1x,,,maybe,,,,`)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.StopOnError = false
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		rowErr := err.(*Errors).Unwrap()[0].(*RowErrors) // nolint: errorlint
+		assert.Equal(t, 2, rowErr.TotalCellError())
+		assert.True(t, rowErr.HasCellError(1))
+		assert.True(t, rowErr.HasCellError(4))
+	})
+}
+
+func Test_Decode_withBoolValues(t *testing.T) {
+	type Item struct {
+		Col1 bool   `csv:"col1"`
+		Col2 *bool  `csv:"col2"`
+		Col3 []bool `csv:"col3,sep=;"`
+	}
+
+	t.Run("#1: default values", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2,col3
+			Yes,off,Y;N;TRUE;0
+			tRuE,NO,on;F`)
+
+		var v []Item
+		_, err := makeDecoder(data).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{
+			{Col1: true, Col2: gofn.New(false), Col3: []bool{true, false, true, false}},
+			{Col1: true, Col2: gofn.New(false), Col3: []bool{true, false}},
+		}, v)
+	})
+
+	t.Run("#2: custom values with column overrides", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2,col3
+			oui,X,oui;non
+			NON,-,`)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.BoolTrueValues = []string{"oui"}
+			cfg.BoolFalseValues = []string{"non"}
+			cfg.ConfigureColumn("col2", func(cfg *DecodeColumnConfig) {
+				cfg.BoolTrueValues = []string{"x"}
+				cfg.BoolFalseValues = []string{"-"}
+			})
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{
+			{Col1: true, Col2: gofn.New(true), Col3: []bool{true, false}},
+			{Col1: false, Col2: gofn.New(false)},
+		}, v)
+	})
+
+	t.Run("#3: value not in the sets", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2,col3
+			yes,maybe,`)
+
+		var v []Item
+		_, err := makeDecoder(data).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		cellErr := err.(*Errors).Unwrap()[0].(*RowErrors).Unwrap()[0].(*CellError) // nolint: errorlint
+		assert.Equal(t, "col2", cellErr.Header())
+		assert.Equal(t, "maybe", cellErr.fields["Token"])
+
+		_, err = makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.BoolTrueValues = []string{"1"}
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		assert.Equal(t, "col1", err.(*Errors).Unwrap()[0].(*RowErrors).Unwrap()[0].(*CellError).Header()) // nolint: errorlint
+	})
+
+	t.Run("#4: case-sensitive values", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2,col3
+			Y,N,Y;N`)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.BoolTrueValues = []string{"Y"}
+			cfg.BoolFalseValues = []string{"N"}
+			cfg.BoolCaseSensitive = true
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{{Col1: true, Col2: gofn.New(false), Col3: []bool{true, false}}}, v)
+
+		_, err = makeDecoder(gofn.MultilineString(
+			`col1,col2,col3
+			Y,n,`), func(cfg *DecodeConfig) {
+			cfg.BoolTrueValues = []string{"Y"}
+			cfg.BoolFalseValues = []string{"N"}
+			cfg.BoolCaseSensitive = true
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		assert.Equal(t, "col2", err.(*Errors).Unwrap()[0].(*RowErrors).Unwrap()[0].(*CellError).Header()) // nolint: errorlint
+	})
+}
+
+func Test_Decode_withIntBase(t *testing.T) {
+	t.Run("#1: success", func(t *testing.T) {
+		type Item struct {
+			Col1 int    `csv:"col1,base=16"`
+			Col2 *uint8 `csv:"col2,base=2"`
+			Col3 int64  `csv:"col3,base=0"`
+			Col4 []uint `csv:"col4,sep=;,base=8"`
+		}
+		data := gofn.MultilineString(
+			`col1,col2,col3,col4
+			1F,101,0x1F,755;17
+			-ff,11111111,0755,
+			0,0,-0b11,`)
+
+		var v []Item
+		_, err := makeDecoder(data).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{
+			{Col1: 31, Col2: gofn.New[uint8](5), Col3: 31, Col4: []uint{493, 15}},
+			{Col1: -255, Col2: gofn.New[uint8](255), Col3: 493},
+			{Col1: 0, Col2: gofn.New[uint8](0), Col3: -3},
+		}, v)
+	})
+
+	t.Run("#2: invalid digits for the base", func(t *testing.T) {
+		type Item struct {
+			Col1 int   `csv:"col1,base=8"`
+			Col2 uint8 `csv:"col2,base=16"`
+		}
+		data := gofn.MultilineString(
+			`col1,col2
+			78,1
+			1,1FF`)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.StopOnError = false
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		errs := err.(*Errors).Unwrap() // nolint: errorlint
+		assert.Equal(t, 2, len(errs))
+		assert.Equal(t, "col1", errs[0].(*RowErrors).Unwrap()[0].(*CellError).Header()) // nolint: errorlint
+		assert.Equal(t, "col2", errs[1].(*RowErrors).Unwrap()[0].(*CellError).Header()) // nolint: errorlint
+	})
+
+	t.Run("#3: base tag on non-integer column", func(t *testing.T) {
+		type Item struct {
+			Col1 float64 `csv:"col1,base=16"`
+		}
+		var v []Item
+		_, err := makeDecoder("col1\n1").Decode(&v)
+		assert.ErrorIs(t, err, ErrTagOptionInvalid)
+	})
+}
+
+func Test_Decode_withNumberFormat(t *testing.T) {
+	type Item struct {
+		Col1 int      `csv:"col1"`
+		Col2 *float64 `csv:"col2"`
+		Col3 uint     `csv:"col3"`
+		Col4 string   `csv:"col4"`
+	}
+
+	t.Run("#1: group by comma", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2,col3,col4
+			"-1,234","1,234.56","1,000,000","1,234.56"`)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.NumberFormat = &NumberFormat{GroupSeparator: ','}
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{{Col1: -1234, Col2: gofn.New(1234.56), Col3: 1000000, Col4: "1,234.56"}}, v)
+	})
+
+	t.Run("#2: european format", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2,col3,col4
+			1.234,"1.234,56",0,"1.234,56"`)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.NumberFormat = &NumberFormat{GroupSeparator: '.', DecimalSeparator: ','}
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{{Col1: 1234, Col2: gofn.New(1234.56), Col3: 0, Col4: "1.234,56"}}, v)
+	})
+
+	t.Run("#3: invalid number", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2,col3,col4
+			"1,234","1,234.56",-1,abc`)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.NumberFormat = &NumberFormat{GroupSeparator: ','}
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		assert.Equal(t, "col3", err.(*Errors).Unwrap()[0].(*RowErrors).Unwrap()[0].(*CellError).Header()) // nolint: errorlint
+	})
+
+	t.Run("#4: invalid config", func(t *testing.T) {
+		var v []Item
+		_, err := makeDecoder("col1,col2,col3,col4", func(cfg *DecodeConfig) {
+			cfg.NumberFormat = &NumberFormat{GroupSeparator: '.'}
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrConfigOptionInvalid)
+	})
+}
+
+func Test_Decode_withSliceSeparator(t *testing.T) {
+	t.Run("#1: success", func(t *testing.T) {
+		type Item struct {
+			Col1 []string `csv:"col1,sep=;"`
+			Col2 []int    `csv:"col2,sep=|"`
+			Col3 []*bool  `csv:"col3,sep=;"`
+		}
+		data := gofn.MultilineString(
+			`col1,col2,col3
+			red;blue;green,1|2,true;false
+			abc,,`)
+
+		var v []Item
+		_, err := makeDecoder(data).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{
+			{Col1: []string{"red", "blue", "green"}, Col2: []int{1, 2}, Col3: []*bool{gofn.New(true), gofn.New(false)}},
+			{Col1: []string{"abc"}, Col2: nil, Col3: nil},
+		}, v)
+	})
+
+	t.Run("#2: invalid element", func(t *testing.T) {
+		type Item struct {
+			Col1 []int `csv:"col1,sep=;"`
+		}
+		data := gofn.MultilineString(
+			`col1
+			1;x;3`)
+
+		var v []Item
+		_, err := makeDecoder(data).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		rowErr := err.(*Errors).Unwrap()[0].(*RowErrors) // nolint: errorlint
+		assert.Equal(t, 1, rowErr.TotalCellError())
+		cellErr := rowErr.Unwrap()[0].(*CellError) // nolint: errorlint
+		assert.Contains(t, cellErr.Error(), "(element 1)")
+		assert.Equal(t, 1, cellErr.Fields()["ElementIndex"])
+	})
+
+	t.Run("#3: invalid element of time slice", func(t *testing.T) {
+		type Item struct {
+			Col1 []time.Time `csv:"col1,sep=;,format=2006-01-02"`
+		}
+		data := gofn.MultilineString(
+			`col1
+			2020-01-02;2020/01/03`)
+
+		var v []Item
+		_, err := makeDecoder(data).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		cellErr := err.(*Errors).Unwrap()[0].(*RowErrors).Unwrap()[0].(*CellError) // nolint: errorlint
+		assert.Equal(t, 1, cellErr.Fields()["ElementIndex"])
+		assert.Equal(t, []string{"2006-01-02"}, cellErr.Fields()["Layouts"])
+	})
+
+	t.Run("#4: sep tag on non-slice field", func(t *testing.T) {
+		type Item struct {
+			Col1 string `csv:"col1,sep=;"`
+		}
+		var v []Item
+		_, err := makeDecoder("col1\nabc").Decode(&v)
+		assert.ErrorIs(t, err, ErrTagOptionInvalid)
+	})
+}
+
+func Test_Decode_withEmbeddedStruct(t *testing.T) {
+	type Audit struct {
+		CreatedBy string `csv:"created_by"`
+		UpdatedBy string `csv:"updated_by,optional"`
+	}
+	type Base struct {
+		ID int `csv:"id"`
+		Audit
+	}
+
+	t.Run("#1: success", func(t *testing.T) {
+		type Item struct {
+			Base
+			Name string `csv:"name"`
+		}
+		data := gofn.MultilineString(
+			`id,created_by,updated_by,name
+			1,tom,jerry,abc
+			2,tom,,def`)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.ConfigureColumn("created_by", func(cfg *DecodeColumnConfig) {
+				cfg.ValidatorFuncs = []ValidatorFunc{ValidatorStrLen[string](1, 5)}
+			})
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{
+			{Base: Base{ID: 1, Audit: Audit{CreatedBy: "tom", UpdatedBy: "jerry"}}, Name: "abc"},
+			{Base: Base{ID: 2, Audit: Audit{CreatedBy: "tom"}}, Name: "def"},
+		}, v)
+	})
+
+	t.Run("#2: embedded pointer with prefix and optional", func(t *testing.T) {
+		type Item struct {
+			Name   string `csv:"name"`
+			*Audit `csv:",optional,prefix=audit_"`
+		}
+		data := gofn.MultilineString(
+			`name,audit_created_by
+			abc,tom`)
+
+		var v []Item
+		ret, err := makeDecoder(data).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"audit_updated_by"}, ret.MissingOptionalColumns())
+		assert.Equal(t, []Item{{Name: "abc", Audit: &Audit{CreatedBy: "tom"}}}, v)
+
+		_, err = makeDecoder("name\nabc").Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{{Name: "abc"}}, v)
+	})
+
+	t.Run("#3: embedded struct with explicit name is a normal column", func(t *testing.T) {
+		type Item struct {
+			Name    string `csv:"name"`
+			StrType `csv:"str"`
+		}
+		var v []Item
+		_, err := makeDecoder("name,str\nabc,xyz").Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{{Name: "abc", StrType: "xyz"}}, v)
+	})
+
+	t.Run("#4: duplicated column from embedded struct", func(t *testing.T) {
+		type Item struct {
+			CreatedBy string `csv:"created_by"`
+			Audit
+		}
+		var v []Item
+		_, err := makeDecoder("created_by,created_by").Decode(&v)
+		assert.ErrorIs(t, err, ErrHeaderColumnDuplicated)
+	})
+
+	t.Run("#6: deep field with pointer intermediate", func(t *testing.T) {
+		type Level3 struct {
+			Code string `csv:"code"`
+		}
+		type Level2 struct {
+			*Level3
+		}
+		type Item struct {
+			Name string `csv:"name"`
+			Level2
+		}
+		var v []Item
+		_, err := makeDecoder("name,code\nabc,x1").Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{{Name: "abc", Level2: Level2{Level3: &Level3{Code: "x1"}}}}, v)
+	})
+
+	t.Run("#5: column errors of embedded struct", func(t *testing.T) {
+		type Item struct {
+			Base
+		}
+		var v []Item
+		_, err := makeDecoder("id,created_by\nx,tom").Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		cellErr := err.(*Errors).Unwrap()[0].(*RowErrors).Unwrap()[0].(*CellError) // nolint: errorlint
+		assert.Equal(t, "id", cellErr.Header())
+	})
+}
+
+func Test_Decode_withRestColumn(t *testing.T) {
+	type Item struct {
+		Col1 int               `csv:"col1"`
+		Col2 string            `csv:"col2,optional"`
+		Rest map[string]string `csv:",rest"`
+	}
+
+	t.Run("#1: success", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,extra1,col2,extra2
+			1,a,x,b
+			2,,y,c`)
+
+		var v []Item
+		ret, err := makeDecoder(data).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{
+			{Col1: 1, Col2: "x", Rest: map[string]string{"extra1": "a", "extra2": "b"}},
+			{Col1: 2, Col2: "y", Rest: map[string]string{"extra1": "", "extra2": "c"}},
+		}, v)
+		assert.Equal(t, 0, len(ret.UnrecognizedColumns()))
+	})
+
+	t.Run("#2: no extra columns", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2
+			1,x`)
+
+		var v []Item
+		_, err := makeDecoder(data).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{{Col1: 1, Col2: "x"}}, v)
+	})
+
+	t.Run("#3: rest column with invalid type", func(t *testing.T) {
+		type Item struct {
+			Col1 int            `csv:"col1"`
+			Rest map[string]int `csv:",rest"`
+		}
+		var v []Item
+		_, err := makeDecoder("col1,extra\n1,2").Decode(&v)
+		assert.ErrorIs(t, err, ErrTagOptionInvalid)
+	})
+
+	t.Run("#4: rest column in no header mode", func(t *testing.T) {
+		var v []Item
+		_, err := makeDecoder("1,x,a", func(cfg *DecodeConfig) {
+			cfg.NoHeaderMode = true
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrTagOptionInvalid)
+	})
+
+	t.Run("#5: required column is still checked", func(t *testing.T) {
+		var v []Item
+		_, err := makeDecoder("col2,extra\nx,a").Decode(&v)
+		assert.ErrorIs(t, err, ErrHeaderColumnRequired)
+	})
+}
+
+func Test_Decode_withColumnIndex(t *testing.T) {
+	type Item struct {
+		Col1 int     `csv:"col1,index=2"`
+		Col2 float32 `csv:"col2,index=0"`
+		ColX string  `csv:"-"`
+	}
+
+	t.Run("#1: success", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`1.1,abc,1,xyz
+			2.2,def,2,uvw`)
+
+		var v []Item
+		ret, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.NoHeaderMode = true
+			cfg.AllowUnrecognizedColumns = true
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, ret.TotalRow())
+		assert.Equal(t, []Item{{Col1: 1, Col2: 1.1}, {Col1: 2, Col2: 2.2}}, v)
+	})
+
+	t.Run("#2: unmapped columns not allowed", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`1.1,abc,1
+			2.2,def,2`)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.NoHeaderMode = true
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrHeaderColumnUnrecognized)
+	})
+
+	t.Run("#3: extra columns not allowed", func(t *testing.T) {
+		type Item struct {
+			Col1 int     `csv:"col1,index=1"`
+			Col2 float32 `csv:"col2,index=0"`
+		}
+		data := gofn.MultilineString(
+			`1.1,1,abc
+			2.2,2,def`)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.NoHeaderMode = true
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeRowFieldCount)
+	})
+
+	t.Run("#4: duplicated index", func(t *testing.T) {
+		type Item struct {
+			Col1 int     `csv:"col1,index=1"`
+			Col2 float32 `csv:"col2,index=1"`
+		}
+		var v []Item
+		_, err := makeDecoder("1,2", func(cfg *DecodeConfig) {
+			cfg.NoHeaderMode = true
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrTagOptionInvalid)
+	})
+
+	t.Run("#5: index tag missing on some fields", func(t *testing.T) {
+		type Item struct {
+			Col1 int     `csv:"col1,index=1"`
+			Col2 float32 `csv:"col2"`
+		}
+		var v []Item
+		_, err := makeDecoder("1,2", func(cfg *DecodeConfig) {
+			cfg.NoHeaderMode = true
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrTagOptionInvalid)
+	})
+
+	t.Run("#6: index tag is ignored when header is present", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2
+			1,2.2`)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.RequireColumnOrder = false
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{{Col1: 1, Col2: 2.2}}, v)
+	})
+}
+
+func Test_Decode_withDefaultValue(t *testing.T) {
+	type Item struct {
+		Col1 int     `csv:"col1"`
+		Col2 float32 `csv:"col2,optional"`
+		Col3 string  `csv:"col3,omitempty"`
+		Col4 *int    `csv:"col4,optional,omitempty"`
+	}
+
+	t.Run("#1: missing optional columns", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col3
+			1,abc
+			2,def`)
+
+		var v []Item
+		ret, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.ConfigureColumn("col2", func(cfg *DecodeColumnConfig) {
+				cfg.DefaultValue = "1.5"
+			})
+			cfg.ConfigureColumn("col4", func(cfg *DecodeColumnConfig) {
+				cfg.DefaultValue = "10"
+			})
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"col2", "col4"}, ret.MissingOptionalColumns())
+		assert.Equal(t, []Item{
+			{Col1: 1, Col2: 1.5, Col3: "abc", Col4: gofn.New(10)},
+			{Col1: 2, Col2: 1.5, Col3: "def", Col4: gofn.New(10)},
+		}, v)
+	})
+
+	t.Run("#2: empty cells", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2,col3
+			,,
+			2, ,`)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.TrimSpace = true
+			cfg.ConfigureColumn("col1", func(cfg *DecodeColumnConfig) {
+				cfg.DefaultValue = "100"
+			})
+			cfg.ConfigureColumn("col2", func(cfg *DecodeColumnConfig) {
+				cfg.DefaultValue = "1.5"
+			})
+			cfg.ConfigureColumn("col3", func(cfg *DecodeColumnConfig) {
+				cfg.DefaultValue = "abc" // not applied as the column is omitempty
+			})
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{{Col1: 100, Col2: 1.5}, {Col1: 2, Col2: 1.5}}, v)
+	})
+
+	t.Run("#3: default value invalid", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col3
+			,abc
+			2,def`)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.StopOnError = false
+			cfg.ConfigureColumn("col1", func(cfg *DecodeColumnConfig) {
+				cfg.DefaultValue = "abc"
+			})
+			cfg.ConfigureColumn("col2", func(cfg *DecodeColumnConfig) {
+				cfg.DefaultValue = "xyz"
+			})
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		errs := err.(*Errors).Unwrap() // nolint: errorlint
+		assert.Equal(t, 2, len(errs))
+		rowErrs := errs[0].(*RowErrors).Unwrap() // nolint: errorlint
+		assert.Equal(t, 2, len(rowErrs))
+		assert.Equal(t, "abc", rowErrs[0].(*CellError).Value())   // nolint: errorlint
+		assert.Equal(t, "col2", rowErrs[1].(*CellError).Header()) // nolint: errorlint
+		assert.Equal(t, "xyz", rowErrs[1].(*CellError).Value())   // nolint: errorlint
+	})
+
+	t.Run("#4: default value set via tag", func(t *testing.T) {
+		type Item struct {
+			Col1 int    `csv:"col1,default=10"`
+			Col2 string `csv:"col2,optional,default=not set"`
+			Col3 string `csv:"col3,omitempty,default=abc"`
+			Col4 string `csv:"col4,default="`
+			Col5 int    `csv:"col5,optional,default=5"`
+		}
+		data := gofn.MultilineString(
+			`col1,col2,col3,col4
+			,,,
+			1,x,y,z`)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.ConfigureColumn("col5", func(cfg *DecodeColumnConfig) {
+				cfg.DefaultValue = "50" // overrides the tag
+			})
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{
+			// Default value is not applied for empty cells of omitempty column
+			{Col1: 10, Col2: "not set", Col5: 50},
+			{Col1: 1, Col2: "x", Col3: "y", Col4: "z", Col5: 50},
+		}, v)
+	})
+}
+
+func Test_Decode_withNullValues(t *testing.T) {
+	type Item struct {
+		Col1 int      `csv:"col1"`
+		Col2 *float32 `csv:"col2"`
+		Col3 *int     `csv:"col3,omitempty"`
+		Col4 string   `csv:"col4"`
+	}
+
+	t.Run("#1: success", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2,col3,col4
+			N/A,NULL, n/a ,-
+			1,2.5,3,abc`)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.TrimSpace = true
+			cfg.NullValues = []string{"N/A", "n/a", "NULL", "-"}
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{
+			{},
+			{Col1: 1, Col2: gofn.New[float32](2.5), Col3: gofn.New(3), Col4: "abc"},
+		}, v)
+	})
+
+	t.Run("#2: validators are skipped for null values", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2,col3,col4
+			N/A,N/A,N/A,N/A`)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.NullValues = []string{"N/A"}
+			cfg.ConfigureColumn("col1", func(cfg *DecodeColumnConfig) {
+				cfg.ValidatorFuncs = []ValidatorFunc{ValidatorGT(0)}
+			})
+			cfg.ConfigureColumn("col4", func(cfg *DecodeColumnConfig) {
+				cfg.ValidatorFuncs = []ValidatorFunc{ValidatorStrLen[string](1, 10)}
+			})
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{{}}, v)
+	})
+
+	t.Run("#3: column null values override global ones", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2,col3,col4
+			0,-,-,N/A`)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.NullValues = []string{"N/A"}
+			cfg.ConfigureColumn("col2", func(cfg *DecodeColumnConfig) {
+				cfg.NullValues = []string{"-"}
+			})
+			cfg.ConfigureColumn("col4", func(cfg *DecodeColumnConfig) {
+				cfg.NullValues = []string{}
+			})
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		assert.Equal(t, "col3", err.(*Errors).Unwrap()[0].(*RowErrors).Unwrap()[0].(*CellError).Header()) // nolint: errorlint
+	})
+
+	t.Run("#4: decode one into non-empty value", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2,col3,col4
+			NULL,NULL,NULL,NULL`)
+
+		item := Item{Col1: 1, Col2: gofn.New[float32](2), Col3: gofn.New(3), Col4: "abc"}
+		err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.NullValues = []string{"NULL"}
+		}).DecodeOne(&item)
+		assert.Nil(t, err)
+		assert.Equal(t, Item{}, item)
+	})
+
+	t.Run("#5: case-insensitive comparison after preprocessors", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2,col3,col4
+			null,Null,[n/a],\N`)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.NullValues = []string{"NULL", "N/A", "\\N"}
+			cfg.NullValuesIgnoreCase = true
+			cfg.ConfigureColumn("col3", func(cfg *DecodeColumnConfig) {
+				cfg.PreprocessorFuncs = []ProcessorFunc{func(s string) string { return strings.Trim(s, "[]") }}
+			})
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{{}}, v)
+
+		_, err = makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.NullValues = []string{"NULL"}
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+	})
+}
+
+func Test_Decode_withFallbackValue(t *testing.T) {
+	type Item struct {
+		Col1 int      `csv:"col1"`
+		Col2 *float64 `csv:"col2"`
+		Col3 int      `csv:"col3"`
+	}
+	data := gofn.MultilineString(
+		`col1,col2,col3
+		1,1.5,100
+		x,abc,200
+		3,2.5,y`)
+
+	t.Run("#1: fallback values", func(t *testing.T) {
+		var v []Item
+		ret, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.ConfigureColumn("col1", func(cfg *DecodeColumnConfig) {
+				cfg.OnErrorUseFallback = true
+				cfg.FallbackValue = "-1"
+			})
+			cfg.ConfigureColumn("col2", func(cfg *DecodeColumnConfig) {
+				cfg.OnErrorUseFallback = true
+			})
+			cfg.ConfigureColumn("col3", func(cfg *DecodeColumnConfig) {
+				cfg.OnErrorUseFallback = true
+				cfg.ValidatorFuncs = []ValidatorFunc{ValidatorGTE(0)}
+			})
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{
+			{Col1: 1, Col2: gofn.New(1.5), Col3: 100},
+			{Col1: -1, Col2: nil, Col3: 200},
+			{Col1: 3, Col2: gofn.New(2.5), Col3: 0},
+		}, v)
+		assert.Equal(t, map[string]int{"col1": 1, "col2": 1, "col3": 1}, ret.FallbackCounts())
+
+		warnings := ret.Warnings()
+		assert.Equal(t, 2, warnings.TotalRowError())
+		assert.Equal(t, 3, warnings.TotalCellError())
+		assert.ErrorIs(t, warnings, ErrDecodeValueType)
+		rowErr := warnings.Unwrap()[0].(*RowErrors) // nolint: errorlint
+		assert.Equal(t, 3, rowErr.Row())
+		cellErr := rowErr.Unwrap()[1].(*CellError) // nolint: errorlint
+		assert.Equal(t, "col2", cellErr.Header())
+		assert.Equal(t, "abc", cellErr.Value())
+	})
+
+	t.Run("#2: fallback value fails to decode", func(t *testing.T) {
+		var v []Item
+		ret, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.StopOnError = false
+			cfg.ConfigureColumn("col1", func(cfg *DecodeColumnConfig) {
+				cfg.OnErrorUseFallback = true
+				cfg.FallbackValue = "invalid"
+			})
+			cfg.ConfigureColumn("col3", func(cfg *DecodeColumnConfig) {
+				cfg.OnErrorUseFallback = true
+			})
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		assert.Equal(t, 2, err.(*Errors).TotalCellError())
+		assert.Equal(t, map[string]int{"col3": 1}, ret.FallbackCounts())
+	})
+
+	t.Run("#3: no warning", func(t *testing.T) {
+		var v []Item
+		ret, err := makeDecoder(gofn.MultilineString(
+			`col1,col2,col3
+			1,1.5,100`), func(cfg *DecodeConfig) {
+			cfg.ConfigureColumn("col1", func(cfg *DecodeColumnConfig) {
+				cfg.OnErrorUseFallback = true
+			})
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Nil(t, ret.Warnings())
+		assert.Nil(t, ret.FallbackCounts())
+	})
+}
+
+func Test_Decode_withRequired(t *testing.T) {
+	type Item struct {
+		Col1 int     `csv:"col1"`
+		Col2 string  `csv:"col2,required"`
+		Col3 *string `csv:"col3,optional,required"`
+	}
+
+	t.Run("#1: success", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2,col3
+			1,abc,x
+			2,def,y`)
+
+		var v []Item
+		_, err := makeDecoder(data).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{
+			{Col1: 1, Col2: "abc", Col3: gofn.New("x")},
+			{Col1: 2, Col2: "def", Col3: gofn.New("y")},
+		}, v)
+	})
+
+	t.Run("#2: empty cell", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2,col3
+			1,abc,x
+			2,"  ",y`)
+
+		var v []Item
+		_, err := makeDecoder(data).Decode(&v)
+		assert.ErrorIs(t, err, ErrValidationRequired)
+		assert.ErrorIs(t, err, ErrValidation)
+		rowErr := err.(*Errors).Unwrap()[0].(*RowErrors) // nolint: errorlint
+		assert.Equal(t, 3, rowErr.Row())
+		cellErr := rowErr.Unwrap()[0].(*CellError) // nolint: errorlint
+		assert.Equal(t, "col2", cellErr.Header())
+		assert.Equal(t, 1, cellErr.Column())
+		assert.Equal(t, "  ", cellErr.Value())
+	})
+
+	t.Run("#3: missing optional column is not checked", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2
+			1,abc`)
+
+		var v []Item
+		_, err := makeDecoder(data).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{{Col1: 1, Col2: "abc"}}, v)
+	})
+
+	t.Run("#4: default and null values", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2,col3
+			1,,N/A`)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.StopOnError = false
+			cfg.NullValues = []string{"N/A"}
+			cfg.ConfigureColumn("col2", func(cfg *DecodeColumnConfig) {
+				cfg.DefaultValue = "abc"
+			})
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrValidationRequired)
+		rowErrs := err.(*Errors).Unwrap()[0].(*RowErrors).Unwrap() // nolint: errorlint
+		assert.Equal(t, 1, len(rowErrs))
+		assert.Equal(t, "col3", rowErrs[0].(*CellError).Header()) // nolint: errorlint
+	})
+
+	t.Run("#5: StopOnError is false", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2,col3
+			1,,
+			2,abc,
+			3,def,z`)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.StopOnError = false
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrValidationRequired)
+		errs := err.(*Errors).Unwrap() // nolint: errorlint
+		assert.Equal(t, 2, len(errs))
+		assert.Equal(t, 2, len(errs[0].(*RowErrors).Unwrap())) // nolint: errorlint
+		assert.Equal(t, 1, len(errs[1].(*RowErrors).Unwrap())) // nolint: errorlint
+	})
+
+	t.Run("#6: stop on error of a specific column", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2,col3
+			1,abc,
+			2,,y
+			3,,z`)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.StopOnError = false
+			cfg.ConfigureColumn("col2", func(cfg *DecodeColumnConfig) {
+				cfg.StopOnError = true
+			})
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrValidationRequired)
+		errs := err.(*Errors).Unwrap() // nolint: errorlint
+		assert.Equal(t, 2, len(errs))
+		assert.Equal(t, 3, errs[1].(*RowErrors).Row()) // nolint: errorlint
+	})
+}
+
+func Test_Decode_withSkipInitialRows(t *testing.T) {
+	type Item struct {
+		Col1 int     `csv:"col1"`
+		Col2 float32 `csv:"col2"`
+	}
+
+	t.Run("#1: success", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`Report of Items
+			Generated at,2024-01-01,by admin
+			col1,col2
+			1,2.2
+			2,3.3`)
+
+		var v []Item
+		ret, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.SkipInitialRows = 2
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, 5, ret.TotalRow())
+		assert.Equal(t, 2, ret.SkippedRows())
+		assert.Equal(t, []Item{{Col1: 1, Col2: 2.2}, {Col1: 2, Col2: 3.3}}, v)
+	})
+
+	t.Run("#2: row and line of errors count from the beginning", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`Report of Items
+
+			col1,col2
+			1,2.2
+			abc,3.3`)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.SkipInitialRows = 1
+			cfg.DetectRowLine = true
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		rowErr := err.(*Errors).Unwrap()[0].(*RowErrors) // nolint: errorlint
+		assert.Equal(t, 4, rowErr.Row())
+		assert.Equal(t, 5, rowErr.Line())
+	})
+
+	t.Run("#3: no header mode", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`Report of Items
+			1,2.2
+			2,abc`)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.NoHeaderMode = true
+			cfg.SkipInitialRows = 1
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		assert.Equal(t, 3, err.(*Errors).Unwrap()[0].(*RowErrors).Row()) // nolint: errorlint
+	})
+
+	t.Run("#4: input has not enough rows", func(t *testing.T) {
+		var v []Item
+		_, err := makeDecoder("Report of Items", func(cfg *DecodeConfig) {
+			cfg.SkipInitialRows = 2
+		}).Decode(&v)
+		assert.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("#5: invalid config", func(t *testing.T) {
+		var v []Item
+		_, err := makeDecoder("col1,col2", func(cfg *DecodeConfig) {
+			cfg.SkipInitialRows = -1
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrConfigOptionInvalid)
+	})
+}
+
+func Test_Decode_withSkipRows(t *testing.T) {
+	type Item struct {
+		Col1 int     `csv:"col1"`
+		Col2 float32 `csv:"col2"`
+	}
+
+	t.Run("#1: success", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2
+			Generated at,2024-01-01
+			1,2.2
+			2,3.3`)
+
+		var v []Item
+		ret, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.SkipRows = 1
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, 4, ret.TotalRow())
+		assert.Equal(t, 1, ret.SkippedRows())
+		assert.Equal(t, []Item{{Col1: 1, Col2: 2.2}, {Col1: 2, Col2: 3.3}}, v)
+	})
+
+	t.Run("#2: SkipRows is 0", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2
+			1,2.2
+			2,3.3`)
+
+		var v []Item
+		ret, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.SkipRows = 0
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, 3, ret.TotalRow())
+		assert.Equal(t, 0, ret.SkippedRows())
+		assert.Equal(t, []Item{{Col1: 1, Col2: 2.2}, {Col1: 2, Col2: 3.3}}, v)
+	})
+
+	t.Run("#3: compose with HeaderRowIndex", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`Report of Items
+			col1,col2
+			Generated at,2024-01-01
+			1,2.2
+			abc,3.3`)
+
+		var v []Item
+		ret, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.HeaderRowIndex = 1
+			cfg.SkipRows = 1
+			cfg.StopOnError = false
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		assert.Equal(t, 5, err.(*Errors).Unwrap()[0].(*RowErrors).Row()) // nolint: errorlint
+		assert.Equal(t, 5, ret.TotalRow())
+		assert.Equal(t, 2, ret.SkippedRows())
+	})
+
+	t.Run("#4: no header mode", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`Report of Items
+			Generated at,2024-01-01,by admin
+			1,2.2
+			2,abc`)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.NoHeaderMode = true
+			cfg.SkipInitialRows = 1
+			cfg.SkipRows = 1
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		assert.Equal(t, 4, err.(*Errors).Unwrap()[0].(*RowErrors).Row()) // nolint: errorlint
+	})
+
+	t.Run("#5: input ends before all rows are skipped", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2
+			Generated at,2024-01-01`)
+
+		var v []Item
+		ret, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.SkipRows = 3
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, ret.TotalRow())
+		assert.Equal(t, 1, ret.SkippedRows())
+		assert.Equal(t, 0, len(v))
+	})
+
+	t.Run("#6: skipped rows are not checked with TreatIncorrectStructureAsError", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2
+			Generated at 2024-01-01
+			1,2.2
+			2,3.3,extra`)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.SkipRows = 1
+			cfg.TreatIncorrectStructureAsError = true
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeRowFieldCount)
+		assert.ErrorContains(t, err, "row 4")
+	})
+
+	t.Run("#7: invalid config", func(t *testing.T) {
+		var v []Item
+		_, err := makeDecoder("col1,col2", func(cfg *DecodeConfig) {
+			cfg.SkipRows = -1
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrConfigOptionInvalid)
+
+		_, err = makeDecoder("col1,col2", func(cfg *DecodeConfig) {
+			cfg.HeaderRowIndex = 1
+			cfg.SkipInitialRows = 1
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrConfigOptionInvalid)
+
+		_, err = makeDecoder("col1,col2", func(cfg *DecodeConfig) {
+			cfg.HeaderRowIndex = 1
+			cfg.NoHeaderMode = true
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrConfigOptionInvalid)
+	})
+
+	t.Run("#8: paging with MaxRows", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2
+			1,2.2
+			2,3.3
+			abc,4.4
+			4,5.5`)
+
+		var v []Item
+		ret, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.SkipRows = 1
+			cfg.MaxRows = 2
+			cfg.StopOnError = false
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		assert.Equal(t, 4, err.(*Errors).Unwrap()[0].(*RowErrors).Row()) // nolint: errorlint
+		assert.True(t, ret.Truncated())
+		assert.Equal(t, 4, ret.TotalRow())
+		assert.Equal(t, 1, ret.SkippedRows())
+	})
+}
+
+func Test_Decode_withMaxRows(t *testing.T) {
+	type Item struct {
+		Col1 int     `csv:"col1"`
+		Col2 float32 `csv:"col2"`
+	}
+	data := gofn.MultilineString(
+		`col1,col2
+		1,2.2
+		2,3.3
+		3,4.4`)
+
+	t.Run("#1: number of rows equals to MaxRows", func(t *testing.T) {
+		var v []Item
+		ret, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.MaxRows = 3
+			cfg.ErrorOnMaxRows = true
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.False(t, ret.Truncated())
+		assert.Equal(t, 4, ret.TotalRow())
+		assert.Equal(t, []Item{{Col1: 1, Col2: 2.2}, {Col1: 2, Col2: 3.3}, {Col1: 3, Col2: 4.4}}, v)
+	})
+
+	t.Run("#2: truncate the remaining rows", func(t *testing.T) {
+		var v []Item
+		ret, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.MaxRows = 2
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.True(t, ret.Truncated())
+		assert.Equal(t, 3, ret.TotalRow())
+		assert.Equal(t, []Item{{Col1: 1, Col2: 2.2}, {Col1: 2, Col2: 3.3}}, v)
+	})
+
+	t.Run("#3: truncate when decoding row by row", func(t *testing.T) {
+		decoder := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.MaxRows = 1
+		})
+		var item Item
+		assert.Nil(t, decoder.DecodeOne(&item))
+		assert.Equal(t, Item{Col1: 1, Col2: 2.2}, item)
+		assert.ErrorIs(t, decoder.DecodeOne(&item), ErrFinished)
+		ret, err := decoder.Finish()
+		assert.Nil(t, err)
+		assert.True(t, ret.Truncated())
+		assert.Equal(t, 2, ret.TotalRow())
+	})
+
+	t.Run("#4: return error when the input has more rows", func(t *testing.T) {
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.MaxRows = 2
+			cfg.ErrorOnMaxRows = true
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrMaxRowsExceeded)
+		assert.Nil(t, v)
+	})
+
+	t.Run("#5: invalid config", func(t *testing.T) {
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.MaxRows = -1
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrConfigOptionInvalid)
+	})
+}
+
+func Test_Decode_withMaxErrors(t *testing.T) {
+	type Item struct {
+		Col1 int `csv:"col1"`
+		Col2 int `csv:"col2"`
+	}
+	data := gofn.MultilineString(
+		`col1,col2
+		x,1
+		2,y
+		x,y
+		4,4
+		x,5`)
+
+	t.Run("#1: errors under the limit", func(t *testing.T) {
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.StopOnError = false
+			cfg.MaxErrors = 10
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		assert.NotErrorIs(t, err, ErrTooManyErrors)
+		assert.False(t, err.(*Errors).Truncated())
+		assert.Equal(t, 5, err.(*Errors).TotalError())
+	})
+
+	t.Run("#2: errors reach the limit", func(t *testing.T) {
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.StopOnError = false
+			cfg.MaxErrors = 2
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrTooManyErrors)
+		errs := err.(*Errors)
+		assert.True(t, errs.Truncated())
+		assert.Equal(t, 2, errs.TotalRowError())
+		assert.Equal(t, 2, errs.TotalCellError())
+		assert.Equal(t, 3, len(errs.Unwrap()))
+
+		r, _ := NewRenderer(errs, func(cfg *ErrorRenderConfig) {
+			cfg.HeaderFormatKey = "{{.TotalCellError}} errors{{if .Truncated}} (truncated){{end}}"
+		})
+		msg, _, _ := r.Render()
+		assert.Contains(t, msg, "2 errors (truncated)")
+	})
+
+	t.Run("#3: the row reaching the limit is kept entirely", func(t *testing.T) {
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.StopOnError = false
+			cfg.MaxErrors = 3
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrTooManyErrors)
+		assert.Equal(t, 4, err.(*Errors).TotalCellError())
+	})
+
+	t.Run("#4: with DecodeOne", func(t *testing.T) {
+		d := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.StopOnError = false
+			cfg.MaxErrors = 1
+		})
+		var v Item
+		assert.ErrorIs(t, d.DecodeOne(&v), ErrDecodeValueType)
+		assert.ErrorIs(t, d.DecodeOne(&v), ErrAlreadyFailed)
+		_, err := d.Finish()
+		assert.ErrorIs(t, err, ErrTooManyErrors)
+	})
+
+	t.Run("#5: invalid config", func(t *testing.T) {
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.MaxErrors = -1
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrConfigOptionInvalid)
+	})
+}
+
+func Test_Decode_withStripBOM(t *testing.T) {
+	type Item struct {
+		Col1 int    `csv:"col1"`
+		Col2 string `csv:"col2"`
+	}
+
+	t.Run("#1: header with BOM", func(t *testing.T) {
+		data := "\uFEFF" + gofn.MultilineString(
+			`col1,col2
+			1,abc`)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.StripBOM = true
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{{Col1: 1, Col2: "abc"}}, v)
+
+		_, err = makeDecoder(data).Decode(&v)
+		assert.ErrorIs(t, err, ErrHeaderColumnUnrecognized)
+	})
+
+	t.Run("#2: data without BOM is unaffected", func(t *testing.T) {
+		data := "col1,col2\n1,\uFEFFabc"
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.StripBOM = true
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{{Col1: 1, Col2: "\uFEFFabc"}}, v)
+	})
+
+	t.Run("#3: no header mode", func(t *testing.T) {
+		data := "\uFEFF" + gofn.MultilineString(
+			`1,abc
+			2,def`)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.NoHeaderMode = true
+			cfg.StripBOM = true
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{{Col1: 1, Col2: "abc"}, {Col1: 2, Col2: "def"}}, v)
+	})
+
+	t.Run("#4: with skipped initial rows", func(t *testing.T) {
+		data := "\uFEFF" + gofn.MultilineString(
+			`report
+			col1,col2
+			1,abc`)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.SkipInitialRows = 1
+			cfg.StripBOM = true
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{{Col1: 1, Col2: "abc"}}, v)
+	})
+}
+
+func Test_Decode_withCommentChar(t *testing.T) {
+	type Item struct {
+		Col1 int    `csv:"col1"`
+		Col2 string `csv:"col2"`
+	}
+
+	t.Run("#1: success", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2
+			# first comment
+			1,a#b
+			  #second, comment
+			2,"#c"
+			3,x`)
+
+		var v []Item
+		ret, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.CommentChar = '#'
+			cfg.TrimSpace = true
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, 6, ret.TotalRow())
+		assert.Equal(t, 2, ret.SkippedRows())
+		assert.Equal(t, []Item{{Col1: 1, Col2: "a#b"}, {Col1: 2, Col2: "#c"}, {Col1: 3, Col2: "x"}}, v)
+	})
+
+	t.Run("#2: row numbers of errors", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2
+			// comment
+			abc,x`)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.CommentChar = '/'
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		assert.Equal(t, 3, err.(*Errors).Unwrap()[0].(*RowErrors).Row()) // nolint: errorlint
+	})
+
+	t.Run("#3: header is a comment row", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`#col1,col2
+			1,x`)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.CommentChar = '#'
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrHeaderColumnInvalid)
+	})
+
+	t.Run("#4: no header mode", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`# generated data
+			1,x
+			# another comment
+			2,y`)
+
+		var v []Item
+		ret, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.NoHeaderMode = true
+			cfg.CommentChar = '#'
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, 4, ret.TotalRow())
+		assert.Equal(t, 2, ret.SkippedRows())
+		assert.Equal(t, []Item{{Col1: 1, Col2: "x"}, {Col1: 2, Col2: "y"}}, v)
+	})
+
+	t.Run("#5: comment char not configured", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2
+			#1,x`)
+
+		var v []Item
+		_, err := makeDecoder(data).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+	})
+
+	t.Run("#6: quoted first field is not a comment", func(t *testing.T) {
+		type Item struct {
+			Col1 string `csv:"col1"`
+			Col2 int    `csv:"col2"`
+		}
+		data := "col1,col2\n\"#quoted\",1\n#real,2\nx,3\n\"#multi\nline\",4\n\"#a\"\"b\",5\n"
+
+		var v []Item
+		ret, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.CommentChar = '#'
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, ret.SkippedRows())
+		assert.Equal(t, []Item{{Col1: "#quoted", Col2: 1}, {Col1: "x", Col2: 3}, {Col1: "#multi\nline", Col2: 4},
+			{Col1: "#a\"b", Col2: 5}}, v)
+	})
+
+	t.Run("#7: quoted first field with leading spaces trimmed", func(t *testing.T) {
+		type Item struct {
+			Col1 string `csv:"col1"`
+			Col2 int    `csv:"col2"`
+		}
+		r := csv.NewReader(strings.NewReader("\ufeffcol1,col2\n \"#quoted\", 1\n  #real, 2\n#x, 3\n"))
+		r.TrimLeadingSpace = true
+
+		var v []Item
+		ret, err := NewDecoder(r, func(cfg *DecodeConfig) {
+			cfg.CommentChar = '#'
+			cfg.TrimSpace = true
+			cfg.StripBOM = true
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, ret.SkippedRows())
+		assert.Equal(t, []Item{{Col1: "#quoted", Col2: 1}}, v)
+	})
+}
+
+func Test_Decode_withSkipEmptyRows(t *testing.T) {
+	type Item struct {
+		Col1 int    `csv:"col1"`
+		Col2 string `csv:"col2"`
+	}
+
+	t.Run("#1: success", func(t *testing.T) {
+		data := "col1,col2\n1,a\n,\n  ,\t\n2,b\n   \n"
+
+		var v []Item
+		ret, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.SkipEmptyRows = true
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, 6, ret.TotalRow())
+		assert.Equal(t, 3, ret.SkippedEmptyRows())
+		assert.Equal(t, 0, ret.SkippedRows())
+		assert.Equal(t, []Item{{Col1: 1, Col2: "a"}, {Col1: 2, Col2: "b"}}, v)
+	})
+
+	t.Run("#2: row numbers of errors", func(t *testing.T) {
+		data := "col1,col2\n,\nabc,x\n"
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.SkipEmptyRows = true
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		assert.Equal(t, 3, err.(*Errors).Unwrap()[0].(*RowErrors).Row()) // nolint: errorlint
+	})
+
+	t.Run("#3: no header mode", func(t *testing.T) {
+		data := " \n1,x\n,\n2,y\n"
+
+		var v []Item
+		ret, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.NoHeaderMode = true
+			cfg.SkipEmptyRows = true
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, 4, ret.TotalRow())
+		assert.Equal(t, 2, ret.SkippedEmptyRows())
+		assert.Equal(t, []Item{{Col1: 1, Col2: "x"}, {Col1: 2, Col2: "y"}}, v)
+	})
+
+	t.Run("#4: empty rows not skipped by default", func(t *testing.T) {
+		data := "col1,col2\n1,a\n,\n"
+
+		var v []Item
+		_, err := makeDecoder(data).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		assert.Equal(t, 3, err.(*Errors).Unwrap()[0].(*RowErrors).Row()) // nolint: errorlint
+	})
+}
+
+func Test_Decode_withHeaderNormalizeFunc(t *testing.T) {
+	type Item struct {
+		Col1 int     `csv:"col 1"`
+		Col2 float32 `csv:"col2"`
+	}
+	normalizeFunc := func(s string) string {
+		s = strings.TrimPrefix(s, "\uFEFF")
+		s = strings.TrimSuffix(s, "*")
+		return strings.Join(strings.Fields(s), " ")
+	}
+
+	t.Run("#1: success", func(t *testing.T) {
+		data := gofn.MultilineString(
+			"\uFEFFcol   1*,col2 ,Col X\n" +
+				`1,2.5,x`)
+
+		var v []Item
+		ret, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.HeaderNormalizeFunc = normalizeFunc
+			cfg.AllowUnrecognizedColumns = true
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"Col X"}, ret.UnrecognizedColumns())
+		assert.Equal(t, []Item{{Col1: 1, Col2: 2.5}}, v)
+	})
+
+	t.Run("#2: raw header in errors", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col 1*,  col2
+			abc,2.5`)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.HeaderNormalizeFunc = normalizeFunc
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		assert.Equal(t, []string{"col 1*", "  col2"}, err.(*Errors).Header()) // nolint: errorlint
+
+		data = gofn.MultilineString(
+			`col 1*,col3*
+			abc,2.5`)
+		_, err = makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.HeaderNormalizeFunc = normalizeFunc
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrHeaderColumnUnrecognized)
+		assert.Contains(t, err.Error(), `"col3*"`)
+	})
+
+	t.Run("#3: normalized header duplicated", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col 1,col  1*
+			1,2`)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.HeaderNormalizeFunc = normalizeFunc
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrHeaderColumnDuplicated)
+	})
+}
+
+func Test_Decode_withAliases(t *testing.T) {
+	type Item struct {
+		Name string `csv:"name"`
+		Qty  int    `csv:"qty,aliases=quantity|qty ordered"`
+		Note string `csv:"note,optional,aliases=comment"`
+	}
+
+	t.Run("#1: success", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`name,qty ordered,comment
+			abc,10,x
+			def,20,y`)
+
+		var v []Item
+		ret, err := makeDecoder(data).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]string{"qty": "qty ordered", "note": "comment"}, ret.UsedAliases())
+		assert.Equal(t, []Item{{Name: "abc", Qty: 10, Note: "x"}, {Name: "def", Qty: 20, Note: "y"}}, v)
+	})
+
+	t.Run("#2: no alias used", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`name,qty
+			abc,10`)
+
+		var v []Item
+		ret, err := makeDecoder(data).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(ret.UsedAliases()))
+		assert.Equal(t, []string{"note"}, ret.MissingOptionalColumns())
+		assert.Equal(t, []Item{{Name: "abc", Qty: 10}}, v)
+	})
+
+	t.Run("#3: multiple aliases matched", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`name,qty,quantity
+			abc,10,10`)
+
+		var v []Item
+		_, err := makeDecoder(data).Decode(&v)
+		assert.ErrorIs(t, err, ErrHeaderColumnDuplicated)
+	})
+
+	t.Run("#4: alias conflicts with other column", func(t *testing.T) {
+		type Item struct {
+			Name string `csv:"name"`
+			Qty  int    `csv:"qty,aliases=name"`
+		}
+		var v []Item
+		_, err := makeDecoder("name,qty").Decode(&v)
+		assert.ErrorIs(t, err, ErrHeaderColumnDuplicated)
+	})
+
+	t.Run("#5: errors refer to the alias", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`name,quantity
+			abc,xyz`)
+
+		var v []Item
+		_, err := makeDecoder(data).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		cellErr := err.(*Errors).Unwrap()[0].(*RowErrors).Unwrap()[0].(*CellError) // nolint: errorlint
+		assert.Equal(t, "quantity", cellErr.Header())
+	})
+
+	t.Run("#6: aliases set via column config", func(t *testing.T) {
+		type Item struct {
+			Email string `csv:"email"`
+			Phone string `csv:"phone,optional"`
+		}
+		withAliases := func(cfg *DecodeConfig) {
+			cfg.ConfigureColumn("email", func(cfg *DecodeColumnConfig) {
+				cfg.Aliases = []string{"email_address", "mail"}
+			})
+			cfg.ConfigureColumn("phone", func(cfg *DecodeColumnConfig) {
+				cfg.Aliases = []string{"phone_number"}
+			})
+		}
+
+		// Primary found, aliases ignored
+		var v []Item
+		ret, err := makeDecoder("email,phone\na@x.com,123", withAliases).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(ret.UsedAliases()))
+		assert.Equal(t, []Item{{Email: "a@x.com", Phone: "123"}}, v)
+
+		// Alias matched
+		ret, err = makeDecoder("mail,phone_number\na@x.com,123", withAliases).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]string{"email": "mail", "phone": "phone_number"}, ret.UsedAliases())
+		assert.Equal(t, []Item{{Email: "a@x.com", Phone: "123"}}, v)
+
+		// No match on optional column
+		ret, err = makeDecoder("email_address\na@x.com", withAliases).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"phone"}, ret.MissingOptionalColumns())
+		assert.Equal(t, []Item{{Email: "a@x.com"}}, v)
+
+		// No match on required column
+		_, err = makeDecoder("phone\n123", withAliases).Decode(&v)
+		assert.ErrorIs(t, err, ErrHeaderColumnRequired)
+	})
+
+	t.Run("#7: tag aliases extended by column config", func(t *testing.T) {
+		var v []Item
+		ret, err := makeDecoder("name,amount\nabc,10", func(cfg *DecodeConfig) {
+			cfg.ConfigureColumn("qty", func(cfg *DecodeColumnConfig) {
+				cfg.Aliases = []string{"amount"}
+			})
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]string{"qty": "amount"}, ret.UsedAliases())
+		assert.Equal(t, []Item{{Name: "abc", Qty: 10}}, v)
+	})
+
+	t.Run("#8: duplicated alias across columns", func(t *testing.T) {
+		type Item struct {
+			Email string `csv:"email"`
+			Phone string `csv:"phone,optional"`
+		}
+		var v []Item
+		_, err := makeDecoder("email,phone", func(cfg *DecodeConfig) {
+			cfg.ConfigureColumn("email", func(cfg *DecodeColumnConfig) {
+				cfg.Aliases = []string{"contact"}
+			})
+			cfg.ConfigureColumn("phone", func(cfg *DecodeColumnConfig) {
+				cfg.Aliases = []string{"contact"}
+			})
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrHeaderColumnDuplicated)
+	})
+
+	t.Run("#9: alias option with column config set via alias", func(t *testing.T) {
+		type Item struct {
+			Email string `csv:"email,alias=email_address;e-mail"`
+			Name  string `csv:"name,alias=email_address"`
+		}
+		type Item2 struct {
+			Email string `csv:"email,alias=email_address;e-mail"`
+		}
+		var v []Item
+		_, err := makeDecoder("email,name").Decode(&v)
+		assert.ErrorIs(t, err, ErrHeaderColumnDuplicated)
+
+		var v2 []Item2
+		ret, err := makeDecoder("e-mail\n A@X.COM ", func(cfg *DecodeConfig) {
+			cfg.ConfigureColumn("email_address", func(cfg *DecodeColumnConfig) {
+				cfg.TrimSpace = true
+				cfg.PreprocessorFuncs = []ProcessorFunc{strings.ToLower}
+			})
+		}).Decode(&v2)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]string{"email": "e-mail"}, ret.UsedAliases())
+		assert.Equal(t, []Item2{{Email: "a@x.com"}}, v2)
+	})
+}
+
+func Test_Decode_withCaseInsensitiveHeader(t *testing.T) {
+	type Item struct {
+		Email string `csv:"email"`
+		Name  string `csv:"name,aliases=full_name"`
+		Age   int    `csv:"age,optional"`
+	}
+
+	t.Run("#1: columns in different case", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`EMAIL,Full_Name
+			a@x.com,tom`)
+
+		var v []Item
+		d := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.CaseInsensitiveHeader = true
+		})
+		ret, err := d.Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{{Email: "a@x.com", Name: "tom"}}, v)
+		assert.Equal(t, []string{"age"}, ret.MissingOptionalColumns())
+		assert.Equal(t, map[string]string{"name": "Full_Name"}, ret.UsedAliases())
+		assert.Equal(t, "EMAIL", ret.ColumnMapping()[0].HeaderText)
+		assert.Equal(t, "email", ret.ColumnMapping()[0].HeaderKey)
+	})
+
+	t.Run("#2: case-sensitive by default", func(t *testing.T) {
+		var v []Item
+		_, err := makeDecoder("EMAIL,name\na@x.com,tom").Decode(&v)
+		assert.ErrorIs(t, err, ErrHeaderColumnUnrecognized)
+	})
+
+	t.Run("#3: columns differing only in case", func(t *testing.T) {
+		var v []Item
+		_, err := makeDecoder("email,name,Email\na@x.com,tom,b@x.com", func(cfg *DecodeConfig) {
+			cfg.CaseInsensitiveHeader = true
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrHeaderColumnDuplicated)
+
+		_, err = makeDecoder("email,name,note,NOTE\na@x.com,tom,a,b", func(cfg *DecodeConfig) {
+			cfg.CaseInsensitiveHeader = true
+			cfg.AllowUnrecognizedColumns = true
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrHeaderColumnDuplicated)
+	})
+}
+
+func Test_Decode_withDeduplicateHeaders(t *testing.T) {
+	type Item struct {
+		Name    string `csv:"name"`
+		Amount  int    `csv:"amount"`
+		Amount2 int    `csv:"amount_2"`
+	}
+
+	t.Run("#1: duplicated columns renamed", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`name,amount,amount
+			tom,1,2`)
+
+		var v []Item
+		ret, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.DeduplicateHeaders = true
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{{Name: "tom", Amount: 1, Amount2: 2}}, v)
+		assert.Equal(t, map[string]string{"amount_2": "amount"}, ret.RenamedColumns())
+		assert.Equal(t, []string{"name", "amount", "amount"}, ret.ParsedHeader())
+	})
+
+	t.Run("#2: renamed columns treated as unrecognized", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`name,amount,amount_2,amount,name
+			tom,1,2,3,jerry`)
+
+		var v []Item
+		ret, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.DeduplicateHeaders = true
+			cfg.AllowUnrecognizedColumns = true
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{{Name: "tom", Amount: 1, Amount2: 2}}, v)
+		// The new names do not conflict with the existing columns
+		assert.Equal(t, map[string]string{"amount_3": "amount", "name_2": "name"}, ret.RenamedColumns())
+		assert.Equal(t, []string{"amount_3", "name_2"}, ret.UnrecognizedColumns())
+	})
+
+	t.Run("#3: case-insensitive header", func(t *testing.T) {
+		var v []Item
+		ret, err := makeDecoder("name,Amount,AMOUNT\ntom,1,2", func(cfg *DecodeConfig) {
+			cfg.DeduplicateHeaders = true
+			cfg.CaseInsensitiveHeader = true
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{{Name: "tom", Amount: 1, Amount2: 2}}, v)
+		assert.Equal(t, map[string]string{"AMOUNT_2": "AMOUNT"}, ret.RenamedColumns())
+	})
+
+	t.Run("#4: duplicated columns fail by default", func(t *testing.T) {
+		var v []Item
+		ret, err := makeDecoder("name,amount,amount\ntom,1,2").Decode(&v)
+		assert.Nil(t, ret)
+		assert.ErrorIs(t, err, ErrHeaderColumnDuplicated)
+	})
+}
+
+func Test_Decode_columnMapping(t *testing.T) {
+	t.Run("#1: unordered header with inline columns", func(t *testing.T) {
+		type Inline struct {
+			Sub1 int `csv:"sub1"`
+			Sub2 int `csv:"sub2"`
+		}
+		type Item struct {
+			Name string `csv:"name"`
+			Fix  Inline `csv:"fix,inline,prefix=f_"`
+			Qty  int    `csv:"qty,aliases=quantity"`
+		}
+		data := gofn.MultilineString(
+			`quantity,f_sub2,name,f_sub1
+			1,2,abc,3`)
+
+		var v []Item
+		ret, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.RequireColumnOrder = false
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []ColumnMapping{
+			{FileColumn: 0, HeaderText: "quantity", HeaderKey: "qty", StructField: "Qty"},
+			{FileColumn: 1, HeaderText: "f_sub2", HeaderKey: "f_sub2", StructField: "Fix"},
+			{FileColumn: 2, HeaderText: "name", HeaderKey: "name", StructField: "Name"},
+			{FileColumn: 3, HeaderText: "f_sub1", HeaderKey: "f_sub1", StructField: "Fix"},
+		}, ret.ColumnMapping())
+	})
+
+	t.Run("#2: unrecognized columns", func(t *testing.T) {
+		type Item struct {
+			Name string `csv:"name"`
+			Age  int    `csv:"age"`
+		}
+		var v []Item
+		ret, err := makeDecoder("name,extra,age\nabc,x,1", func(cfg *DecodeConfig) {
+			cfg.AllowUnrecognizedColumns = true
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []ColumnMapping{
+			{FileColumn: 0, HeaderText: "name", HeaderKey: "name", StructField: "Name"},
+			{FileColumn: 1, HeaderText: "extra", HeaderKey: "extra", Unrecognized: true},
+			{FileColumn: 2, HeaderText: "age", HeaderKey: "age", StructField: "Age"},
+		}, ret.ColumnMapping())
+	})
+
+	t.Run("#3: rest column and raw header", func(t *testing.T) {
+		type Item struct {
+			Name  string            `csv:"name"`
+			Extra map[string]string `csv:",rest"`
+		}
+		var v []Item
+		ret, err := makeDecoder("Name,Mark\nabc,1", func(cfg *DecodeConfig) {
+			cfg.HeaderNormalizeFunc = strings.ToLower
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []ColumnMapping{
+			{FileColumn: 0, HeaderText: "Name", HeaderKey: "name", StructField: "Name"},
+			{FileColumn: 1, HeaderText: "Mark", HeaderKey: "mark", StructField: "Extra"},
+		}, ret.ColumnMapping())
+	})
+
+	t.Run("#4: decoding into maps", func(t *testing.T) {
+		var v []map[string]string
+		ret, err := makeDecoder("name\nabc").Decode(&v)
+		assert.Nil(t, err)
+		assert.Nil(t, ret.ColumnMapping())
+	})
+}
+
+func Test_Decode_withColumnNameMap(t *testing.T) {
+	type Item struct {
+		Name string `csv:"name"`
+		Age  int    `csv:"age"`
+	}
+	columnNameMap := map[string]string{"Full Name": "name", "Years": "age"}
+
+	t.Run("#1: success", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`Full Name,Years,Note
+			jerry,20,x`)
+
+		var v []Item
+		ret, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.ColumnNameMap = columnNameMap
+			cfg.AllowUnrecognizedColumns = true
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"Note"}, ret.UnrecognizedColumns())
+		assert.Equal(t, []Item{{Name: "jerry", Age: 20}}, v)
+	})
+
+	t.Run("#2: unmapped column not allowed", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`Full Name,Years,Note
+			jerry,20,x`)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.ColumnNameMap = columnNameMap
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrHeaderColumnUnrecognized)
+		assert.Contains(t, err.Error(), `"Note"`)
+	})
+
+	t.Run("#3: errors refer to the original header", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`Full Name,Years
+			jerry,abc`)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.ColumnNameMap = columnNameMap
+			cfg.HeaderNormalizeFunc = strings.TrimSpace
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		errs := err.(*Errors) // nolint: errorlint
+		assert.Equal(t, []string{"Full Name", "Years"}, errs.Header())
+		assert.Equal(t, "Years", errs.Unwrap()[0].(*RowErrors).Unwrap()[0].(*CellError).Header()) // nolint: errorlint
+
+		r, _ := NewCSVRenderer(errs)
+		msg, _, _ := r.RenderAsString()
+		assert.True(t, strings.HasPrefix(msg, "Row,Line,CommonError,Full Name,Years\n"))
+	})
+}
+
+func Test_Decode_incorrectStructure(t *testing.T) {
+	type Item struct {
+		ColX bool `csv:",optional"`
+		ColY bool
+		Col1 int     `csv:"col1"`
+		Col2 float32 `csv:"col2"`
+	}
+
+	t.Run("#1: row field count not match header", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2
+			1,1.1
+			1000,2.2,invalid,
+			2,2.2,abc,123
+			3`)
+
+		var v []Item
+		ret, err := makeDecoder(data).Decode(&v)
+		assert.Nil(t, ret)
+		assert.ErrorIs(t, err, ErrDecodeRowFieldCount)
+	})
+
+	t.Run("#2: row field count not match header (TreatAsError = false)", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2
+			1,1.1
+			1000,2.2,invalid,
+			2,2.2,abc,123
+			3`)
+
+		var v []Item
+		ret, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.TreatIncorrectStructureAsError = false
+			cfg.StopOnError = false
+		}).Decode(&v)
+		assert.Equal(t, 5, ret.TotalRow())
+		assert.Equal(t, 3, err.(*Errors).TotalError())
+		assert.ErrorIs(t, err.(*Errors).Unwrap()[0], ErrDecodeRowFieldCount)
+		assert.ErrorIs(t, err.(*Errors).Unwrap()[1], ErrDecodeRowFieldCount)
+		assert.ErrorIs(t, err.(*Errors).Unwrap()[2], ErrDecodeRowFieldCount)
+	})
+
+	t.Run("#3: invalid field quote", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2
+			1,1.1
+			"1000"",2.2,
+			2,2.2""`)
+
+		var v []Item
+		ret, err := makeDecoder(data).Decode(&v)
+		assert.Nil(t, ret)
+		assert.Equal(t, 1, err.(*Errors).TotalError())
+		assert.ErrorIs(t, err, ErrDecodeQuoteInvalid)
+	})
+
+	t.Run("#4: invalid field quote (TreatAsError = false)", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2
+			1,1.1
+			"1000"",2.2
+			2,2.2
+			3,3.3`)
+
+		var v []Item
+		ret, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.TreatIncorrectStructureAsError = false
+			cfg.StopOnError = false
+		}).Decode(&v)
+		assert.Equal(t, 3, ret.TotalRow())
+		assert.Equal(t, 1, err.(*Errors).TotalError())
+		assert.ErrorIs(t, err, ErrDecodeQuoteInvalid)
+	})
+
+	t.Run("#5: trailing empty columns allowed", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2
+			1,1.1,,
+			2,2.2
+			3,3.3,`)
+
+		var v []Item
+		ret, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.AllowTrailingEmptyColumns = true
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, 4, ret.TotalRow())
+		assert.Equal(t, []Item{{Col1: 1, Col2: 1.1}, {Col1: 2, Col2: 2.2}, {Col1: 3, Col2: 3.3}}, v)
+	})
+
+	t.Run("#6: trailing non-empty columns still fail", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2
+			1,1.1,,
+			2,2.2,, x`)
+
+		var v []Item
+		ret, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.AllowTrailingEmptyColumns = true
+		}).Decode(&v)
+		assert.Nil(t, ret)
+		assert.ErrorIs(t, err, ErrDecodeRowFieldCount)
+	})
+
+	t.Run("#7: trailing empty columns in no header mode", func(t *testing.T) {
+		type Item struct {
+			Col1 int     `csv:"col1"`
+			Col2 float32 `csv:"col2"`
+		}
+		data := gofn.MultilineString(
+			`1,1.1,,
+			2,2.2,,`)
+
+		var v []Item
+		ret, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.NoHeaderMode = true
+			cfg.AllowTrailingEmptyColumns = true
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, ret.TotalRow())
+		assert.Equal(t, []Item{{Col1: 1, Col2: 1.1}, {Col1: 2, Col2: 2.2}}, v)
+
+		// The first row having trailing empty cells does not determine the number of fields
+		data = gofn.MultilineString(
+			`# comment
+			1,1.1,,
+			2,2.2
+			3,3.3,`)
+		ret, err = makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.NoHeaderMode = true
+			cfg.AllowTrailingEmptyColumns = true
+			cfg.CommentChar = '#'
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, 4, ret.TotalRow())
+		assert.Equal(t, []Item{{Col1: 1, Col2: 1.1}, {Col1: 2, Col2: 2.2}, {Col1: 3, Col2: 3.3}}, v)
+
+		_, err = makeDecoder("1,1.1,,\n2,2.2,,x", func(cfg *DecodeConfig) {
+			cfg.NoHeaderMode = true
+			cfg.AllowTrailingEmptyColumns = true
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeRowFieldCount)
+	})
+}
+
+func Test_Decode_withRowFilter(t *testing.T) {
+	type Item struct {
+		Col1 int    `csv:"col1"`
+		Col2 string `csv:"status"`
+	}
+	data := gofn.MultilineString(
+		`col1,status
+			1,DONE
+			2,DRAFT
+			3,DONE
+			abc,DRAFT
+			5,DONE`)
+	skipDraft := func(rawRow []string, header []string) bool {
+		return rawRow[gofn.IndexOf(header, "status")] != "DRAFT"
+	}
+
+	t.Run("#1: filtered rows are skipped", func(t *testing.T) {
+		var v []Item
+		ret, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.RowFilterFunc = skipDraft
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, 6, ret.TotalRow())
+		assert.Equal(t, 2, ret.FilteredRows())
+		assert.Equal(t, []Item{{1, "DONE"}, {3, "DONE"}, {5, "DONE"}}, v)
+	})
+
+	t.Run("#2: errors in kept rows still propagate", func(t *testing.T) {
+		var v []Item
+		ret, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.StopOnError = false
+			cfg.RowFilterFunc = func(rawRow []string, header []string) bool {
+				return rawRow[0] != "2"
+			}
+		}).Decode(&v)
+		assert.Equal(t, 1, ret.FilteredRows())
+		assert.Equal(t, 1, err.(*Errors).TotalRowError())
+		assert.Equal(t, 5, err.(*Errors).Unwrap()[0].(*RowErrors).Row())
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+	})
+
+	t.Run("#3: decode one with filter", func(t *testing.T) {
+		var v1, v2 Item
+		d := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.RowFilterFunc = skipDraft
+		})
+		assert.Nil(t, d.DecodeOne(&v1))
+		assert.Nil(t, d.DecodeOne(&v2))
+		assert.Equal(t, Item{3, "DONE"}, v2)
+	})
+
+	t.Run("#4: filter subtotal rows by row number", func(t *testing.T) {
+		var v []Item
+		var filteredRows []int
+		ret, err := makeDecoder(gofn.MultilineString(
+			`col1,status
+			1,DONE
+			2,DONE
+			TOTAL,
+			abc,DONE
+			TOTAL,`), func(cfg *DecodeConfig) {
+			cfg.StopOnError = false
+			cfg.RowFilterByRowFunc = func(records []string, row int) bool {
+				if records[0] == "TOTAL" {
+					filteredRows = append(filteredRows, row)
+					return false
+				}
+				return true
+			}
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		assert.Equal(t, []int{4, 6}, filteredRows)
+		assert.Equal(t, 6, ret.TotalRow())
+		assert.Equal(t, 2, ret.FilteredRows())
+		// Row numbers of errors are kept as in the input
+		assert.Equal(t, 1, err.(*Errors).TotalRowError())
+		assert.Equal(t, 5, err.(*Errors).Unwrap()[0].(*RowErrors).Row())
+	})
+
+	t.Run("#5: filter with both funcs", func(t *testing.T) {
+		var v []Item
+		ret, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.RowFilterFunc = skipDraft
+			cfg.RowFilterByRowFunc = func(records []string, row int) bool {
+				return row != 6
+			}
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, 6, ret.TotalRow())
+		assert.Equal(t, 3, ret.FilteredRows())
+		assert.Equal(t, []Item{{1, "DONE"}, {3, "DONE"}}, v)
+	})
+}
+
+func Test_Decode_withOnRowDecodedFunc(t *testing.T) {
+	type Item struct {
+		Col1  int `csv:"col1"`
+		Col2  int `csv:"col2"`
+		Total int `csv:"-"`
+	}
+	data := gofn.MultilineString(
+		`col1,col2
+		1,2
+		3,abc
+		5,-6`)
+	errNegative := errors.New("total is negative")
+	computeTotal := func(row int, v any) error {
+		item := v.(*Item) // nolint: forcetypeassert
+		item.Total = item.Col1 + item.Col2
+		if item.Total < 0 {
+			return errNegative
+		}
+		return nil
+	}
+
+	t.Run("#1: modify decoded items", func(t *testing.T) {
+		var v []Item
+		var rows []int
+		_, err := makeDecoder(gofn.MultilineString(
+			`col1,col2
+			1,2
+			3,4`), func(cfg *DecodeConfig) {
+			cfg.OnRowDecodedFunc = func(row int, v any) error {
+				rows = append(rows, row)
+				return computeTotal(row, v)
+			}
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []int{2, 3}, rows)
+		assert.Equal(t, []Item{{Col1: 1, Col2: 2, Total: 3}, {Col1: 3, Col2: 4, Total: 7}}, v)
+	})
+
+	t.Run("#2: not called on rows having errors", func(t *testing.T) {
+		var v []*Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.StopOnError = false
+			cfg.OnRowDecodedFunc = computeTotal
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		assert.ErrorIs(t, err, errNegative)
+		rowErrs := err.(*Errors).Unwrap() // nolint: errorlint
+		assert.Equal(t, 2, len(rowErrs))
+		assert.Equal(t, 3, rowErrs[0].(*RowErrors).Row())            // nolint: errorlint
+		assert.Equal(t, 4, rowErrs[1].(*RowErrors).Row())            // nolint: errorlint
+		assert.Equal(t, 0, rowErrs[1].(*RowErrors).TotalCellError()) // nolint: errorlint
+	})
+
+	t.Run("#3: stop on error", func(t *testing.T) {
+		var v []Item
+		calls := 0
+		_, err := makeDecoder(gofn.MultilineString(
+			`col1,col2
+			-1,0
+			3,4`), func(cfg *DecodeConfig) {
+			cfg.OnRowDecodedFunc = func(row int, v any) error {
+				calls++
+				return computeTotal(row, v)
+			}
+		}).Decode(&v)
+		assert.ErrorIs(t, err, errNegative)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("#4: decode one", func(t *testing.T) {
+		var item Item
+		d := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.OnRowDecodedFunc = computeTotal
+		})
+		assert.Nil(t, d.DecodeOne(&item))
+		assert.Equal(t, Item{Col1: 1, Col2: 2, Total: 3}, item)
+	})
+
+	t.Run("#5: decode into maps", func(t *testing.T) {
+		var v []map[string]string
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.OnRowDecodedFunc = func(row int, v any) error {
+				m := *v.(*map[string]string) // nolint: forcetypeassert
+				m["row"] = strconv.Itoa(row)
+				return nil
+			}
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]string{"col1": "3", "col2": "abc", "row": "3"}, v[1])
+	})
+}
+
+func Test_Decode_withRowValidators(t *testing.T) {
+	type Item struct {
+		Email string `csv:"email"`
+		Phone string `csv:"phone"`
+		Start int    `csv:"start"`
+		End   int    `csv:"end"`
+	}
+	data := gofn.MultilineString(
+		`email,phone,start,end
+		a@x.com,,1,2
+		,,1,2
+		,123,3,2
+		b@x.com,,abc,0`)
+	errContactRequired := errors.New("either email or phone must be set")
+	errEndBeforeStart := errors.New("end must be after start")
+	validators := []ValidatorFunc{
+		TypedRowValidator(func(item Item) error {
+			if item.Email == "" && item.Phone == "" {
+				return errContactRequired
+			}
+			return nil
+		}),
+		TypedRowValidator(func(item Item) error {
+			if item.End < item.Start {
+				return errEndBeforeStart
+			}
+			return nil
+		}),
+	}
+
+	t.Run("#1: success", func(t *testing.T) {
+		var v []*Item
+		_, err := makeDecoder(gofn.MultilineString(
+			`email,phone,start,end
+			a@x.com,,1,2
+			,123,3,4`), func(cfg *DecodeConfig) {
+			cfg.RowValidatorFuncs = validators
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(v))
+	})
+
+	t.Run("#2: validation errors", func(t *testing.T) {
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.StopOnError = false
+			cfg.RowValidatorFuncs = validators
+		}).Decode(&v)
+		assert.ErrorIs(t, err, errContactRequired)
+		assert.ErrorIs(t, err, errEndBeforeStart)
+		rowErrs := err.(*Errors).ErrorsByRow() // nolint: errorlint
+		assert.Equal(t, 3, len(rowErrs))
+		assert.Equal(t, -1, rowErrs[3].CellErrors()[0].Column())
+		assert.ErrorIs(t, rowErrs[3], errContactRequired)
+		assert.ErrorIs(t, rowErrs[4], errEndBeforeStart)
+		// Row validators are not called when the row has cell errors
+		assert.Equal(t, 1, rowErrs[5].TotalError())
+		assert.ErrorIs(t, rowErrs[5], ErrDecodeValueType)
+
+		r, _ := NewCSVRenderer(err.(*Errors)) // nolint: errorlint
+		msg, _, _ := r.RenderAsString()
+		assert.Equal(t, gofn.MultilineString(
+			`Row,Line,CommonError,email,phone,start,end
+			3,-1,either email or phone must be set,,,,
+			4,-1,end must be after start,,,,
+			5,-1,,,,ErrDecodeValueType: int (abc),
+			`), msg)
+	})
+
+	t.Run("#3: stop on error", func(t *testing.T) {
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.RowValidatorFuncs = validators
+		}).Decode(&v)
+		assert.ErrorIs(t, err, errContactRequired)
+		assert.Equal(t, 1, err.(*Errors).TotalError()) // nolint: errorlint
+	})
+}
+
+func Test_Decode_withOnRowErrorFunc(t *testing.T) {
+	type Item struct {
+		Col1 int `csv:"col1"`
+		Col2 int `csv:"col2"`
+	}
+	data := gofn.MultilineString(
+		`col1,col2
+		1,x
+		y,2
+		3,4
+		a,b`)
+
+	t.Run("#1: modify errors of rows", func(t *testing.T) {
+		var v []Item
+		var rowErrs []*RowErrors
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.StopOnError = false
+			cfg.OnRowErrorFunc = func(rowErr *RowErrors) bool {
+				rowErrs = append(rowErrs, rowErr)
+				for _, cellErr := range rowErr.CellErrors() {
+					cellErr.SetLocalizationKey("ERR_INVALID")
+				}
+				return false
+			}
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		assert.Equal(t, 3, len(rowErrs))
+		for i, rowErr := range err.(*Errors).Unwrap() { // nolint: errorlint
+			assert.Same(t, rowErrs[i], rowErr)
+		}
+		assert.Equal(t, "ERR_INVALID", rowErrs[2].CellErrors()[1].LocalizationKey())
+	})
+
+	t.Run("#2: stop decoding", func(t *testing.T) {
+		var v []Item
+		totalErr := 0
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.StopOnError = false
+			cfg.OnRowErrorFunc = func(rowErr *RowErrors) bool {
+				totalErr += rowErr.TotalError()
+				return totalErr >= 2
+			}
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		assert.Equal(t, 2, err.(*Errors).TotalRowError()) // nolint: errorlint
+	})
+
+	t.Run("#3: stop decoding row by row", func(t *testing.T) {
+		var item Item
+		d := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.StopOnError = false
+			cfg.OnRowErrorFunc = func(rowErr *RowErrors) bool {
+				return true
+			}
+		})
+		assert.ErrorIs(t, d.DecodeOne(&item), ErrDecodeValueType)
+		assert.ErrorIs(t, d.DecodeOne(&item), ErrAlreadyFailed)
+	})
+}
+
+type structValidatorItem struct {
+	Name string `csv:"name"`
+	Age  int    `csv:"age"`
+}
+
+type structValidatorMultiErr []error
+
+func (e structValidatorMultiErr) Error() string   { return getErrorMsg(e) }
+func (e structValidatorMultiErr) Unwrap() []error { return e }
+
+var (
+	errStructValidatorName = errors.New("name is required")
+	errStructValidatorAge  = errors.New("age must be positive")
+)
+
+func (item *structValidatorItem) Validate() error {
+	var errs structValidatorMultiErr
+	if item.Name == "" {
+		errs = append(errs, errStructValidatorName)
+	}
+	if item.Age <= 0 {
+		errs = append(errs, errStructValidatorAge)
+	}
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	if len(errs) > 1 {
+		return errs
+	}
+	return nil
+}
+
+func Test_Decode_withStructValidator(t *testing.T) {
+	data := gofn.MultilineString(
+		`name,age
+		tom,20
+		,20
+		,0
+		jerry,abc`)
+
+	t.Run("#1: struct validator is not used by default", func(t *testing.T) {
+		var v []structValidatorItem
+		_, err := makeDecoder(gofn.MultilineString(
+			`name,age
+			tom,20
+			,0`)).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(v))
+	})
+
+	t.Run("#2: validation errors", func(t *testing.T) {
+		var v []*structValidatorItem
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.StopOnError = false
+			cfg.UseStructValidator = true
+		}).Decode(&v)
+		rowErrs := err.(*Errors).ErrorsByRow() // nolint: errorlint
+		assert.Equal(t, 3, len(rowErrs))
+		assert.Equal(t, 1, rowErrs[3].TotalError())
+		assert.ErrorIs(t, rowErrs[3], errStructValidatorName)
+		// Multiple errors are split
+		assert.Equal(t, 2, rowErrs[4].TotalCellError())
+		assert.ErrorIs(t, rowErrs[4].CellErrors()[0], errStructValidatorName)
+		assert.ErrorIs(t, rowErrs[4].CellErrors()[1], errStructValidatorAge)
+		assert.Equal(t, -1, rowErrs[4].CellErrors()[1].Column())
+		// Not called when the row has cell errors
+		assert.Equal(t, 1, rowErrs[5].TotalError())
+		assert.ErrorIs(t, rowErrs[5], ErrDecodeValueType)
+	})
+
+	t.Run("#3: stop on error", func(t *testing.T) {
+		var item structValidatorItem
+		d := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.UseStructValidator = true
+		})
+		assert.Nil(t, d.DecodeOne(&item))
+		assert.ErrorIs(t, d.DecodeOne(&item), errStructValidatorName)
+		assert.ErrorIs(t, d.DecodeOne(&item), ErrAlreadyFailed)
+	})
+
+	t.Run("#4: cell errors referencing specific columns", func(t *testing.T) {
+		type Item struct {
+			Type   string `csv:"type"`
+			Amount int    `csv:"amount"`
+		}
+		var v []Item
+		_, err := makeDecoder(gofn.MultilineString(
+			`type,amount
+			credit,10
+			credit,-10
+			debit,-10`), func(cfg *DecodeConfig) {
+			cfg.StopOnError = false
+			cfg.RowValidatorFuncs = []ValidatorFunc{
+				TypedRowValidator(func(item Item) error {
+					if item.Type == "credit" && item.Amount <= 0 {
+						return NewCellError(ErrValidationPositive, 1, "amount")
+					}
+					return nil
+				}),
+			}
+		}).Decode(&v)
+		rowErrs := err.(*Errors).ErrorsByRow() // nolint: errorlint
+		assert.Equal(t, 1, len(rowErrs))
+		cellErr := rowErrs[3].CellErrors()[0]
+		assert.ErrorIs(t, cellErr, ErrValidationPositive)
+		assert.Equal(t, 1, cellErr.Column())
+		assert.Equal(t, "amount", cellErr.Header())
+	})
+
+	t.Run("#5: items not implementing StructValidator", func(t *testing.T) {
+		type Item struct {
+			Name string `csv:"name"`
+			Age  int    `csv:"age"`
+		}
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.StopOnError = false
+			cfg.UseStructValidator = true
+		}).Decode(&v)
+		rowErrs := err.(*Errors).ErrorsByRow() // nolint: errorlint
+		assert.Equal(t, 1, len(rowErrs))
+		assert.ErrorIs(t, rowErrs[5], ErrDecodeValueType)
+	})
+}
+
+type postDecodeHookItem struct {
+	FirstName string `csv:"first_name"`
+	LastName  string `csv:"last_name"`
+	Age       int    `csv:"age"`
+	FullName  string `csv:"-"`
+}
+
+var errPostDecodeHook = errors.New("last name is required")
+
+func (item *postDecodeHookItem) PostDecode() error {
+	if item.LastName == "" {
+		return errPostDecodeHook
+	}
+	item.FullName = item.FirstName + " " + item.LastName
+	return nil
+}
+
+func Test_Decode_withPostDecodeHook(t *testing.T) {
+	t.Run("#1: hook succeeds", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`first_name,last_name,age
+			tom,smith,20`)
+
+		var v []*postDecodeHookItem
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.RowValidatorFuncs = []ValidatorFunc{
+				TypedRowValidator(func(item postDecodeHookItem) error {
+					// Row validators see the derived fields
+					assert.Equal(t, "tom smith", item.FullName)
+					return nil
+				}),
+			}
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []*postDecodeHookItem{{FirstName: "tom", LastName: "smith", Age: 20, FullName: "tom smith"}}, v)
+	})
+
+	t.Run("#2: hook returns error", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`first_name,last_name,age
+			tom,smith,20
+			jerry,,20
+			anna,,abc`)
+
+		var v []postDecodeHookItem
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.StopOnError = false
+		}).Decode(&v)
+		rowErrs := err.(*Errors).ErrorsByRow() // nolint: errorlint
+		assert.Equal(t, 2, len(rowErrs))
+		assert.ErrorIs(t, rowErrs[3], errPostDecodeHook)
+		assert.Equal(t, -1, rowErrs[3].CellErrors()[0].Column())
+		// Not called when the row has cell errors
+		assert.Equal(t, 1, rowErrs[4].TotalError())
+		assert.ErrorIs(t, rowErrs[4], ErrDecodeValueType)
+
+		var item postDecodeHookItem
+		d := makeDecoder(data)
+		assert.Nil(t, d.DecodeOne(&item))
+		assert.Equal(t, "tom smith", item.FullName)
+		assert.ErrorIs(t, d.DecodeOne(&item), errPostDecodeHook)
+		assert.ErrorIs(t, d.DecodeOne(&item), ErrAlreadyFailed)
+	})
+
+	t.Run("#3: items not implementing PostDecodeHook", func(t *testing.T) {
+		type Item struct {
+			FirstName string `csv:"first_name"`
+			LastName  string `csv:"last_name"`
+		}
+		var v []Item
+		_, err := makeDecoder("first_name,last_name\ntom,").Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{{FirstName: "tom"}}, v)
+	})
+}
+
+type rowValidatorItem struct {
+	Type   string `csv:"type"`
+	Amount int    `csv:"amount"`
+}
+
+var errRowValidatorType = errors.New("unknown type")
+
+func (item *rowValidatorItem) ValidateRow() error {
+	switch item.Type {
+	case "credit":
+		if item.Amount <= 0 {
+			return NewCellError(ErrValidationPositive, 1, "amount")
+		}
+	case "debit":
+	default:
+		return errRowValidatorType
+	}
+	return nil
+}
+
+func Test_Decode_withRowValidator(t *testing.T) {
+	data := gofn.MultilineString(
+		`type,amount
+		credit,10
+		credit,-10
+		debit,-10
+		other,1
+		credit,abc`)
+
+	t.Run("#1: errors of rows", func(t *testing.T) {
+		var v []rowValidatorItem
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.StopOnError = false
+		}).Decode(&v)
+		rowErrs := err.(*Errors).ErrorsByRow() // nolint: errorlint
+		assert.Equal(t, 3, len(rowErrs))
+		// Cell errors are kept as they are
+		cellErr := rowErrs[3].CellErrors()[0]
+		assert.ErrorIs(t, cellErr, ErrValidationPositive)
+		assert.Equal(t, 1, cellErr.Column())
+		assert.Equal(t, "amount", cellErr.Header())
+		// Other errors are not related to any column
+		assert.ErrorIs(t, rowErrs[5], errRowValidatorType)
+		assert.Equal(t, -1, rowErrs[5].CellErrors()[0].Column())
+		// Not called when the row has cell errors
+		assert.Equal(t, 1, rowErrs[6].TotalError())
+		assert.ErrorIs(t, rowErrs[6], ErrDecodeValueType)
+	})
+
+	t.Run("#2: stop on error", func(t *testing.T) {
+		var items []*rowValidatorItem
+		_, err := makeDecoder(data).Decode(&items)
+		assert.ErrorIs(t, err, ErrValidationPositive)
+		assert.Equal(t, 1, err.(*Errors).TotalRowError()) // nolint: errorlint
+	})
+
+	t.Run("#3: items not implementing RowValidator", func(t *testing.T) {
+		type Item struct {
+			Type   string `csv:"type"`
+			Amount int    `csv:"amount"`
+		}
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.StopOnError = false
+		}).Decode(&v)
+		rowErrs := err.(*Errors).ErrorsByRow() // nolint: errorlint
+		assert.Equal(t, 1, len(rowErrs))
+		assert.ErrorIs(t, rowErrs[6], ErrDecodeValueType)
+	})
+}
+
+func Test_NewDecoderFromReader(t *testing.T) {
+	type Item struct {
+		Name string `csv:"tên"`
+		Qty  int    `csv:"qty"`
+	}
+
+	t.Run("#1: utf-8 input", func(t *testing.T) {
+		var v []Item
+		_, err := NewDecoderFromReader(strings.NewReader("tên,qty\nabc,1")).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{{Name: "abc", Qty: 1}}, v)
+	})
+
+	t.Run("#2: utf-16 input with row lines", func(t *testing.T) {
+		data := encodeUTF16("\uFEFFtên,qty\n\"a\nb\",1\nđ,x", false)
+		var v []Item
+		_, err := NewDecoderFromReader(bytes.NewReader(data), func(cfg *DecodeConfig) {
+			cfg.SourceCharset = "utf-16le"
+			cfg.DetectRowLine = true
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		rowErr := err.(*Errors).Unwrap()[0].(*RowErrors) // nolint: errorlint
+		assert.Equal(t, 3, rowErr.Row())
+		assert.Equal(t, 4, rowErr.Line())
+	})
+
+	t.Run("#3: transform reader", func(t *testing.T) {
+		var v []Item
+		_, err := NewDecoderFromReader(strings.NewReader("t\xean,QTY\nabc,1"), func(cfg *DecodeConfig) {
+			cfg.TransformReader = func(r io.Reader) io.Reader {
+				data, _ := io.ReadAll(r)
+				return bytes.NewReader(bytes.ReplaceAll(data, []byte("QTY"), []byte("qty")))
+			}
+			cfg.SourceCharset = "windows-1252"
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{{Name: "abc", Qty: 1}}, v)
+	})
+
+	t.Run("#4: invalid config", func(t *testing.T) {
+		var v []Item
+		_, err := NewDecoderFromReader(strings.NewReader("tên,qty"), func(cfg *DecodeConfig) {
+			cfg.SourceCharset = "ebcdic"
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrConfigOptionInvalid)
+
+		_, err = makeDecoder("tên,qty", func(cfg *DecodeConfig) {
+			cfg.SourceCharset = "utf-16le"
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrConfigOptionInvalid)
+	})
+
+	t.Run("#5: auto detect delimiter", func(t *testing.T) {
+		var v []Item
+		_, err := NewDecoderFromReader(strings.NewReader("tên;qty\n\"a;b\";1\nc,d;2"), func(cfg *DecodeConfig) {
+			cfg.AutoDetectDelimiter = true
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{{Name: "a;b", Qty: 1}, {Name: "c,d", Qty: 2}}, v)
+
+		_, err = NewDecoderFromReader(strings.NewReader("tên;qty,x\na;1,2"), func(cfg *DecodeConfig) {
+			cfg.AutoDetectDelimiter = true
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrDelimiterAmbiguous)
+
+		_, err = makeDecoder("tên,qty", func(cfg *DecodeConfig) {
+			cfg.AutoDetectDelimiter = true
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrConfigOptionInvalid)
+	})
+
+	t.Run("#6: with csv options", func(t *testing.T) {
+		data := "tên|qty\na \"b\"|1\n# comment\nc|2"
+		var v []Item
+		_, err := NewDecoderFromReaderWithCSVOptions(strings.NewReader(data), func(r *csv.Reader) {
+			r.LazyQuotes = true
+			r.Comma = ';'
+		}, func(cfg *DecodeConfig) {
+			cfg.Comma = '|'
+			cfg.Comment = '#'
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{{Name: "a \"b\"", Qty: 1}, {Name: "c", Qty: 2}}, v)
+
+		_, err = NewDecoderFromReaderWithCSVOptions(strings.NewReader(data), nil, func(cfg *DecodeConfig) {
+			cfg.Comma = '|'
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeQuoteInvalid)
+	})
+}
+
+func Test_Decode_withProgressFunc(t *testing.T) {
+	type Item struct {
+		Col1 int `csv:"col1"`
+	}
+	buildData := func(numRows int) string {
+		var sb strings.Builder
+		sb.WriteString("col1\n")
+		for i := 0; i < numRows; i++ {
+			sb.WriteString(strconv.Itoa(i) + "\n")
+		}
+		return sb.String()
+	}
+	type progress struct {
+		processed, total int
+	}
+
+	t.Run("#1: report every interval", func(t *testing.T) {
+		var reports []progress
+		var v []Item
+		_, err := makeDecoder(buildData(25), func(cfg *DecodeConfig) {
+			cfg.ProgressInterval = 10
+			cfg.ProgressFunc = func(processedRows, totalRows int) {
+				reports = append(reports, progress{processedRows, totalRows})
+			}
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []progress{{10, 25}, {20, 25}, {25, 25}}, reports)
+
+		// The final report is not duplicated
+		reports = nil
+		_, err = makeDecoder(buildData(20), func(cfg *DecodeConfig) {
+			cfg.ProgressInterval = 10
+			cfg.ProgressFunc = func(processedRows, totalRows int) {
+				reports = append(reports, progress{processedRows, totalRows})
+			}
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []progress{{10, 20}, {20, 20}}, reports)
+	})
+
+	t.Run("#2: total is unknown before the input is read completely", func(t *testing.T) {
+		var reports []progress
+		var v []Item
+		_, err := makeDecoder(buildData(decodeChunkSize+500), func(cfg *DecodeConfig) {
+			cfg.ProgressInterval = 5000
+			cfg.WorkerCount = 4
+			cfg.ProgressFunc = func(processedRows, totalRows int) {
+				reports = append(reports, progress{processedRows, totalRows})
+			}
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []progress{{decodeChunkSize, -1}, {decodeChunkSize + 500, decodeChunkSize + 500}}, reports)
+	})
+
+	t.Run("#3: decode row by row", func(t *testing.T) {
+		var reports []progress
+		d := makeDecoder(buildData(3), func(cfg *DecodeConfig) {
+			cfg.ProgressInterval = 2
+			cfg.ProgressFunc = func(processedRows, totalRows int) {
+				reports = append(reports, progress{processedRows, totalRows})
+			}
+		})
+		var item Item
+		for d.DecodeOne(&item) == nil {
+		}
+		assert.Equal(t, []progress{{2, -1}, {3, 3}}, reports)
+	})
+
+	t.Run("#4: invalid interval", func(t *testing.T) {
+		var v []Item
+		_, err := makeDecoder(buildData(1), func(cfg *DecodeConfig) {
+			cfg.ProgressInterval = 0
+			cfg.ProgressFunc = func(processedRows, totalRows int) {}
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrConfigOptionInvalid)
+	})
+
+	t.Run("#5: callback for every row", func(t *testing.T) {
+		var reports []progress
+		var v []Item
+		_, err := makeDecoder(buildData(3), func(cfg *DecodeConfig) {
+			cfg.OnRowDecoded = func(row int, total int) {
+				reports = append(reports, progress{row, total})
+			}
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []progress{{1, 3}, {2, 3}, {3, 3}}, reports)
+	})
+
+	t.Run("#6: callback for rows having errors", func(t *testing.T) {
+		var reports []progress
+		var v []Item
+		_, err := makeDecoder("col1\n1\nabc\n3\nxyz\n", func(cfg *DecodeConfig) {
+			cfg.StopOnError = false
+			cfg.OnRowDecoded = func(row int, total int) {
+				reports = append(reports, progress{row, total})
+			}
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		assert.Equal(t, []progress{{1, 4}, {2, 4}, {3, 4}, {4, 4}}, reports)
+
+		// The row stopping the decoding is also reported
+		reports = nil
+		_, err = makeDecoder("col1\n1\nabc\n3\n", func(cfg *DecodeConfig) {
+			cfg.OnRowDecoded = func(row int, total int) {
+				reports = append(reports, progress{row, total})
+			}
+		}).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		assert.Equal(t, []progress{{1, 3}, {2, 3}}, reports)
+	})
+
+	t.Run("#7: callback with parallel decoding", func(t *testing.T) {
+		count := 0
+		var v []Item
+		_, err := makeDecoder(buildData(decodeChunkSize+500), func(cfg *DecodeConfig) {
+			cfg.WorkerCount = 4
+			cfg.OnRowDecoded = func(row int, total int) {
+				count++
+				assert.Equal(t, count, row)
+			}
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, decodeChunkSize+500, count)
+	})
+}
+
+func Test_Decode_largeInput(t *testing.T) {
+	type Item struct {
+		Col1 int     `csv:"col1"`
+		Col2 float32 `csv:"col2"`
+	}
+	buildData := func(numRows int, invalidRows ...int) string {
+		var sb strings.Builder
+		sb.WriteString("col1,col2\n")
+		for i := 0; i < numRows; i++ {
+			if gofn.Contain(invalidRows, i) {
+				sb.WriteString("1,2,3\n")
+				continue
+			}
+			sb.WriteString(strconv.Itoa(i) + ",1.5\n")
+		}
+		return sb.String()
+	}
+
+	t.Run("#1: decode data across multiple chunks", func(t *testing.T) {
+		numRows := decodeChunkSize*2 + 100
+		var v []Item
+		ret, err := makeDecoder(buildData(numRows)).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, numRows+1, ret.TotalRow())
+		assert.Equal(t, numRows, len(v))
+		assert.Equal(t, Item{Col1: numRows - 1, Col2: 1.5}, v[numRows-1])
+	})
+
+	t.Run("#2: row field count error in a later chunk", func(t *testing.T) {
+		numRows := decodeChunkSize + 100
+		var v []Item
+		ret, err := makeDecoder(buildData(numRows, 5, decodeChunkSize+10), func(cfg *DecodeConfig) {
+			cfg.TreatIncorrectStructureAsError = false
+			cfg.StopOnError = false
+		}).Decode(&v)
+		assert.Equal(t, numRows+1, ret.TotalRow())
+		assert.Equal(t, 2, err.(*Errors).TotalRowError())
+		rowErr := err.(*Errors).Unwrap()[1].(*RowErrors)
+		assert.Equal(t, decodeChunkSize+12, rowErr.Row())
+		assert.ErrorIs(t, rowErr, ErrDecodeRowFieldCount)
+	})
+
+	t.Run("#3: stop on error without reading the remaining chunks", func(t *testing.T) {
+		numRows := decodeChunkSize*3 + 100
+		var v []Item
+		data := strings.Replace(buildData(numRows), "\n5,", "\nabc,", 1)
+		ret, err := makeDecoder(data).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		assert.Equal(t, decodeChunkSize+1, ret.TotalRow())
 	})
 }
 
@@ -1225,6 +4769,426 @@ func Test_DecodeOne(t *testing.T) {
 	})
 }
 
+func Test_DecodeContext(t *testing.T) {
+	type Item struct {
+		Col1 int     `csv:"col1"`
+		Col2 float32 `csv:"col2"`
+	}
+	data := gofn.MultilineString(
+		`col1,col2
+			1,2.123
+			2,abc
+			3,300
+			4,400`)
+
+	t.Run("#1: context canceled before decoding", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		var v []Item
+		d := makeDecoder(data)
+		_, err := d.DecodeContext(ctx, &v)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Nil(t, v)
+		_, err = d.DecodeContext(context.Background(), &v)
+		assert.ErrorIs(t, err, ErrAlreadyFailed)
+	})
+
+	t.Run("#2: context canceled mid-decoding keeps partial errors", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var v []Item
+		d := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.StopOnError = false
+			cfg.ConfigureColumn("col1", func(cfg *DecodeColumnConfig) {
+				cfg.ValidatorFuncs = []ValidatorFunc{func(v any) error {
+					if v.(int) == 3 {
+						cancel()
+					}
+					return nil
+				}}
+			})
+		})
+		_, err := d.DecodeContext(ctx, &v)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		assert.Equal(t, 1, err.(*Errors).TotalRowError())
+
+		_, err = d.DecodeContext(ctx, &v)
+		assert.ErrorIs(t, err, ErrAlreadyFailed)
+		_, err = d.Finish()
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+	})
+
+	t.Run("#3: decode one with deadline exceeded", func(t *testing.T) {
+		ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+		defer cancel()
+		var v1, v2 Item
+		d := makeDecoder(data)
+		err := d.DecodeOneContext(context.Background(), &v1)
+		assert.Nil(t, err)
+		assert.Equal(t, Item{Col1: 1, Col2: 2.123}, v1)
+		err = d.DecodeOneContext(ctx, &v2)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		err = d.DecodeOne(&v2)
+		assert.ErrorIs(t, err, ErrAlreadyFailed)
+		_, err = d.Finish()
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func Test_DecodeStream(t *testing.T) {
+	type Item struct {
+		Col1 int     `csv:"col1"`
+		Col2 float32 `csv:"col2"`
+	}
+
+	t.Run("#1: decode until finishes", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2
+			1,2.123
+			100,200`)
+
+		d := makeDecoder(data)
+		itemCh, errCh := DecodeStream[Item](context.Background(), d)
+		var v []Item
+		for item := range itemCh {
+			v = append(v, item)
+		}
+		assert.Nil(t, <-errCh)
+		assert.Equal(t, []Item{{Col1: 1, Col2: 2.123}, {Col1: 100, Col2: 200}}, v)
+		ret, err := d.Finish()
+		assert.Nil(t, err)
+		assert.Equal(t, 3, ret.TotalRow())
+	})
+
+	t.Run("#2: context canceled mid-stream", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2
+			1,2.123
+			2,200
+			3,300
+			4,400`)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		d := makeDecoder(data)
+		itemCh, errCh := DecodeStream[Item](ctx, d)
+		item := <-itemCh
+		assert.Equal(t, Item{Col1: 1, Col2: 2.123}, item)
+		cancel()
+		for range itemCh {
+		}
+		err := <-errCh
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.IsType(t, &Errors{}, err)
+		err = d.DecodeOne(&item)
+		assert.ErrorIs(t, err, ErrAlreadyFailed)
+		_, err = d.Finish()
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("#3: error propagation with StopOnError = false", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2
+			1,2.123
+			abc,200
+			3,xyz
+			4,400`)
+
+		d := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.StopOnError = false
+		})
+		itemCh, errCh := DecodeStream[Item](context.Background(), d)
+		var v []Item
+		for item := range itemCh {
+			v = append(v, item)
+		}
+		err := <-errCh
+		assert.Equal(t, []Item{{Col1: 1, Col2: 2.123}, {Col1: 4, Col2: 400}}, v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		assert.Equal(t, 2, err.(*Errors).TotalRowError())
+	})
+
+	t.Run("#4: stop on the first error", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2
+			1,2.123
+			abc,200
+			3,300`)
+
+		itemCh, errCh := DecodeStream[Item](context.Background(), makeDecoder(data))
+		var v []Item
+		for item := range itemCh {
+			v = append(v, item)
+		}
+		err := <-errCh
+		assert.Equal(t, []Item{{Col1: 1, Col2: 2.123}}, v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		assert.Equal(t, 1, err.(*Errors).TotalRowError())
+	})
+
+	t.Run("#5: invalid item type", func(t *testing.T) {
+		itemCh, errCh := DecodeStream[int](context.Background(), makeDecoder("col1,col2"))
+		for range itemCh {
+		}
+		assert.ErrorIs(t, <-errCh, ErrTypeInvalid)
+	})
+}
+
+func Test_DecodeEach(t *testing.T) {
+	type Item struct {
+		Col1 int     `csv:"col1"`
+		Col2 float32 `csv:"col2"`
+		Col3 string  `csv:"col3,omitempty"`
+	}
+
+	t.Run("#1: decode each until finishes", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2,col3
+			1,2.123,abc
+			100,200,`)
+
+		var v []Item
+		ret, err := DecodeEach(makeDecoder(data), func(item Item) error {
+			v = append(v, item)
+			return nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 3, ret.TotalRow())
+		assert.Equal(t, []Item{{Col1: 1, Col2: 2.123, Col3: "abc"}, {Col1: 100, Col2: 200}}, v)
+	})
+
+	t.Run("#2: callback returns error", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2,col3
+			1,2.123,abc
+			2,200,
+			3,300,`)
+
+		errCallback := errors.New("callback error")
+		var v []Item
+		_, err := DecodeEach(makeDecoder(data), func(item Item) error {
+			if item.Col1 == 2 {
+				return errCallback
+			}
+			v = append(v, item)
+			return nil
+		})
+		assert.ErrorIs(t, err, errCallback)
+		assert.Equal(t, 3, err.(*Errors).Unwrap()[0].(*RowErrors).Row())
+		assert.Equal(t, []Item{{Col1: 1, Col2: 2.123, Col3: "abc"}}, v)
+	})
+
+	t.Run("#3: validators and StopOnError = false", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1,col2,col3
+			1,2.123,abc
+			20,200,
+			3,300,`)
+
+		var v []Item
+		ret, err := DecodeEach(makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.StopOnError = false
+			cfg.ConfigureColumn("col1", func(cfg *DecodeColumnConfig) {
+				cfg.ValidatorFuncs = []ValidatorFunc{ValidatorLT(10)}
+			})
+		}), func(item Item) error {
+			v = append(v, item)
+			return nil
+		})
+		assert.Equal(t, 4, ret.TotalRow())
+		assert.ErrorIs(t, err, ErrValidationLT)
+		assert.Equal(t, 1, err.(*Errors).TotalRowError())
+		assert.Equal(t, []Item{{Col1: 1, Col2: 2.123, Col3: "abc"}, {Col1: 3, Col2: 300}}, v)
+	})
+
+	t.Run("#4: invalid item type", func(t *testing.T) {
+		_, err := DecodeEach(makeDecoder("col1"), func(item int) error { return nil })
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+	})
+}
+
+func Test_DecodeEachBatch(t *testing.T) {
+	type Item struct {
+		Col1 int `csv:"col1"`
+	}
+	data := gofn.MultilineString(
+		`col1
+		1
+		2
+		3
+		4
+		5`)
+
+	t.Run("#1: uneven final batch", func(t *testing.T) {
+		var batches [][]Item
+		ret, err := DecodeEachBatch(makeDecoder(data), 2, func(batch []Item) error {
+			batches = append(batches, batch)
+			return nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 6, ret.TotalRow())
+		assert.Equal(t, [][]Item{{{1}, {2}}, {{3}, {4}}, {{5}}}, batches)
+	})
+
+	t.Run("#2: callback returns error", func(t *testing.T) {
+		errCallback := errors.New("callback error")
+		var batches [][]Item
+		_, err := DecodeEachBatch(makeDecoder(data), 2, func(batch []Item) error {
+			if len(batches) == 1 {
+				return errCallback
+			}
+			batches = append(batches, batch)
+			return nil
+		})
+		assert.ErrorIs(t, err, errCallback)
+		assert.Equal(t, [][]Item{{{1}, {2}}}, batches)
+	})
+
+	t.Run("#3: stop on error", func(t *testing.T) {
+		data := gofn.MultilineString(
+			`col1
+			1
+			2
+			3
+			abc
+			5`)
+
+		var batches [][]Item
+		_, err := DecodeEachBatch(makeDecoder(data), 2, func(batch []Item) error {
+			batches = append(batches, batch)
+			return nil
+		})
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		// Items decoded before the error row are passed
+		assert.Equal(t, [][]Item{{{1}, {2}}, {{3}}}, batches)
+
+		batches = nil
+		_, err = DecodeEachBatch(makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.StopOnError = false
+		}), 2, func(batch []Item) error {
+			batches = append(batches, batch)
+			return nil
+		})
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		assert.Equal(t, 1, err.(*Errors).TotalRowError()) // nolint: errorlint
+		// Error rows are not counted in batches
+		assert.Equal(t, [][]Item{{{1}, {2}}, {{3}, {5}}}, batches)
+	})
+
+	t.Run("#4: invalid batch size", func(t *testing.T) {
+		_, err := DecodeEachBatch(makeDecoder(data), 0, func(batch []Item) error { return nil })
+		assert.ErrorIs(t, err, ErrConfigOptionInvalid)
+	})
+}
+
+func Test_Decoder_Reset(t *testing.T) {
+	type Item struct {
+		Col1 int     `csv:"col1"`
+		Col2 float32 `csv:"col2"`
+		Col3 string  `csv:"col3,optional"`
+	}
+	makeReader := func(data string) Reader {
+		return csv.NewReader(strings.NewReader(data))
+	}
+
+	t.Run("#1: reuse decoder with the same header", func(t *testing.T) {
+		d := makeDecoder(gofn.MultilineString(
+			`col1,col2
+			1,2.123
+			100,200`))
+		var v1 []Item
+		_, err := d.Decode(&v1)
+		assert.Nil(t, err)
+		colsMeta := d.colsMeta
+
+		d.Reset(makeReader(gofn.MultilineString(
+			`col1,col2
+			3,4.5`)))
+		var v2 []Item
+		ret, err := d.Decode(&v2)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, ret.TotalRow())
+		assert.Equal(t, []string{"col3"}, ret.MissingOptionalColumns())
+		assert.Equal(t, []Item{{Col1: 3, Col2: 4.5}}, v2)
+		assert.Equal(t, colsMeta, d.colsMeta)
+	})
+
+	t.Run("#2: errors of previous data are cleared", func(t *testing.T) {
+		d := makeDecoder(gofn.MultilineString(
+			`col1,col2
+			abc,2.123`))
+		var v []Item
+		_, err := d.Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+
+		d.Reset(makeReader(gofn.MultilineString(
+			`col1,col2
+			3,4.5`)))
+		var item Item
+		assert.Nil(t, d.DecodeOne(&item))
+		assert.Equal(t, Item{Col1: 3, Col2: 4.5}, item)
+		assert.ErrorIs(t, d.DecodeOne(&item), ErrFinished)
+		_, err = d.Finish()
+		assert.Nil(t, err)
+	})
+
+	t.Run("#3: new header has different order", func(t *testing.T) {
+		d := makeDecoder(gofn.MultilineString(
+			`col1,col2
+			1,2.123`), func(cfg *DecodeConfig) {
+			cfg.RequireColumnOrder = false
+		})
+		var v []Item
+		_, err := d.Decode(&v)
+		assert.Nil(t, err)
+
+		d.Reset(makeReader(gofn.MultilineString(
+			`col2,col1
+			4.5,3`)))
+		_, err = d.Decode(&v)
+		assert.ErrorIs(t, err, ErrHeaderColumnOrderInvalid)
+	})
+
+	t.Run("#4: new header has unrecognized or missing columns", func(t *testing.T) {
+		d := makeDecoder(gofn.MultilineString(
+			`col1,col2
+			1,2.123`))
+		var v []Item
+		_, err := d.Decode(&v)
+		assert.Nil(t, err)
+
+		d.Reset(makeReader(gofn.MultilineString(
+			`col1,col2,col3
+			3,4.5,abc`)))
+		_, err = d.Decode(&v)
+		assert.ErrorIs(t, err, ErrHeaderColumnUnrecognized)
+
+		d.Reset(makeReader(gofn.MultilineString(
+			`col1
+			3`)))
+		_, err = d.Decode(&v)
+		assert.ErrorIs(t, err, ErrHeaderColumnRequired)
+	})
+
+	t.Run("#5: reset after preparation failure", func(t *testing.T) {
+		d := makeDecoder(gofn.MultilineString(
+			`col1,colX
+			1,2.123`))
+		var v []Item
+		_, err := d.Decode(&v)
+		assert.ErrorIs(t, err, ErrHeaderColumnUnrecognized)
+
+		d.Reset(makeReader(gofn.MultilineString(
+			`col1,col2,col3
+			3,4.5,abc`)))
+		ret, err := d.Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(ret.MissingOptionalColumns()))
+		assert.Equal(t, []Item{{Col1: 3, Col2: 4.5, Col3: "abc"}}, v)
+	})
+}
+
 func Test_parseColumnDetailsFromStructType(t *testing.T) {
 	type Item struct {
 		Col0 InlineColumn[int64]  `csv:"dynA,inline"`
@@ -1274,3 +5238,165 @@ func Test_parseColumnDetailsFromStructType(t *testing.T) {
 		assert.ErrorIs(t, err, ErrHeaderDynamicNotAllowLocalizedHeader)
 	})
 }
+
+func Test_Decoder_Validate(t *testing.T) {
+	type Item struct {
+		Col1 int    `csv:"col1"`
+		Col2 string `csv:"col2"`
+	}
+	data := gofn.MultilineString(
+		`col1,col2
+		1,a
+		abc,b
+		-1,c
+		2`)
+	options := func(cfg *DecodeConfig) {
+		cfg.StopOnError = false
+		cfg.TreatIncorrectStructureAsError = false
+		cfg.ConfigureColumn("col1", func(cfg *DecodeColumnConfig) {
+			cfg.ValidatorFuncs = []ValidatorFunc{ValidatorPositive[int]()}
+		})
+	}
+
+	t.Run("#1: same errors as decoding", func(t *testing.T) {
+		var v1, v2 []Item
+		_, decodeErr := makeDecoder(data, options).Decode(&v1)
+		ret, err := makeDecoder(data, options).Validate(&v2)
+		assert.Equal(t, decodeErr, err)
+		assert.Equal(t, 3, err.(*Errors).TotalRowError()) // nolint: errorlint
+		assert.Equal(t, 5, ret.TotalRow())
+		assert.Nil(t, v2)
+	})
+
+	t.Run("#2: success", func(t *testing.T) {
+		v := []Item{{Col1: 100}}
+		d := makeDecoder("col1,col2\n1,a\n2,b", options)
+		ret, err := d.Validate(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, 3, ret.TotalRow())
+		assert.Equal(t, []Item{{Col1: 100}}, v)
+		_, err = d.Validate(&v)
+		assert.ErrorIs(t, err, ErrFinished)
+	})
+
+	t.Run("#3: header errors", func(t *testing.T) {
+		var v []Item
+		_, err := makeDecoder("col1,col3\n1,a", options).Validate(&v)
+		assert.ErrorIs(t, err, ErrHeaderColumnUnrecognized)
+	})
+}
+
+func Test_Decoder_ReadHeader(t *testing.T) {
+	type Item struct {
+		Col1 int    `csv:"col1"`
+		Col2 string `csv:"col2"`
+	}
+	data := gofn.MultilineString(
+		`# exported data
+		Col1,Col2
+		1,a
+		2,b`)
+	options := func(cfg *DecodeConfig) {
+		cfg.SkipInitialRows = 1
+		cfg.HeaderNormalizeFunc = strings.ToLower
+	}
+
+	t.Run("#1: read header then decode", func(t *testing.T) {
+		d := makeDecoder(data, options)
+		header, err := d.ReadHeader()
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"col1", "col2"}, header)
+		// The cached header is returned
+		header[0] = "x"
+		header, err = d.ReadHeader()
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"col1", "col2"}, header)
+
+		var v []Item
+		ret, err := d.Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, 4, ret.TotalRow())
+		assert.Equal(t, 1, ret.SkippedRows())
+		assert.Equal(t, []string{"Col1", "Col2"}, ret.ParsedHeader())
+		assert.Equal(t, []Item{{Col1: 1, Col2: "a"}, {Col1: 2, Col2: "b"}}, v)
+	})
+
+	t.Run("#2: read header then decode one by one", func(t *testing.T) {
+		d := makeDecoder(data, options)
+		_, err := d.ReadHeader()
+		assert.Nil(t, err)
+
+		var item Item
+		assert.Nil(t, d.DecodeOne(&item))
+		assert.Equal(t, Item{Col1: 1, Col2: "a"}, item)
+		_, err = d.ReadHeader()
+		assert.ErrorIs(t, err, ErrUnexpected)
+	})
+
+	t.Run("#3: invalid header", func(t *testing.T) {
+		d := makeDecoder("col1,col1\n1,2")
+		_, err := d.ReadHeader()
+		assert.ErrorIs(t, err, ErrHeaderColumnDuplicated)
+		var v []Item
+		_, err = d.Decode(&v)
+		assert.ErrorIs(t, err, ErrAlreadyFailed)
+	})
+
+	t.Run("#4: no header mode", func(t *testing.T) {
+		d := makeDecoder("1,a", func(cfg *DecodeConfig) {
+			cfg.NoHeaderMode = true
+		})
+		header, err := d.ReadHeader()
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(header))
+		var v []Item
+		_, err = d.Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{{Col1: 1, Col2: "a"}}, v)
+	})
+}
+
+func Test_Decode_appendToSlice(t *testing.T) {
+	type Item struct {
+		Col1 int `csv:"col1"`
+	}
+	appendToSlice := func(cfg *DecodeConfig) {
+		cfg.AppendToSlice = true
+	}
+
+	t.Run("#1: replace by default", func(t *testing.T) {
+		v := []Item{{Col1: 100}}
+		_, err := makeDecoder("col1\n1\n2").Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{{Col1: 1}, {Col1: 2}}, v)
+	})
+
+	t.Run("#2: append items of multiple inputs", func(t *testing.T) {
+		v := make([]Item, 1, 10)
+		v[0] = Item{Col1: 100}
+		_, err := makeDecoder("col1\n1\n2", appendToSlice).Decode(&v)
+		assert.Nil(t, err)
+		_, err = makeDecoder("col1\n3", appendToSlice).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{{Col1: 100}, {Col1: 1}, {Col1: 2}, {Col1: 3}}, v)
+		assert.Equal(t, 10, cap(v))
+	})
+
+	t.Run("#3: errors have rows of the input", func(t *testing.T) {
+		v := []Item{{Col1: 100}, {Col1: 200}}
+		_, err := makeDecoder("col1\n1\nabc", appendToSlice).Decode(&v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+		assert.Equal(t, 3, err.(*Errors).Unwrap()[0].(*RowErrors).Row()) // nolint: errorlint
+		assert.Equal(t, []Item{{Col1: 100}, {Col1: 200}}, v)
+	})
+
+	t.Run("#4: pointer items", func(t *testing.T) {
+		v := []*Item{{Col1: 100}}
+		_, err := makeDecoder("col1\n1", func(cfg *DecodeConfig) {
+			cfg.AppendToSlice = true
+			cfg.WorkerCount = 2
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []*Item{{Col1: 100}, {Col1: 1}}, v)
+	})
+}
@@ -0,0 +1,105 @@
+package csvlib
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	// delimiterSampleSize number of bytes at the beginning of the input used to detect the delimiter
+	delimiterSampleSize = 8 * 1024
+)
+
+var (
+	// defaultDelimiterCandidates delimiters to be detected when no candidate is specified
+	defaultDelimiterCandidates = []rune{',', ';', '\t', '|'}
+)
+
+// DetectDelimiter detects the delimiter of CSV data by sniffing the first 8KB of the input.
+// The candidates are the given runes, default are comma, semicolon, tab and pipe. The candidate splitting
+// all the sampled rows into the same number of fields, and the most fields, is preferred. When there is
+// no such unique candidate, ErrDelimiterAmbiguous is returned. Data having one column only have
+// the first candidate as the delimiter.
+// The data read from the reader are consumed, use NewDecoderFromReader and DecodeConfig.AutoDetectDelimiter
+// to decode the data with the detected delimiter.
+func DetectDelimiter(r io.Reader, candidates ...rune) (rune, error) {
+	sample := make([]byte, delimiterSampleSize)
+	n, err := io.ReadFull(r, sample)
+	eof := errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+	if err != nil && !eof {
+		return 0, err
+	}
+	return detectDelimiter(sample[:n], eof, candidates)
+}
+
+// detectDelimiter detects the delimiter from the sample data. When the sample is not the whole input,
+// its last line is ignored as it can be incomplete.
+func detectDelimiter(sample []byte, eof bool, candidates []rune) (rune, error) {
+	if len(candidates) == 0 {
+		candidates = defaultDelimiterCandidates
+	}
+	if !eof {
+		if i := bytes.LastIndexByte(sample, '\n'); i >= 0 {
+			sample = sample[:i+1]
+		}
+	}
+
+	bestDelimiter, bestFieldCount, ambiguous := candidates[0], 1, false
+	for _, candidate := range candidates {
+		fieldCount := countConsistentFields(sample, candidate)
+		switch {
+		case fieldCount > bestFieldCount:
+			bestDelimiter, bestFieldCount, ambiguous = candidate, fieldCount, false
+		case fieldCount == bestFieldCount && fieldCount > 1:
+			ambiguous = true
+		}
+	}
+	if ambiguous {
+		return 0, fmt.Errorf("%w: multiple candidates split the rows into %d fields", ErrDelimiterAmbiguous,
+			bestFieldCount)
+	}
+	if bestFieldCount == 1 && hasSplittingCandidate(sample, candidates) {
+		return 0, fmt.Errorf("%w: no candidate splits the rows consistently", ErrDelimiterAmbiguous)
+	}
+	return bestDelimiter, nil
+}
+
+// countConsistentFields gets the number of fields of every row of the sample parsed with the delimiter.
+// Returns `0` when the rows have different numbers of fields or the sample can't be parsed.
+func countConsistentFields(sample []byte, delimiter rune) int {
+	records, err := readSampleRecords(sample, delimiter)
+	if err != nil || len(records) == 0 {
+		return 0
+	}
+	fieldCount := len(records[0])
+	for _, record := range records[1:] {
+		if len(record) != fieldCount {
+			return 0
+		}
+	}
+	return fieldCount
+}
+
+// hasSplittingCandidate checks if any of the candidates splits a row of the sample into multiple fields
+func hasSplittingCandidate(sample []byte, candidates []rune) bool {
+	for _, candidate := range candidates {
+		records, _ := readSampleRecords(sample, candidate)
+		for _, record := range records {
+			if len(record) > 1 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func readSampleRecords(sample []byte, delimiter rune) ([][]string, error) {
+	r := csv.NewReader(bytes.NewReader(sample))
+	r.Comma = delimiter
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	return r.ReadAll()
+}
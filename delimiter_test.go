@@ -0,0 +1,56 @@
+package csvlib
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tiendc/gofn"
+)
+
+func Test_DetectDelimiter(t *testing.T) {
+	t.Run("#1: consistent field count", func(t *testing.T) {
+		delimiter, err := DetectDelimiter(strings.NewReader("a,b,c\n1,2,3\n4,5,6"))
+		assert.Nil(t, err)
+		assert.Equal(t, ',', delimiter)
+
+		// Commas in the data don't split the rows consistently
+		delimiter, err = DetectDelimiter(strings.NewReader(gofn.MultilineString(
+			`name;price
+			abc;1,5
+			"x;y";2`)))
+		assert.Nil(t, err)
+		assert.Equal(t, ';', delimiter)
+
+		delimiter, err = DetectDelimiter(strings.NewReader("a\tb\n1\t2\n"))
+		assert.Nil(t, err)
+		assert.Equal(t, '\t', delimiter)
+	})
+
+	t.Run("#2: custom candidates", func(t *testing.T) {
+		delimiter, err := DetectDelimiter(strings.NewReader("a:b\n1:2"), ',', ':')
+		assert.Nil(t, err)
+		assert.Equal(t, ':', delimiter)
+	})
+
+	t.Run("#3: single column", func(t *testing.T) {
+		delimiter, err := DetectDelimiter(strings.NewReader("name\nabc\n"))
+		assert.Nil(t, err)
+		assert.Equal(t, ',', delimiter)
+	})
+
+	t.Run("#4: ambiguous", func(t *testing.T) {
+		_, err := DetectDelimiter(strings.NewReader("a,b;c\n1,2;3"))
+		assert.ErrorIs(t, err, ErrDelimiterAmbiguous)
+
+		_, err = DetectDelimiter(strings.NewReader("a,b\n1;2;3\n4|5"))
+		assert.ErrorIs(t, err, ErrDelimiterAmbiguous)
+	})
+
+	t.Run("#5: incomplete last line of the sample is ignored", func(t *testing.T) {
+		data := strings.Repeat("abc;123\n", delimiterSampleSize/8) + "x,y,z;1\n"
+		delimiter, err := DetectDelimiter(strings.NewReader(data))
+		assert.Nil(t, err)
+		assert.Equal(t, ';', delimiter)
+	})
+}
@@ -5,14 +5,70 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
+	"time"
 )
 
 var (
 	textMarshaler = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
 	csvMarshaler  = reflect.TypeOf((*CSVMarshaler)(nil)).Elem()
+	preEncodeHook = reflect.TypeOf((*PreEncodeHook)(nil)).Elem()
 )
 
-func getEncodeFunc(typ reflect.Type) (EncodeFunc, error) {
+const (
+	// defaultEncodeTimeLayout layout to encode time values without a specific layout
+	defaultEncodeTimeLayout = time.RFC3339Nano
+
+	// defaultEncodeFloatFormat format to encode float values, see strconv.FormatFloat
+	defaultEncodeFloatFormat = 'f'
+)
+
+// encodeFuncConfig configuration for building encode functions
+type encodeFuncConfig struct {
+	timeLayout      string
+	format          string
+	sep             string
+	intBase         int
+	intBasePrefix   bool
+	numberFormat    *NumberFormat
+	boolTrueText    string
+	boolFalseText   string
+	floatFormat     byte
+	floatPrecision  int
+	typeEncodeFuncs map[reflect.Type]EncodeFunc
+}
+
+func getEncodeFunc(typ reflect.Type, cfg *encodeFuncConfig) (EncodeFunc, error) {
+	if cfg.sep != "" {
+		return encodeSliceFunc(typ, cfg)
+	}
+	if cfg.intBase != 0 {
+		return encodeIntBaseFunc(typ, cfg.intBase, cfg.intBasePrefix)
+	}
+	if encodeFn := findEncodeFunc(cfg.typeEncodeFuncs, typ); encodeFn != nil {
+		return encodeFn, nil
+	}
+	if encodeFn := getRegisteredEncodeFunc(typ); encodeFn != nil {
+		return encodeFn, nil
+	}
+	if typ == timeType {
+		return encodeTimeFunc(cfg.timeLayout), nil
+	}
+	if typ.Kind() == reflect.Pointer && typ.Elem() == timeType {
+		return encodePtrTimeFunc(cfg.timeLayout), nil
+	}
+	if typ == durationType || (typ.Kind() == reflect.Pointer && typ.Elem() == durationType) {
+		if cfg.format != "" && cfg.format != durationFormatSeconds {
+			return nil, fmt.Errorf("%w: format=%s", ErrTagOptionInvalid, cfg.format)
+		}
+		if typ == durationType {
+			return encodeDurationFunc(cfg.format), nil
+		}
+		return encodePtrDurationFunc(cfg.format), nil
+	}
+	if isSQLNullType(indirectType(typ)) {
+		return encodeSQLNullFunc(typ, cfg)
+	}
 	if typ.Implements(csvMarshaler) {
 		return encodeCSVMarshaler, nil
 	}
@@ -25,10 +81,20 @@ func getEncodeFunc(typ reflect.Type) (EncodeFunc, error) {
 	if reflect.PointerTo(typ).Implements(textMarshaler) {
 		return encodePtrTextMarshaler, nil
 	}
-	return getEncodeFuncBaseType(typ)
+	if err := validateFormatTag(typ, cfg.format); err != nil {
+		return nil, err
+	}
+	if cfg.numberFormat != nil && isNumberType(typ) {
+		encodeFn, err := getEncodeFuncBaseType(typ, cfg)
+		if err != nil {
+			return nil, err
+		}
+		return encodeNumberFormatFunc(encodeFn, cfg.numberFormat), nil
+	}
+	return getEncodeFuncBaseType(typ, cfg)
 }
 
-func getEncodeFuncBaseType(typ reflect.Type) (EncodeFunc, error) {
+func getEncodeFuncBaseType(typ reflect.Type, cfg *encodeFuncConfig) (EncodeFunc, error) {
 	typeIsPtr := false
 	if typ.Kind() == reflect.Pointer {
 		typeIsPtr = true
@@ -42,29 +108,29 @@ func getEncodeFuncBaseType(typ reflect.Type) (EncodeFunc, error) {
 		return encodeStr, nil
 	case reflect.Int, reflect.Int64, reflect.Int32, reflect.Int16, reflect.Int8:
 		if typeIsPtr {
-			return encodePtrInt, nil
+			return encodePtrIntFunc(10, ""), nil //nolint:mnd
 		}
-		return encodeInt, nil
+		return encodeIntFunc(10, ""), nil //nolint:mnd
 	case reflect.Uint, reflect.Uint64, reflect.Uint32, reflect.Uint16, reflect.Uint8:
 		if typeIsPtr {
-			return encodePtrUint, nil
+			return encodePtrUintFunc(10, ""), nil //nolint:mnd
 		}
-		return encodeUint, nil
+		return encodeUintFunc(10, ""), nil //nolint:mnd
 	case reflect.Bool:
 		if typeIsPtr {
-			return encodePtrBool, nil
+			return encodePtrBoolFunc(cfg.boolTrueText, cfg.boolFalseText), nil
 		}
-		return encodeBool, nil
+		return encodeBoolFunc(cfg.boolTrueText, cfg.boolFalseText), nil
 	case reflect.Float32, reflect.Float64:
 		if typeIsPtr {
-			return encodePtrFloatFunc(typ.Bits()), nil
+			return encodePtrFloatFunc(typ.Bits(), cfg.floatFormat, cfg.floatPrecision), nil
 		}
-		return encodeFloatFunc(typ.Bits()), nil
+		return encodeFloatFunc(typ.Bits(), cfg.floatFormat, cfg.floatPrecision), nil
 	case reflect.Interface:
 		if typeIsPtr {
-			return encodePtrInterface, nil
+			return encodePtrInterfaceFunc(cfg), nil
 		}
-		return encodeInterface, nil
+		return encodeInterfaceFunc(cfg), nil
 	default:
 		return nil, fmt.Errorf("%w: %v", ErrTypeUnsupported, typ.Kind())
 	}
@@ -89,7 +155,7 @@ func encodePtrCSVMarshaler(v reflect.Value, omitempty bool) (string, error) {
 		return encodeCSVMarshaler(v.Addr(), omitempty)
 	}
 	// Fallback to process the value dynamically
-	encodeFn, err := getEncodeFuncBaseType(v.Type())
+	encodeFn, err := getEncodeFuncBaseType(v.Type(), defaultEncodeFuncConfig())
 	if err != nil {
 		return "", err
 	}
@@ -115,7 +181,7 @@ func encodePtrTextMarshaler(v reflect.Value, omitempty bool) (string, error) {
 		return encodeTextMarshaler(v.Addr(), omitempty)
 	}
 	// Fallback to process the value dynamically
-	encodeFn, err := getEncodeFuncBaseType(v.Type())
+	encodeFn, err := getEncodeFuncBaseType(v.Type(), defaultEncodeFuncConfig())
 	if err != nil {
 		return "", err
 	}
@@ -134,106 +200,281 @@ func encodePtrStr(v reflect.Value, _ bool) (string, error) {
 	return v.String(), nil
 }
 
-func encodeBool(v reflect.Value, omitempty bool) (string, error) {
+func encodeBool(v reflect.Value, omitempty bool, trueText, falseText string) (string, error) {
 	t := v.Bool()
 	if !t && omitempty {
 		return "", nil
 	}
-	return strconv.FormatBool(t), nil
+	if t {
+		return trueText, nil
+	}
+	return falseText, nil
 }
 
-func encodePtrBool(v reflect.Value, omitempty bool) (string, error) {
-	v = v.Elem()
-	if !v.IsValid() {
-		return "", nil
+func encodeBoolFunc(trueText, falseText string) EncodeFunc {
+	return func(v reflect.Value, omitempty bool) (string, error) {
+		return encodeBool(v, omitempty, trueText, falseText)
+	}
+}
+
+func encodePtrBoolFunc(trueText, falseText string) EncodeFunc {
+	return func(v reflect.Value, omitempty bool) (string, error) {
+		v = v.Elem()
+		if !v.IsValid() {
+			return "", nil
+		}
+		return encodeBool(v, omitempty, trueText, falseText)
 	}
-	return encodeBool(v, omitempty)
 }
 
-func encodeInt(v reflect.Value, omitempty bool) (string, error) {
+func encodeInt(v reflect.Value, omitempty bool, base int, prefix string) (string, error) {
 	n := v.Int()
 	if n == 0 && omitempty {
 		return "", nil
 	}
-	return strconv.FormatInt(n, 10), nil
+	if n < 0 {
+		return "-" + prefix + strconv.FormatUint(uint64(-n), base), nil
+	}
+	return prefix + strconv.FormatInt(n, base), nil
 }
 
-func encodePtrInt(v reflect.Value, omitempty bool) (string, error) {
-	v = v.Elem()
-	if !v.IsValid() {
-		return "", nil
+func encodeIntFunc(base int, prefix string) EncodeFunc {
+	return func(v reflect.Value, omitempty bool) (string, error) {
+		return encodeInt(v, omitempty, base, prefix)
+	}
+}
+
+func encodePtrIntFunc(base int, prefix string) EncodeFunc {
+	return func(v reflect.Value, omitempty bool) (string, error) {
+		v = v.Elem()
+		if !v.IsValid() {
+			return "", nil
+		}
+		return encodeInt(v, omitempty, base, prefix)
 	}
-	return encodeInt(v, omitempty)
 }
 
-func encodeUint(v reflect.Value, omitempty bool) (string, error) {
+func encodeUint(v reflect.Value, omitempty bool, base int, prefix string) (string, error) {
 	n := v.Uint()
 	if n == 0 && omitempty {
 		return "", nil
 	}
-	return strconv.FormatUint(n, 10), nil
+	return prefix + strconv.FormatUint(n, base), nil
 }
 
-func encodePtrUint(v reflect.Value, omitempty bool) (string, error) {
-	v = v.Elem()
-	if !v.IsValid() {
-		return "", nil
+func encodeUintFunc(base int, prefix string) EncodeFunc {
+	return func(v reflect.Value, omitempty bool) (string, error) {
+		return encodeUint(v, omitempty, base, prefix)
 	}
-	return encodeUint(v, omitempty)
 }
 
-func encodeFloat(v reflect.Value, omitempty bool, bits int) (string, error) {
+func encodePtrUintFunc(base int, prefix string) EncodeFunc {
+	return func(v reflect.Value, omitempty bool) (string, error) {
+		v = v.Elem()
+		if !v.IsValid() {
+			return "", nil
+		}
+		return encodeUint(v, omitempty, base, prefix)
+	}
+}
+
+// encodeNumberFormatFunc converts the encoded number text to the given format
+func encodeNumberFormatFunc(encodeFn EncodeFunc, format *NumberFormat) EncodeFunc {
+	return func(v reflect.Value, omitempty bool) (string, error) {
+		s, err := encodeFn(v, omitempty)
+		if err != nil {
+			return "", err
+		}
+		return format.format(s), nil
+	}
+}
+
+// encodeIntBaseFunc gets encode function for integers in the given base (set via tag option `base`).
+// Base 0 (auto-detection when decoding) is encoded as base 10.
+func encodeIntBaseFunc(typ reflect.Type, base int, withPrefix bool) (EncodeFunc, error) {
+	prefix := ""
+	switch base {
+	case intBaseAuto:
+		base = 10
+	case 2: //nolint:mnd
+		prefix = "0b"
+	case 8: //nolint:mnd
+		prefix = "0o"
+	case 16: //nolint:mnd
+		prefix = "0x"
+	}
+	if !withPrefix {
+		prefix = ""
+	}
+	typeIsPtr := typ.Kind() == reflect.Pointer
+	switch indirectType(typ).Kind() { // nolint: exhaustive
+	case reflect.Int, reflect.Int64, reflect.Int32, reflect.Int16, reflect.Int8:
+		if typeIsPtr {
+			return encodePtrIntFunc(base, prefix), nil
+		}
+		return encodeIntFunc(base, prefix), nil
+	case reflect.Uint, reflect.Uint64, reflect.Uint32, reflect.Uint16, reflect.Uint8:
+		if typeIsPtr {
+			return encodePtrUintFunc(base, prefix), nil
+		}
+		return encodeUintFunc(base, prefix), nil
+	default:
+		return nil, fmt.Errorf("%w: base tag is only accepted for integer column", ErrTagOptionInvalid)
+	}
+}
+
+func encodeFloat(v reflect.Value, omitempty bool, bits int, format byte, precision int) (string, error) {
 	f := v.Float()
 	if f == 0 && omitempty {
 		return "", nil
 	}
-	return strconv.FormatFloat(f, 'f', -1, bits), nil
+	return strconv.FormatFloat(f, format, precision, bits), nil
 }
 
-func encodePtrFloat(v reflect.Value, omitempty bool, bits int) (string, error) {
+func encodePtrFloat(v reflect.Value, omitempty bool, bits int, format byte, precision int) (string, error) {
 	v = v.Elem()
 	if !v.IsValid() {
 		return "", nil
 	}
-	return encodeFloat(v, omitempty, bits)
+	return encodeFloat(v, omitempty, bits, format, precision)
 }
 
-func encodeFloatFunc(bits int) EncodeFunc {
+func encodeFloatFunc(bits int, format byte, precision int) EncodeFunc {
 	return func(v reflect.Value, omitempty bool) (string, error) {
-		return encodeFloat(v, omitempty, bits)
+		return encodeFloat(v, omitempty, bits, format, precision)
 	}
 }
 
-func encodePtrFloatFunc(bits int) EncodeFunc {
+func encodePtrFloatFunc(bits int, format byte, precision int) EncodeFunc {
 	return func(v reflect.Value, omitempty bool) (string, error) {
-		return encodePtrFloat(v, omitempty, bits)
+		return encodePtrFloat(v, omitempty, bits, format, precision)
 	}
 }
 
-func encodeInterface(v reflect.Value, omitempty bool) (string, error) {
-	val := v.Elem()
-	if !val.IsValid() {
+func encodeTime(v reflect.Value, omitempty bool, layout string) (string, error) {
+	t, _ := v.Interface().(time.Time)
+	if t.IsZero() && omitempty {
 		return "", nil
 	}
-	encodeFn, err := getEncodeFunc(val.Type())
+	return t.Format(layout), nil
+}
+
+func encodeTimeFunc(layout string) EncodeFunc {
+	return func(v reflect.Value, omitempty bool) (string, error) {
+		return encodeTime(v, omitempty, layout)
+	}
+}
+
+func encodePtrTimeFunc(layout string) EncodeFunc {
+	return func(v reflect.Value, omitempty bool) (string, error) {
+		v = v.Elem()
+		if !v.IsValid() {
+			return "", nil
+		}
+		return encodeTime(v, omitempty, layout)
+	}
+}
+
+// encodeSQLNullFunc builds the encode function for the nullable types of package `database/sql`.
+// Invalid values (`Valid` is `false`) are encoded as empty.
+func encodeSQLNullFunc(typ reflect.Type, cfg *encodeFuncConfig) (EncodeFunc, error) {
+	valueEncodeFunc, err := getEncodeFunc(indirectType(typ).Field(0).Type, cfg)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	return encodeFn(val, omitempty)
+	return func(v reflect.Value, omitempty bool) (string, error) {
+		v = indirectValue(v)
+		if !v.IsValid() || !v.Field(1).Bool() {
+			return "", nil
+		}
+		return valueEncodeFunc(v.Field(0), omitempty)
+	}, nil
 }
 
-func encodePtrInterface(v reflect.Value, omitempty bool) (string, error) {
-	val := v.Elem()
-	if !val.IsValid() {
+func encodeDuration(v reflect.Value, omitempty bool, format string) (string, error) {
+	d := time.Duration(v.Int())
+	if d == 0 && omitempty {
 		return "", nil
 	}
-	val = val.Elem()
+	if format == durationFormatSeconds {
+		return strconv.FormatFloat(d.Seconds(), 'f', -1, 64), nil
+	}
+	return d.String(), nil
+}
+
+func encodeDurationFunc(format string) EncodeFunc {
+	return func(v reflect.Value, omitempty bool) (string, error) {
+		return encodeDuration(v, omitempty, format)
+	}
+}
+
+func encodePtrDurationFunc(format string) EncodeFunc {
+	return func(v reflect.Value, omitempty bool) (string, error) {
+		v = v.Elem()
+		if !v.IsValid() {
+			return "", nil
+		}
+		return encodeDuration(v, omitempty, format)
+	}
+}
+
+func encodeSliceFunc(typ reflect.Type, cfg *encodeFuncConfig) (EncodeFunc, error) {
+	if typ.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("%w: sep tag is only accepted for slice column", ErrTagOptionInvalid)
+	}
+	elemCfg := *cfg
+	elemCfg.sep = ""
+	elemEncodeFunc, err := getEncodeFunc(typ.Elem(), &elemCfg)
+	if err != nil {
+		return nil, err
+	}
+	return func(v reflect.Value, _ bool) (string, error) {
+		elems := make([]string, v.Len())
+		for i := range elems {
+			elem, err := elemEncodeFunc(v.Index(i), false)
+			if err != nil {
+				return "", err
+			}
+			elems[i] = elem
+		}
+		return strings.Join(elems, cfg.sep), nil
+	}, nil
+}
+
+func encodeInterface(v reflect.Value, omitempty bool, cfg *encodeFuncConfig) (string, error) {
+	val := v.Elem()
 	if !val.IsValid() {
 		return "", nil
 	}
-	encodeFn, err := getEncodeFunc(val.Type())
+	encodeFn, err := getEncodeFunc(val.Type(), cfg)
 	if err != nil {
 		return "", err
 	}
 	return encodeFn(val, omitempty)
 }
+
+func encodeInterfaceFunc(cfg *encodeFuncConfig) EncodeFunc {
+	return func(v reflect.Value, omitempty bool) (string, error) {
+		return encodeInterface(v, omitempty, cfg)
+	}
+}
+
+func encodePtrInterfaceFunc(cfg *encodeFuncConfig) EncodeFunc {
+	return func(v reflect.Value, omitempty bool) (string, error) {
+		val := v.Elem()
+		if !val.IsValid() {
+			return "", nil
+		}
+		return encodeInterface(val, omitempty, cfg)
+	}
+}
+
+func defaultEncodeFuncConfig() *encodeFuncConfig {
+	return &encodeFuncConfig{
+		timeLayout:     defaultEncodeTimeLayout,
+		boolTrueText:   strconv.FormatBool(true),
+		boolFalseText:  strconv.FormatBool(false),
+		floatFormat:    defaultEncodeFloatFormat,
+		floatPrecision: -1,
+	}
+}
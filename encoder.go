@@ -1,8 +1,12 @@
 package csvlib
 
 import (
+	"context"
+	"encoding/csv"
 	"fmt"
+	"io"
 	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/go-multierror"
@@ -17,19 +21,90 @@ type EncodeConfig struct {
 	// NoHeaderMode indicates whether to write header or not (default is `false`)
 	NoHeaderMode bool
 
+	// StopOnError stop the encoding when PreEncode() of an item returns error (default is `true`).
+	// If this is `false`, the item is not encoded and the error is collected, the encode functions
+	// return the collected errors as *Errors after encoding the other items.
+	StopOnError bool
+
+	// Comma delimiter of the fields, applied to the built-in csv.Writer used by Marshal or passed to NewEncoder
+	// (default is `,`). Only single-byte delimiters are supported.
+	Comma rune
+
+	// UseCRLF terminate the lines with `\r\n` instead of `\n`, applied to the built-in csv.Writer used by Marshal
+	// or passed to NewEncoder (default is `false`)
+	UseCRLF bool
+
 	// LocalizeHeader indicates whether to localize the header or not (default is `false`)
 	LocalizeHeader bool
 
 	// LocalizationFunc localization function, required when LocalizeHeader is true
 	LocalizationFunc LocalizationFunc
 
+	// TimeLayout layout to encode time.Time values (default is RFC3339 with nanoseconds)
+	TimeLayout string
+
+	// BoolTrueText text to encode `true` values of bool fields (default is `true`)
+	BoolTrueText string
+
+	// BoolFalseText text to encode `false` values of bool fields (default is `false`)
+	BoolFalseText string
+
+	// NumberFormat format of numbers to encode int, uint and float columns, e.g. `1,234.56` or `1.234,56`
+	// (optional). Other columns are not affected.
+	NumberFormat *NumberFormat
+
+	// NullRepresentation text to encode nil pointer and nil interface values, e.g. `NULL` or `\N`
+	// (default is empty). The text is written even for `omitempty` columns.
+	NullRepresentation string
+
+	// FloatFormat format to encode float values, one of `f`, `e`, `E`, `g`, `G` (default is `f`).
+	// See strconv.FormatFloat for the meaning of the formats.
+	FloatFormat byte
+
+	// FloatPrecision number of digits to encode float values, see strconv.FormatFloat
+	// (default is `-1` - the smallest number of digits necessary to represent the value exactly)
+	FloatPrecision int
+
+	// TypeEncodeFuncs encode functions for all fields of specific types, e.g. to encode all `time.Time` fields
+	// in a custom way without configuring every column (optional). Fields of pointer to the types are also
+	// encoded by the functions, nil pointers are encoded as empty. EncodeColumnConfig.EncodeFunc takes precedence.
+	TypeEncodeFuncs map[reflect.Type]EncodeFunc
+
+	// ColumnOrder order of columns to encode, specified by header keys (optional).
+	// Columns not in the list are appended at the end in struct order unless StrictColumnOrder is `true`.
+	// The name of an inline column can be used to move all of its columns together.
+	ColumnOrder []string
+
+	// StrictColumnOrder only encode the columns specified in ColumnOrder (default is `false`)
+	StrictColumnOrder bool
+
+	// EncodeRestColumns encode the entries of the field tagged `rest` as columns (default is `false`).
+	// The columns are collected from all items passed to the first Encode call and are written
+	// in sorted order after the other columns. Entries of later items not in the header are ignored.
+	EncodeRestColumns bool
+
+	// FlushInterval number of rows to be encoded between flushes of the writer when calling EncodeStream
+	// (default is `1000`). If this is not positive, the writer is flushed only when the stream ends.
+	// The writer is flushed only when it has a `Flush()` function like csv.Writer.
+	FlushInterval int
+
+	// OnRowEncoded function to be called after every item is processed by Encode and EncodeStream, even when
+	// the item fails to be encoded or is nil (optional). `row` is the number of processed items, `total` is
+	// the number of items passed to Encode or `-1` for EncodeStream. The func is called in the encoding loop,
+	// it should not block (e.g. send to a buffered channel).
+	OnRowEncoded func(row int, total int)
+
 	// columnConfigMap a map consists of configuration for specific columns (optional)
 	columnConfigMap map[string]*EncodeColumnConfig
 }
 
 func defaultEncodeConfig() *EncodeConfig {
 	return &EncodeConfig{
-		TagName: DefaultTagName,
+		TagName:        DefaultTagName,
+		StopOnError:    true,
+		FloatFormat:    defaultEncodeFloatFormat,
+		FloatPrecision: -1,
+		FlushInterval:  defaultFlushInterval,
 	}
 }
 
@@ -48,6 +123,10 @@ func (c *EncodeConfig) ConfigureColumn(name string, fn func(*EncodeColumnConfig)
 
 // EncodeColumnConfig configuration for encoding a specific column
 type EncodeColumnConfig struct {
+	// HeaderText text to write in the header for the column, replaces the column name from the struct tag
+	// and takes precedence over EncodeConfig.LocalizeHeader (optional)
+	HeaderText string
+
 	// Skip whether skip encoding the column or not (this is equivalent to use `csv:"-"` in struct tag)
 	// (default is `false`)
 	Skip bool
@@ -55,14 +134,47 @@ type EncodeColumnConfig struct {
 	// EncodeFunc custom encode function (optional)
 	EncodeFunc EncodeFunc
 
+	// TimeLayout layout to encode time.Time values of this column, overrides EncodeConfig.TimeLayout (optional)
+	TimeLayout string
+
+	// BoolTrueText text to encode `true` values, overrides EncodeConfig.BoolTrueText (optional)
+	BoolTrueText string
+
+	// BoolFalseText text to encode `false` values, overrides EncodeConfig.BoolFalseText (optional)
+	BoolFalseText string
+
+	// NullRepresentation text to encode nil values, overrides EncodeConfig.NullRepresentation (optional)
+	NullRepresentation string
+
+	// BasePrefix add the prefix `0x`, `0o` or `0b` to the integers encoded in base 16, 8 or 2 via
+	// the tag option `base` (default is `false`)
+	BasePrefix bool
+
+	// FloatFormat format to encode float values, overrides EncodeConfig.FloatFormat (optional)
+	FloatFormat byte
+
+	// FloatPrecision number of digits to encode float values, overrides EncodeConfig.FloatPrecision
+	// (default is `-2` - use EncodeConfig.FloatPrecision)
+	FloatPrecision int
+
 	// PostprocessorFuncs a list of functions will be called after encoding a cell value (optional)
 	PostprocessorFuncs []ProcessorFunc
 }
 
 func defaultEncodeColumnConfig() *EncodeColumnConfig {
-	return &EncodeColumnConfig{}
+	return &EncodeColumnConfig{
+		FloatPrecision: floatPrecisionInherit,
+	}
 }
 
+const (
+	// floatPrecisionInherit the column uses the float precision of the global config
+	floatPrecisionInherit = -2
+
+	// defaultFlushInterval number of encoded rows between flushes of the writer in EncodeStream by default
+	defaultFlushInterval = 1000
+)
+
 // EncodeOption function to modify encoding config
 type EncodeOption func(cfg *EncodeConfig)
 
@@ -77,6 +189,9 @@ type Encoder struct {
 	hasDynamicInlineColumns bool
 	hasFixedInlineColumns   bool
 	colsMeta                []*encodeColumnMeta
+	flushOnFinish           bool
+	hasPreEncodeHook        bool
+	nextRow                 int
 }
 
 // NewEncoder creates a new Encoder object
@@ -91,9 +206,23 @@ func NewEncoder(w Writer, options ...EncodeOption) *Encoder {
 	}
 }
 
+// NewEncoderToWriter creates a new Encoder object writing CSV data to the given io.Writer via a `csv.Writer`.
+// The data are buffered, call Finish() to flush them to the writer.
+func NewEncoderToWriter(w io.Writer, options ...EncodeOption) *Encoder {
+	e := NewEncoder(csv.NewWriter(w), options...)
+	e.flushOnFinish = true
+	return e
+}
+
 // Encode encode input data stored in the given variable.
 // The input var must be a slice, e.g. `[]Student` or `[]*Student`.
 func (e *Encoder) Encode(v any) error {
+	return e.EncodeContext(context.Background(), v)
+}
+
+// EncodeContext the same as Encode, but the context is checked between rows.
+// When the context is done, the encoding stops and an *Errors wrapping the context error is returned.
+func (e *Encoder) EncodeContext(ctx context.Context, v any) error {
 	if e.finished {
 		return ErrFinished
 	}
@@ -119,24 +248,43 @@ func (e *Encoder) Encode(v any) error {
 
 	totalRow := val.Len()
 	itemKindIsPtr := e.itemType.Kind() == reflect.Pointer
+	rowErrs := NewErrors()
 	for row := 0; row < totalRow; row++ {
+		if err := ctx.Err(); err != nil {
+			rowErrs.Add(err)
+			e.err = rowErrs
+			break
+		}
 		rowVal := val.Index(row)
+		e.nextRow++
 		if itemKindIsPtr {
 			if rowVal.IsNil() {
+				e.callOnRowEncoded(row+1, totalRow)
 				continue
 			}
 			rowVal = rowVal.Elem()
 		}
-		if err := e.encodeRow(rowVal); err != nil {
+		err := e.encodeRow(e.nextRow, rowVal, rowErrs)
+		e.callOnRowEncoded(row+1, totalRow)
+		if err != nil {
 			e.err = err
 			break
 		}
 	}
+	if e.err == nil && rowErrs.HasError() {
+		return rowErrs
+	}
 	return e.err
 }
 
 // EncodeOne encode single object into a single CSV row
 func (e *Encoder) EncodeOne(v any) error {
+	return e.EncodeOneContext(context.Background(), v)
+}
+
+// EncodeOneContext the same as EncodeOne, but the context is checked before encoding the object.
+// When the context is done, an *Errors wrapping the context error is returned and the encoder can't encode more.
+func (e *Encoder) EncodeOneContext(ctx context.Context, v any) error {
 	if e.finished {
 		return ErrFinished
 	}
@@ -161,15 +309,38 @@ func (e *Encoder) EncodeOne(v any) error {
 		return fmt.Errorf("%w: %v (expect %v)", ErrTypeUnmatched, itemType, e.itemType)
 	}
 
-	if err := e.encodeRow(rowVal); err != nil {
+	if err := ctx.Err(); err != nil {
+		errs := NewErrors()
+		errs.Add(err)
+		e.err = errs
+		return errs
+	}
+	e.nextRow++
+	if rowVal.Kind() == reflect.Pointer {
+		if rowVal.IsNil() {
+			return nil
+		}
+		rowVal = rowVal.Elem()
+	}
+	rowErrs := NewErrors()
+	if err := e.encodeRow(e.nextRow, rowVal, rowErrs); err != nil {
 		e.err = err
 		return err
 	}
+	if rowErrs.HasError() {
+		return rowErrs
+	}
 	return nil
 }
 
-// Finish encoding, after calling this func, you can't encode more
+// Finish encoding, after calling this func, you can't encode more.
+// The writer of the encoders created via NewEncoderToWriter is flushed.
 func (e *Encoder) Finish() error {
+	if e.flushOnFinish && !e.finished {
+		if err := e.flushWriter(); err != nil && e.err == nil {
+			e.err = err
+		}
+	}
 	e.finished = true
 	return e.err
 }
@@ -187,6 +358,7 @@ func (e *Encoder) prepareEncode(v reflect.Value) error {
 	if err = e.validateConfig(); err != nil {
 		return err
 	}
+	e.configureCSVWriter()
 
 	if err = e.parseColumnsMeta(itemType, v); err != nil {
 		return err
@@ -195,6 +367,7 @@ func (e *Encoder) prepareEncode(v reflect.Value) error {
 	if err = e.buildColumnEncoders(); err != nil {
 		return err
 	}
+	e.hasPreEncodeHook = reflect.PointerTo(indirectType(itemType)).Implements(preEncodeHook)
 
 	if err = e.encodeHeader(); err != nil {
 		return err
@@ -226,7 +399,22 @@ func (e *Encoder) encodeHeader() error {
 	}
 }
 
-func (e *Encoder) encodeRow(rowVal reflect.Value) error {
+// encodeRow encodes the item of the given 1-based row number. When PreEncode() of the item
+// returns error and StopOnError is `false`, the error is added to the given errors and the item is skipped.
+func (e *Encoder) encodeRow(row int, rowVal reflect.Value, rowErrs *Errors) error {
+	if e.hasPreEncodeHook {
+		var err error
+		if rowVal, err = callPreEncodeHook(rowVal); err != nil {
+			if e.cfg.StopOnError {
+				return err
+			}
+			rowErr := NewRowErrors(row, 0)
+			rowErr.Add(err)
+			rowErrs.Add(rowErr)
+			return nil
+		}
+	}
+
 	colsMeta := e.colsMeta
 	if e.hasDynamicInlineColumns || e.hasFixedInlineColumns {
 		for _, colMeta := range colsMeta {
@@ -243,13 +431,20 @@ func (e *Encoder) encodeRow(rowVal reflect.Value) error {
 		}
 		colVal := colMeta.getColumnValue(rowVal)
 		if !colVal.IsValid() {
-			record = append(record, "")
+			record = append(record, colMeta.emptyText())
+			continue
+		}
+		if colMeta.nullText != "" && isNilValue(colVal) {
+			record = append(record, colMeta.nullText)
 			continue
 		}
 		text, err := colMeta.encodeFunc(colVal, colMeta.omitEmpty)
 		if err != nil {
 			return err
 		}
+		if text == "" {
+			text = colMeta.emptyText()
+		}
 		for _, fn := range colMeta.postprocessorFuncs {
 			text = fn(text)
 		}
@@ -258,6 +453,24 @@ func (e *Encoder) encodeRow(rowVal reflect.Value) error {
 	return e.w.Write(record)
 }
 
+// callOnRowEncoded call EncodeConfig.OnRowEncoded if it is set
+func (e *Encoder) callOnRowEncoded(row, total int) {
+	if e.cfg.OnRowEncoded != nil {
+		e.cfg.OnRowEncoded(row, total)
+	}
+}
+
+// callPreEncodeHook calls PreEncode() of the item. The items which are not addressable
+// (e.g. passed to EncodeOne by value) are copied, so the hook can modify them before encoding.
+func callPreEncodeHook(rowVal reflect.Value) (reflect.Value, error) {
+	if !rowVal.CanAddr() {
+		copied := reflect.New(rowVal.Type()).Elem()
+		copied.Set(rowVal)
+		rowVal = copied
+	}
+	return rowVal, rowVal.Addr().Interface().(PreEncodeHook).PreEncode() // nolint: forcetypeassert
+}
+
 func (e *Encoder) parseInputVar(v reflect.Value) (itemType reflect.Type, err error) {
 	kind := v.Kind()
 	if kind != reflect.Slice && kind != reflect.Array {
@@ -283,15 +496,66 @@ func (e *Encoder) validateConfig() error {
 	if e.cfg.LocalizeHeader && e.cfg.LocalizationFunc == nil {
 		return fmt.Errorf("%w: localization function required", ErrConfigOptionInvalid)
 	}
+	if err := validateCSVDelimiter("Comma", e.cfg.Comma); err != nil {
+		return err
+	}
+	if e.cfg.NumberFormat != nil {
+		if err := e.cfg.NumberFormat.validate(); err != nil {
+			return err
+		}
+	}
+	if !isFloatFormatValid(e.cfg.FloatFormat) {
+		return fmt.Errorf("%w: float format '%c' invalid", ErrConfigOptionInvalid, e.cfg.FloatFormat)
+	}
+	if e.cfg.FloatPrecision < -1 {
+		return fmt.Errorf("%w: float precision must not be less than -1", ErrConfigOptionInvalid)
+	}
+	for colKey, columnCfg := range e.cfg.columnConfigMap {
+		if columnCfg.FloatFormat != 0 && !isFloatFormatValid(columnCfg.FloatFormat) {
+			return fmt.Errorf("%w: float format '%c' of column \"%s\" invalid",
+				ErrConfigOptionInvalid, columnCfg.FloatFormat, colKey)
+		}
+		if columnCfg.FloatPrecision < floatPrecisionInherit {
+			return fmt.Errorf("%w: float precision of column \"%s\" must not be less than -2",
+				ErrConfigOptionInvalid, colKey)
+		}
+	}
 	return nil
 }
 
+// configureCSVWriter apply EncodeConfig.Comma and EncodeConfig.UseCRLF to the writer if it is a csv.Writer
+func (e *Encoder) configureCSVWriter() {
+	csvWriter, ok := e.w.(*csv.Writer)
+	if !ok {
+		return
+	}
+	if e.cfg.Comma != 0 {
+		csvWriter.Comma = e.cfg.Comma
+	}
+	if e.cfg.UseCRLF {
+		csvWriter.UseCRLF = true
+	}
+}
+
+// isFloatFormatValid checks if the format is accepted by strconv.FormatFloat for encoding decimal numbers
+func isFloatFormatValid(format byte) bool {
+	switch format {
+	case 'f', 'e', 'E', 'g', 'G':
+		return true
+	}
+	return false
+}
+
 func (e *Encoder) parseColumnsMeta(itemType reflect.Type, val reflect.Value) error {
 	colsMeta, err := e.parseColumnsMetaFromStructType(itemType, val)
 	if err != nil {
 		return err
 	}
 
+	if colsMeta, err = e.reorderColumnsMeta(colsMeta); err != nil {
+		return err
+	}
+
 	if err = e.validateColumnsMeta(colsMeta); err != nil {
 		return err
 	}
@@ -318,10 +582,51 @@ func (e *Encoder) validateColumnsMeta(colsMeta []*encodeColumnMeta) error {
 	return nil
 }
 
-func (e *Encoder) parseColumnsMetaFromStructType(itemType reflect.Type, val reflect.Value) (
-	colsMeta []*encodeColumnMeta, err error) {
+// reorderColumnsMeta reorder columns based on the configuration EncodeConfig.ColumnOrder
+func (e *Encoder) reorderColumnsMeta(colsMeta []*encodeColumnMeta) ([]*encodeColumnMeta, error) {
 	cfg := e.cfg
+	if len(cfg.ColumnOrder) == 0 {
+		return colsMeta, nil
+	}
+
+	newColsMeta := make([]*encodeColumnMeta, 0, len(colsMeta))
+	mapAdded := make(map[*encodeColumnMeta]struct{}, len(colsMeta))
+	for _, name := range cfg.ColumnOrder {
+		found := false
+		for _, colMeta := range colsMeta {
+			isDynamicInline := colMeta.inlineColumnMeta != nil &&
+				colMeta.inlineColumnMeta.inlineType == inlineColumnStructDynamic
+			if (colMeta.headerKey != name || isDynamicInline) && colMeta.parentKey != name {
+				continue
+			}
+			if _, ok := mapAdded[colMeta]; ok {
+				return nil, fmt.Errorf("%w: column \"%s\" duplicated in column order", ErrConfigOptionInvalid, name)
+			}
+			mapAdded[colMeta] = struct{}{}
+			newColsMeta = append(newColsMeta, colMeta)
+			found = true
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: column \"%s\" in column order not found", ErrConfigOptionInvalid, name)
+		}
+	}
+
+	if !cfg.StrictColumnOrder {
+		for _, colMeta := range colsMeta {
+			if _, ok := mapAdded[colMeta]; !ok {
+				newColsMeta = append(newColsMeta, colMeta)
+			}
+		}
+	}
+
+	for i, colMeta := range newColsMeta {
+		colMeta.column = i
+	}
+	return newColsMeta, nil
+}
 
+func (e *Encoder) parseColumnsMetaFromStructType(itemType reflect.Type, val reflect.Value) (
+	colsMeta []*encodeColumnMeta, err error) {
 	// Get first row data from the input
 	firstRowVal := reflect.Value{}
 	if val.Len() > 0 {
@@ -331,30 +636,75 @@ func (e *Encoder) parseColumnsMetaFromStructType(itemType reflect.Type, val refl
 		firstRowVal = firstRowVal.Elem()
 	}
 
-	itemType = indirectType(itemType)
-	numFields := itemType.NumField()
+	colsMeta, restColsMeta, err := e.parseColumnsMetaFromStructFields(indirectType(itemType),
+		&embeddedStruct{}, val, firstRowVal)
+	if err != nil {
+		return nil, err
+	}
+	colsMeta = append(colsMeta, restColsMeta...)
+
+	for i, colMeta := range colsMeta {
+		colMeta.column = i
+	}
+	return colsMeta, nil
+}
+
+// parseColumnsMetaFromStructFields parse columns metadata from the fields of the struct type.
+// Fields of embedded structs are parsed recursively as if they are fields of the parent struct.
+func (e *Encoder) parseColumnsMetaFromStructFields(typ reflect.Type, parent *embeddedStruct,
+	val, firstRowVal reflect.Value) (colsMeta, restColsMeta []*encodeColumnMeta, err error) {
+	cfg := e.cfg
+	numFields := typ.NumField()
 	for i := 0; i < numFields; i++ {
-		field := itemType.Field(i)
+		field := parent.structField(typ, i)
 		tag, err := parseTag(cfg.TagName, field)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+		if isEmbeddedStructField(field, tag) {
+			embeddedColsMeta, embeddedRestColsMeta, err := e.parseColumnsMetaFromStructFields(
+				indirectType(field.Type), parent.embed(field, tag), val, firstRowVal)
+			if err != nil {
+				return nil, nil, err
+			}
+			colsMeta = append(colsMeta, embeddedColsMeta...)
+			restColsMeta = append(restColsMeta, embeddedRestColsMeta...)
+			continue
 		}
 		if tag == nil || tag.ignored {
 			continue
 		}
+		if tag.rest {
+			if field.Type != restColumnType {
+				return nil, nil, fmt.Errorf("%w: rest column must be of type %v", ErrTagOptionInvalid, restColumnType)
+			}
+			if cfg.EncodeRestColumns {
+				restColsMeta = append(restColsMeta, e.parseRestColumns(field, val)...)
+			}
+			continue
+		}
 
+		headerKey := parent.prefix + tag.name
+		if tag.inline {
+			// Prefix of embedded struct is applied to the inline columns via the inline prefix
+			headerKey = tag.name
+		}
 		colMeta := &encodeColumnMeta{
-			column:      len(colsMeta),
-			headerKey:   tag.name,
-			headerText:  tag.name,
-			prefix:      tag.prefix,
-			omitEmpty:   tag.omitEmpty,
-			targetField: field,
+			column:       len(colsMeta),
+			headerKey:    headerKey,
+			headerText:   headerKey,
+			prefix:       parent.prefix + tag.prefix,
+			omitEmpty:    tag.omitEmpty || parent.omitEmpty,
+			format:       tag.format,
+			sep:          tag.sep,
+			base:         tag.base,
+			defaultValue: tag.defValue,
+			targetField:  field,
 		}
 		if tag.inline {
 			inlineColsMeta, err := e.parseInlineColumn(field, colMeta, firstRowVal)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			colsMeta = append(colsMeta, inlineColsMeta...)
 			continue
@@ -362,22 +712,56 @@ func (e *Encoder) parseColumnsMetaFromStructType(itemType reflect.Type, val refl
 
 		colMeta.copyConfig(cfg.columnConfigMap[colMeta.headerKey])
 		if err = colMeta.localizeHeader(cfg); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		colsMeta = append(colsMeta, colMeta)
 	}
+	return colsMeta, restColsMeta, nil
+}
 
-	for i, colMeta := range colsMeta {
-		colMeta.column = i
+// parseRestColumns collects the keys of the rest column from all items of the input in sorted order
+func (e *Encoder) parseRestColumns(field reflect.StructField, val reflect.Value) []*encodeColumnMeta {
+	mapKeys := map[string]struct{}{}
+	for i := 0; i < val.Len(); i++ {
+		rowVal := indirectValue(val.Index(i))
+		if !rowVal.IsValid() {
+			continue
+		}
+		restVal := fieldByIndex(rowVal, field.Index)
+		if !restVal.IsValid() {
+			continue
+		}
+		restValues, _ := restVal.Interface().(map[string]string)
+		for k := range restValues {
+			mapKeys[k] = struct{}{}
+		}
 	}
-	return colsMeta, err
+	keys := make([]string, 0, len(mapKeys))
+	for k := range mapKeys {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	colsMeta := make([]*encodeColumnMeta, 0, len(keys))
+	for _, k := range keys {
+		colsMeta = append(colsMeta, &encodeColumnMeta{
+			headerKey:   k,
+			headerText:  k,
+			rest:        true,
+			targetField: field,
+		})
+	}
+	return colsMeta
 }
 
 func (e *Encoder) parseInlineColumn(field reflect.StructField, parentCol *encodeColumnMeta, firstRowVal reflect.Value) (
 	colsMeta []*encodeColumnMeta, err error) {
+	inlineStruct := reflect.Value{}
 	if firstRowVal.IsValid() {
-		inlineStruct := firstRowVal.Field(field.Index[0])
+		inlineStruct = fieldByIndex(firstRowVal, field.Index)
+	}
+	if inlineStruct.IsValid() {
 		inlineColumnsMeta, err := e.parseInlineColumnDynamicType(inlineStruct, parentCol)
 		if err == nil {
 			e.hasDynamicInlineColumns = true
@@ -416,6 +800,9 @@ func (e *Encoder) parseInlineColumnFixedType(typ reflect.Type, parent *encodeCol
 			headerKey:   headerKey,
 			headerText:  headerKey,
 			parentKey:   parent.headerKey,
+			format:      tag.format,
+			sep:         tag.sep,
+			base:        tag.base,
 			targetField: parent.targetField,
 			inlineColumnMeta: &inlineColumnMeta{
 				inlineType:  inlineColumnStructFixed,
@@ -499,6 +886,9 @@ func (e *Encoder) parseInlineColumnDynamicType(inlineStruct reflect.Value, paren
 
 func (e *Encoder) buildColumnEncoders() error {
 	for _, colMeta := range e.colsMeta {
+		if colMeta.nullText == "" {
+			colMeta.nullText = e.cfg.NullRepresentation
+		}
 		if colMeta.encodeFunc != nil {
 			continue
 		}
@@ -506,7 +896,10 @@ func (e *Encoder) buildColumnEncoders() error {
 		if colMeta.inlineColumnMeta != nil {
 			dataType = colMeta.inlineColumnMeta.dataType
 		}
-		encodeFunc, err := getEncodeFunc(dataType)
+		if colMeta.rest {
+			dataType = dataType.Elem()
+		}
+		encodeFunc, err := getEncodeFunc(dataType, colMeta.buildEncodeFuncConfig(e.cfg))
 		if err != nil {
 			return err
 		}
@@ -542,6 +935,23 @@ type encodeColumnMeta struct {
 	prefix     string
 	omitEmpty  bool
 	skipColumn bool
+	rest       bool
+	timeLayout string
+	format     string
+	sep        string
+	base       int
+	basePrefix bool
+	boolTrue   string
+	boolFalse  string
+
+	// defaultValue text to be written instead of the empty text of `omitempty` columns
+	defaultValue string
+
+	// nullText text to be written for nil values
+	nullText string
+
+	floatFormat    byte
+	floatPrecision *int
 
 	targetField      reflect.StructField
 	inlineColumnMeta *inlineColumnMeta
@@ -550,7 +960,20 @@ type encodeColumnMeta struct {
 	postprocessorFuncs []ProcessorFunc
 }
 
+// emptyText gets the text to be written when the column value is encoded as empty,
+// the default value set via the tag option `default=` is used for `omitempty` columns
+func (m *encodeColumnMeta) emptyText() string {
+	if m.omitEmpty {
+		return m.defaultValue
+	}
+	return ""
+}
+
 func (m *encodeColumnMeta) localizeHeader(cfg *EncodeConfig) error {
+	if columnCfg := cfg.columnConfigMap[m.headerKey]; columnCfg != nil && columnCfg.HeaderText != "" {
+		m.headerText = columnCfg.HeaderText
+		return nil
+	}
 	if cfg.LocalizeHeader {
 		headerText, err := cfg.LocalizationFunc(m.headerKey, nil)
 		if err != nil {
@@ -567,13 +990,58 @@ func (m *encodeColumnMeta) copyConfig(columnCfg *EncodeColumnConfig) {
 	}
 	m.skipColumn = columnCfg.Skip
 	m.encodeFunc = columnCfg.EncodeFunc
+	m.timeLayout = columnCfg.TimeLayout
+	m.boolTrue = columnCfg.BoolTrueText
+	m.boolFalse = columnCfg.BoolFalseText
+	m.basePrefix = columnCfg.BasePrefix
+	m.nullText = columnCfg.NullRepresentation
+	m.floatFormat = columnCfg.FloatFormat
+	if columnCfg.FloatPrecision != floatPrecisionInherit {
+		m.floatPrecision = &columnCfg.FloatPrecision
+	}
 	m.postprocessorFuncs = columnCfg.PostprocessorFuncs
 }
 
+func (m *encodeColumnMeta) buildEncodeFuncConfig(cfg *EncodeConfig) *encodeFuncConfig {
+	funcCfg := defaultEncodeFuncConfig()
+	funcCfg.format = m.format
+	funcCfg.sep = m.sep
+	funcCfg.intBase = m.base
+	funcCfg.intBasePrefix = m.basePrefix
+	funcCfg.numberFormat = cfg.NumberFormat
+	switch {
+	case m.timeLayout != "":
+		funcCfg.timeLayout = m.timeLayout
+	case m.format != "":
+		funcCfg.timeLayout = m.format
+	case cfg.TimeLayout != "":
+		funcCfg.timeLayout = cfg.TimeLayout
+	}
+	funcCfg.boolTrueText = gofn.Coalesce(m.boolTrue, cfg.BoolTrueText, funcCfg.boolTrueText)
+	funcCfg.boolFalseText = gofn.Coalesce(m.boolFalse, cfg.BoolFalseText, funcCfg.boolFalseText)
+	// Float format set via the tag option `format=` (e.g. `.2f`) overrides the global config
+	tagFloatFormat, tagFloatPrecision, _ := parseFloatFormat(m.format)
+	funcCfg.floatFormat = gofn.Coalesce(m.floatFormat, tagFloatFormat, cfg.FloatFormat, funcCfg.floatFormat)
+	funcCfg.floatPrecision = cfg.FloatPrecision
+	funcCfg.typeEncodeFuncs = cfg.TypeEncodeFuncs
+	if m.floatPrecision != nil {
+		funcCfg.floatPrecision = *m.floatPrecision
+	} else if tagFloatFormat != 0 && tagFloatPrecision != floatPrecisionInherit {
+		funcCfg.floatPrecision = tagFloatPrecision
+	}
+	return funcCfg
+}
+
 func (m *encodeColumnMeta) getColumnValue(rowVal reflect.Value) reflect.Value {
-	colVal := rowVal.Field(m.targetField.Index[0])
+	colVal := fieldByIndex(rowVal, m.targetField.Index)
+	if !colVal.IsValid() {
+		return colVal
+	}
 	if m.inlineColumnMeta != nil {
 		colVal = m.inlineColumnMeta.encodeGetColumnValue(colVal)
 	}
+	if m.rest {
+		colVal = colVal.MapIndex(reflect.ValueOf(m.headerKey))
+	}
 	return colVal
 }
@@ -0,0 +1,94 @@
+package csvlib
+
+import (
+	"context"
+	"errors"
+)
+
+// EncodeStream encode the items received from the given channel until the channel is closed.
+// The items must be of the same struct type (e.g. `Student` or `*Student`), the type is checked
+// on the first item. `nil` items are skipped. The writer is flushed every EncodeConfig.FlushInterval
+// rows and when the stream ends. When the context is done, the encoding stops after flushing the
+// encoded rows and an *Errors wrapping the context error is returned. When EncodeConfig.StopOnError is `false`, the errors
+// of PreEncodeHook are collected and returned as *Errors when the stream ends.
+func (e *Encoder) EncodeStream(ctx context.Context, ch <-chan any) error {
+	if e.finished {
+		return ErrFinished
+	}
+	if e.err != nil {
+		return ErrAlreadyFailed
+	}
+
+	rowCount, processedRows := 0, 0
+	rowErrs := NewErrors()
+	for {
+		var item any
+		var ok bool
+		select {
+		case <-ctx.Done():
+			rowErrs.Add(ctx.Err())
+			e.err = rowErrs
+			return e.flushWriterOnStop(e.err)
+		case item, ok = <-ch:
+		}
+		if !ok {
+			if err := e.flushWriterOnStop(nil); err != nil {
+				return err
+			}
+			if rowErrs.HasError() {
+				return rowErrs
+			}
+			return nil
+		}
+		processedRows++
+		if item == nil {
+			e.nextRow++
+			e.callOnRowEncoded(processedRows, -1)
+			continue
+		}
+		err := e.EncodeOneContext(ctx, item)
+		e.callOnRowEncoded(processedRows, -1)
+		if err != nil {
+			var itemErrs *Errors
+			if !errors.As(err, &itemErrs) {
+				return e.flushWriterOnStop(err)
+			}
+			rowErrs.Add(itemErrs.Unwrap()...)
+			// The context is done, the errors collected so far are returned together with the context error
+			if e.err != nil {
+				e.err = rowErrs
+				return e.flushWriterOnStop(e.err)
+			}
+		}
+		rowCount++
+		if e.cfg.FlushInterval > 0 && rowCount%e.cfg.FlushInterval == 0 {
+			if err := e.flushWriter(); err != nil {
+				e.err = err
+				return err
+			}
+		}
+	}
+}
+
+// flushWriterOnStop flush the writer when the stream stops, the given error takes precedence
+// over the flushing error
+func (e *Encoder) flushWriterOnStop(err error) error {
+	if flushErr := e.flushWriter(); err == nil && flushErr != nil {
+		e.err = flushErr
+		return flushErr
+	}
+	return err
+}
+
+// flushWriter flush the writer if it supports flushing (e.g. csv.Writer)
+func (e *Encoder) flushWriter() error {
+	w, ok := e.w.(interface {
+		Flush()
+		Error() error
+	})
+	if !ok {
+		return nil
+	}
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,152 @@
+package csvlib
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tiendc/gofn"
+)
+
+func Test_EncodeStream(t *testing.T) {
+	type Item struct {
+		Col1 int    `csv:"col1"`
+		Col2 string `csv:"col2"`
+	}
+	type Item2 struct {
+		Col1 int `csv:"col1"`
+	}
+
+	t.Run("#1: flush when the channel is closed", func(t *testing.T) {
+		ch := make(chan any, 3)
+		ch <- Item{Col1: 1, Col2: "a"}
+		ch <- nil
+		ch <- Item{Col1: 2, Col2: "b"}
+		close(ch)
+
+		e, _, buf := makeEncoder()
+		err := e.EncodeStream(context.Background(), ch)
+		assert.Nil(t, err)
+		assert.Equal(t, gofn.MultilineString(
+			`col1,col2
+			1,a
+			2,b
+			`), buf.String())
+	})
+
+	t.Run("#2: flush periodically", func(t *testing.T) {
+		ch := make(chan any)
+		e, _, buf := makeEncoder(func(cfg *EncodeConfig) {
+			cfg.FlushInterval = 2
+		})
+		done := make(chan error)
+		go func() {
+			done <- e.EncodeStream(context.Background(), ch)
+		}()
+
+		ch <- &Item{Col1: 1, Col2: "a"}
+		ch <- &Item{Col1: 2, Col2: "b"}
+		ch <- &Item{Col1: 3, Col2: "c"}
+		// The 3rd item is received after the first 2 rows are flushed
+		assert.Equal(t, gofn.MultilineString(
+			`col1,col2
+			1,a
+			2,b
+			`), buf.String())
+
+		close(ch)
+		assert.Nil(t, <-done)
+		assert.Equal(t, gofn.MultilineString(
+			`col1,col2
+			1,a
+			2,b
+			3,c
+			`), buf.String())
+	})
+
+	t.Run("#3: context canceled in the middle of the stream", func(t *testing.T) {
+		ch := make(chan any)
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			ch <- Item{Col1: 1, Col2: "a"}
+			ch <- Item{Col1: 2, Col2: "b"}
+			cancel()
+		}()
+
+		e, _, buf := makeEncoder()
+		err := e.EncodeStream(ctx, ch)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.IsType(t, &Errors{}, err)
+		assert.Equal(t, gofn.MultilineString(
+			`col1,col2
+			1,a
+			2,b
+			`), buf.String())
+		assert.ErrorIs(t, e.EncodeStream(context.Background(), ch), ErrAlreadyFailed)
+	})
+
+	t.Run("#4: item type unmatched", func(t *testing.T) {
+		ch := make(chan any, 2)
+		ch <- Item{Col1: 1, Col2: "a"}
+		ch <- Item2{Col1: 2}
+		close(ch)
+
+		e, _, buf := makeEncoder()
+		err := e.EncodeStream(context.Background(), ch)
+		assert.ErrorIs(t, err, ErrTypeUnmatched)
+		assert.Equal(t, gofn.MultilineString(
+			`col1,col2
+			1,a
+			`), buf.String())
+	})
+
+	t.Run("#5: item type invalid", func(t *testing.T) {
+		ch := make(chan any, 1)
+		ch <- "abc"
+		close(ch)
+
+		e, _, _ := makeEncoder()
+		err := e.EncodeStream(context.Background(), ch)
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+	})
+
+	t.Run("#6: hook errors collected with StopOnError = false", func(t *testing.T) {
+		ch := make(chan any, 4)
+		ch <- &preEncodeHookItem{FirstName: "John", Age: 30}
+		ch <- nil
+		ch <- &preEncodeHookItem{Age: 20}
+		ch <- &preEncodeHookItem{FirstName: "Jane", Age: 25}
+		close(ch)
+
+		e, _, buf := makeEncoder(func(cfg *EncodeConfig) {
+			cfg.StopOnError = false
+		})
+		err := e.EncodeStream(context.Background(), ch)
+		assert.ErrorIs(t, err, errPreEncode)
+		assert.Equal(t, 3, err.(*Errors).Unwrap()[0].(*RowErrors).Row()) // nolint: errorlint
+		assert.Equal(t, gofn.MultilineString(
+			`full_name,age
+			John,30
+			Jane,25
+			`), buf.String())
+	})
+
+	t.Run("#7: callback for every item", func(t *testing.T) {
+		ch := make(chan any, 3)
+		ch <- Item{Col1: 1, Col2: "a"}
+		ch <- nil
+		ch <- Item{Col1: 2, Col2: "b"}
+		close(ch)
+
+		var rows []int
+		e, _, _ := makeEncoder(func(cfg *EncodeConfig) {
+			cfg.OnRowEncoded = func(row int, total int) {
+				assert.Equal(t, -1, total)
+				rows = append(rows, row)
+			}
+		})
+		err := e.EncodeStream(context.Background(), ch)
+		assert.Nil(t, err)
+		assert.Equal(t, []int{1, 2, 3}, rows)
+	})
+}
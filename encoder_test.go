@@ -2,8 +2,15 @@ package csvlib
 
 import (
 	"bytes"
+	"context"
+	"database/sql"
 	"encoding/csv"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/tiendc/gofn"
@@ -629,6 +636,54 @@ func Test_Encode_withLocalization(t *testing.T) {
 	})
 }
 
+func Test_Encode_withHeaderText(t *testing.T) {
+	type Item struct {
+		Col1 int    `csv:"col1"`
+		ID   string `csv:"internal_id"`
+	}
+	v := []Item{{Col1: 1, ID: "abc"}}
+
+	t.Run("#1: header text of column", func(t *testing.T) {
+		data, err := doEncode(v, func(cfg *EncodeConfig) {
+			cfg.ConfigureColumn("internal_id", func(cfg *EncodeColumnConfig) {
+				cfg.HeaderText = "Customer ID"
+			})
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, gofn.MultilineString(
+			`col1,Customer ID
+			1,abc
+			`), string(data))
+	})
+
+	t.Run("#2: localization is bypassed", func(t *testing.T) {
+		data, err := doEncode(v, func(cfg *EncodeConfig) {
+			cfg.LocalizeHeader = true
+			cfg.LocalizationFunc = localizeEnUs // no translation for `internal_id`
+			cfg.ConfigureColumn("internal_id", func(cfg *EncodeColumnConfig) {
+				cfg.HeaderText = "Customer ID"
+			})
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, gofn.MultilineString(
+			`col-1,Customer ID
+			1,abc
+			`), string(data))
+	})
+
+	t.Run("#3: no tag option for header text", func(t *testing.T) {
+		type Item struct {
+			ID string `csv:"internal_id,header=Customer ID"`
+		}
+		data, err := doEncode([]Item{{ID: "abc"}})
+		assert.Nil(t, err)
+		assert.Equal(t, gofn.MultilineString(
+			`internal_id
+			abc
+			`), string(data))
+	})
+}
+
 func Test_Encode_withCustomMarshaler(t *testing.T) {
 	t.Run("#1: no encode func matching", func(t *testing.T) {
 		type Item struct {
@@ -851,6 +906,933 @@ func Test_Encode_specialTypes(t *testing.T) {
 	})
 }
 
+func Test_Encode_withColumnOrder(t *testing.T) {
+	type Marks struct {
+		Math      int `csv:"math"`
+		Chemistry int `csv:"chemistry"`
+	}
+	type Item struct {
+		Col1  int    `csv:"col1"`
+		Col2  string `csv:"col2"`
+		Col3  bool   `csv:"col3"`
+		Marks Marks  `csv:"marks,inline,prefix=mark_"`
+	}
+	v := []Item{
+		{Col1: 1, Col2: "a", Col3: true, Marks: Marks{Math: 9, Chemistry: 8}},
+	}
+
+	t.Run("#1: partial order", func(t *testing.T) {
+		data, err := doEncode(v, func(cfg *EncodeConfig) {
+			cfg.ColumnOrder = []string{"col3", "mark_chemistry", "col1"}
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, gofn.MultilineString(
+			`col3,mark_chemistry,col1,col2,mark_math
+			true,8,1,a,9
+			`), string(data))
+	})
+
+	t.Run("#2: strict order with inline column name", func(t *testing.T) {
+		data, err := doEncode(v, func(cfg *EncodeConfig) {
+			cfg.ColumnOrder = []string{"marks", "col2"}
+			cfg.StrictColumnOrder = true
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, gofn.MultilineString(
+			`mark_math,mark_chemistry,col2
+			9,8,a
+			`), string(data))
+	})
+
+	t.Run("#3: duplicated names in column order", func(t *testing.T) {
+		_, err := doEncode(v, func(cfg *EncodeConfig) {
+			cfg.ColumnOrder = []string{"col2", "col1", "col2"}
+		})
+		assert.ErrorIs(t, err, ErrConfigOptionInvalid)
+
+		_, err = doEncode(v, func(cfg *EncodeConfig) {
+			cfg.ColumnOrder = []string{"mark_math", "marks"}
+		})
+		assert.ErrorIs(t, err, ErrConfigOptionInvalid)
+	})
+
+	t.Run("#4: name not found", func(t *testing.T) {
+		_, err := doEncode(v, func(cfg *EncodeConfig) {
+			cfg.ColumnOrder = []string{"col2", "colX"}
+		})
+		assert.ErrorIs(t, err, ErrConfigOptionInvalid)
+	})
+
+	t.Run("#5: with localized header", func(t *testing.T) {
+		data, err := doEncode(v, func(cfg *EncodeConfig) {
+			cfg.LocalizeHeader = true
+			cfg.LocalizationFunc = func(k string, params ParameterMap) (string, error) {
+				return strings.ToUpper(k), nil
+			}
+			cfg.ColumnOrder = []string{"col2", "col1"}
+			cfg.StrictColumnOrder = true
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, gofn.MultilineString(
+			`COL2,COL1
+			a,1
+			`), string(data))
+	})
+
+	t.Run("#6: dynamic inline columns moved together", func(t *testing.T) {
+		type Item struct {
+			Col1  int               `csv:"col1"`
+			Marks InlineColumn[int] `csv:"marks,inline"`
+			Col2  string            `csv:"col2"`
+		}
+		v := []Item{
+			{Col1: 1, Col2: "a", Marks: InlineColumn[int]{Header: []string{"math", "physics"}, Values: []int{9, 7}}},
+		}
+		data, err := doEncode(v, func(cfg *EncodeConfig) {
+			cfg.ColumnOrder = []string{"col2", "marks"}
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, gofn.MultilineString(
+			`col2,math,physics,col1
+			a,9,7,1
+			`), string(data))
+	})
+}
+
+func Test_Encode_withTime(t *testing.T) {
+	type Item struct {
+		Col1 time.Time  `csv:"col1"`
+		Col2 *time.Time `csv:"col2"`
+		Col3 time.Time  `csv:"col3,omitempty"`
+	}
+	t1 := time.Date(2020, 1, 2, 10, 20, 30, 500, time.FixedZone("", 7*3600))
+
+	t.Run("#1: default layout", func(t *testing.T) {
+		v := []Item{
+			{Col1: t1, Col2: &t1, Col3: t1},
+			{},
+		}
+		data, err := doEncode(v)
+		assert.Nil(t, err)
+		assert.Equal(t, gofn.MultilineString(
+			`col1,col2,col3
+			2020-01-02T10:20:30.0000005+07:00,2020-01-02T10:20:30.0000005+07:00,2020-01-02T10:20:30.0000005+07:00
+			0001-01-01T00:00:00Z,,
+			`), string(data))
+	})
+
+	t.Run("#2: global and column layouts", func(t *testing.T) {
+		v := []Item{
+			{Col1: t1, Col2: &t1, Col3: t1},
+		}
+		data, err := doEncode(v, func(cfg *EncodeConfig) {
+			cfg.TimeLayout = "2006-01-02"
+			cfg.ConfigureColumn("col3", func(cfg *EncodeColumnConfig) {
+				cfg.TimeLayout = "2006-01-02 15:04:05 -0700"
+			})
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, gofn.MultilineString(
+			`col1,col2,col3
+			2020-01-02,2020-01-02,2020-01-02 10:20:30 +0700
+			`), string(data))
+	})
+
+	t.Run("#3: time in interface field", func(t *testing.T) {
+		type Item struct {
+			Col1 any `csv:"col1"`
+		}
+		data, err := doEncode([]Item{{Col1: t1}}, func(cfg *EncodeConfig) {
+			cfg.TimeLayout = "2006-01-02"
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, "col1\n2020-01-02\n", string(data))
+	})
+
+	t.Run("#4: format tag", func(t *testing.T) {
+		type Item struct {
+			Col1 time.Time  `csv:"col1,format=02/01/2006"`
+			Col2 *time.Time `csv:"col2,format=15:04"`
+			Col3 time.Time  `csv:"col3,format=2006"`
+		}
+		data, err := doEncode([]Item{{Col1: t1, Col2: &t1, Col3: t1}}, func(cfg *EncodeConfig) {
+			cfg.TimeLayout = "2006-01-02"
+			cfg.ConfigureColumn("col3", func(cfg *EncodeColumnConfig) {
+				cfg.TimeLayout = "Jan 2006"
+			})
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, "col1,col2,col3\n02/01/2020,10:20,Jan 2020\n", string(data))
+	})
+}
+
+func Test_Encode_withTypeEncodeFuncs(t *testing.T) {
+	type Item struct {
+		Col1 time.Time  `csv:"col1"`
+		Col2 *time.Time `csv:"col2"`
+		Col3 time.Time  `csv:"col3"`
+	}
+	encodeUnixTime := func(v reflect.Value, _ bool) (string, error) {
+		return strconv.FormatInt(v.Interface().(time.Time).Unix(), 10), nil // nolint: forcetypeassert
+	}
+	v := []Item{
+		{Col1: time.Unix(60, 0), Col2: gofn.New(time.Unix(120, 0)), Col3: time.Unix(180, 0)},
+		{Col1: time.Unix(0, 0), Col3: time.Unix(1, 0)},
+	}
+
+	t.Run("#1: all fields of the type", func(t *testing.T) {
+		data, err := doEncode(v, func(cfg *EncodeConfig) {
+			cfg.TypeEncodeFuncs = map[reflect.Type]EncodeFunc{timeType: encodeUnixTime}
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, gofn.MultilineString(
+			`col1,col2,col3
+			60,120,180
+			0,,1
+			`), string(data))
+	})
+
+	t.Run("#2: column encode func takes precedence", func(t *testing.T) {
+		data, err := doEncode(v, func(cfg *EncodeConfig) {
+			cfg.TypeEncodeFuncs = map[reflect.Type]EncodeFunc{timeType: encodeUnixTime}
+			cfg.ConfigureColumn("col3", func(cfg *EncodeColumnConfig) {
+				cfg.EncodeFunc = func(v reflect.Value, _ bool) (string, error) {
+					return "x", nil
+				}
+			})
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, gofn.MultilineString(
+			`col1,col2,col3
+			60,120,x
+			0,,x
+			`), string(data))
+	})
+}
+
+func Test_Encode_withDefaultValue(t *testing.T) {
+	type Item struct {
+		Col1 int    `csv:"col1,omitempty,default=0"`
+		Col2 string `csv:"col2,omitempty,default=not set"`
+		Col3 *int   `csv:"col3,omitempty,default=-"`
+		Col4 string `csv:"col4,default=abc"`
+		Col5 string `csv:"col5,omitempty,default="`
+	}
+	v := []Item{
+		{Col1: 1, Col2: "x", Col3: gofn.New(3), Col4: "y", Col5: "z"},
+		{},
+	}
+	data, err := doEncode(v)
+	assert.Nil(t, err)
+	assert.Equal(t, gofn.MultilineString(
+		`col1,col2,col3,col4,col5
+		1,x,3,y,z
+		0,not set,-,,
+		`), string(data))
+}
+
+func Test_Encode_withDuration(t *testing.T) {
+	t.Run("#1: success", func(t *testing.T) {
+		type Item struct {
+			Col1 time.Duration  `csv:"col1"`
+			Col2 *time.Duration `csv:"col2"`
+			Col3 time.Duration  `csv:"col3,omitempty,format=seconds"`
+		}
+		v := []Item{
+			{Col1: 90 * time.Minute, Col2: gofn.New(250 * time.Millisecond), Col3: 1500 * time.Millisecond},
+			{},
+		}
+		data, err := doEncode(v)
+		assert.Nil(t, err)
+		assert.Equal(t, gofn.MultilineString(
+			`col1,col2,col3
+			1h30m0s,250ms,1.5
+			0s,,
+			`), string(data))
+	})
+
+	t.Run("#2: invalid format", func(t *testing.T) {
+		type Item struct {
+			Col1 time.Duration `csv:"col1,format=minutes"`
+		}
+		_, err := doEncode([]Item{{Col1: time.Second}})
+		assert.ErrorIs(t, err, ErrTagOptionInvalid)
+	})
+}
+
+func Test_Encode_withSQLNullTypes(t *testing.T) {
+	type Item struct {
+		Col1 sql.NullString  `csv:"col1"`
+		Col2 sql.NullInt64   `csv:"col2"`
+		Col3 sql.NullInt32   `csv:"col3"`
+		Col4 sql.NullFloat64 `csv:"col4"`
+		Col5 sql.NullBool    `csv:"col5"`
+		Col6 *sql.NullInt64  `csv:"col6"`
+		Col7 sql.NullInt64   `csv:"col7,omitempty"`
+	}
+	v := []Item{
+		{
+			Col1: sql.NullString{String: "abc", Valid: true},
+			Col2: sql.NullInt64{Int64: 0, Valid: true},
+			Col3: sql.NullInt32{Int32: -12, Valid: true},
+			Col4: sql.NullFloat64{Float64: 1.5, Valid: true},
+			Col5: sql.NullBool{Bool: false, Valid: true},
+			Col6: &sql.NullInt64{Int64: 7, Valid: true},
+			Col7: sql.NullInt64{Int64: 0, Valid: true},
+		},
+		{
+			Col2: sql.NullInt64{Int64: 100, Valid: false},
+			Col6: &sql.NullInt64{},
+		},
+	}
+
+	t.Run("#1: success", func(t *testing.T) {
+		data, err := doEncode(v)
+		assert.Nil(t, err)
+		assert.Equal(t, gofn.MultilineString(
+			`col1,col2,col3,col4,col5,col6,col7
+			abc,0,-12,1.5,false,7,
+			,,,,,,
+			`), string(data))
+	})
+
+	t.Run("#2: round trip", func(t *testing.T) {
+		data, err := Marshal(v)
+		assert.Nil(t, err)
+		var v2 []Item
+		_, err = Unmarshal(data, &v2)
+		assert.Nil(t, err)
+		expected := append([]Item{}, v...)
+		// Col7 is omitted when encoding as its value is zero, values of invalid items are not kept
+		expected[0].Col7 = sql.NullInt64{}
+		expected[1].Col2 = sql.NullInt64{}
+		assert.Equal(t, expected, v2)
+	})
+}
+
+func Test_Encode_withBoolText(t *testing.T) {
+	type Item struct {
+		Col1 bool  `csv:"col1"`
+		Col2 *bool `csv:"col2"`
+		Col3 bool  `csv:"col3,omitempty"`
+	}
+	v := []Item{
+		{Col1: true, Col2: gofn.New(false), Col3: true},
+		{Col1: false, Col3: false},
+	}
+
+	t.Run("#1: default text", func(t *testing.T) {
+		data, err := doEncode(v)
+		assert.Nil(t, err)
+		assert.Equal(t, gofn.MultilineString(
+			`col1,col2,col3
+			true,false,true
+			false,,
+			`), string(data))
+	})
+
+	t.Run("#2: custom text with column overrides", func(t *testing.T) {
+		data, err := doEncode(v, func(cfg *EncodeConfig) {
+			cfg.BoolTrueText = "yes"
+			cfg.BoolFalseText = "no"
+			cfg.ConfigureColumn("col2", func(cfg *EncodeColumnConfig) {
+				cfg.BoolFalseText = "N"
+			})
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, gofn.MultilineString(
+			`col1,col2,col3
+			yes,N,yes
+			no,,
+			`), string(data))
+	})
+}
+
+func Test_Encode_withIntBase(t *testing.T) {
+	type Item struct {
+		Col1 int    `csv:"col1,base=16"`
+		Col2 *uint8 `csv:"col2,base=2"`
+		Col3 int64  `csv:"col3,base=0"`
+		Col4 []uint `csv:"col4,sep=;,base=8"`
+		Col5 int    `csv:"col5,base=36,omitempty"`
+	}
+	v := []Item{
+		{Col1: 31, Col2: gofn.New[uint8](5), Col3: 31, Col4: []uint{493, 15}, Col5: 35},
+		{Col1: -255, Col3: -3},
+	}
+
+	t.Run("#1: without prefix", func(t *testing.T) {
+		data, err := doEncode(v)
+		assert.Nil(t, err)
+		assert.Equal(t, gofn.MultilineString(
+			`col1,col2,col3,col4,col5
+			1f,101,31,755;17,z
+			-ff,,-3,,
+			`), string(data))
+	})
+
+	t.Run("#2: with prefix", func(t *testing.T) {
+		data, err := doEncode(v, func(cfg *EncodeConfig) {
+			for _, col := range []string{"col1", "col2", "col3", "col4", "col5"} {
+				cfg.ConfigureColumn(col, func(cfg *EncodeColumnConfig) {
+					cfg.BasePrefix = true
+				})
+			}
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, gofn.MultilineString(
+			`col1,col2,col3,col4,col5
+			0x1f,0b101,31,0o755;0o17,z
+			-0xff,,-3,,
+			`), string(data))
+	})
+
+	t.Run("#3: base tag on non-integer column", func(t *testing.T) {
+		type Item struct {
+			Col1 string `csv:"col1,base=16"`
+		}
+		_, err := doEncode([]Item{{Col1: "a"}})
+		assert.ErrorIs(t, err, ErrTagOptionInvalid)
+	})
+}
+
+func Test_Encode_withNullRepresentation(t *testing.T) {
+	type Item struct {
+		Col1 *int    `csv:"col1"`
+		Col2 *string `csv:"col2"`
+		Col3 any     `csv:"col3"`
+		Col4 *int    `csv:"col4,omitempty"`
+	}
+	v := []Item{
+		{},
+		{Col1: gofn.New(0), Col2: gofn.New(""), Col3: 0, Col4: gofn.New(0)},
+		{Col3: (*int)(nil)},
+	}
+
+	t.Run("#1: empty by default", func(t *testing.T) {
+		data, err := doEncode(v)
+		assert.Nil(t, err)
+		assert.Equal(t, gofn.MultilineString(
+			`col1,col2,col3,col4
+			,,,
+			0,,0,
+			,,,
+			`), string(data))
+	})
+
+	t.Run("#2: null representation", func(t *testing.T) {
+		data, err := doEncode(v, func(cfg *EncodeConfig) {
+			cfg.NullRepresentation = "NULL"
+		})
+		assert.Nil(t, err)
+		// Non-nil zero values of omitempty columns are still empty
+		assert.Equal(t, gofn.MultilineString(
+			`col1,col2,col3,col4
+			NULL,NULL,NULL,NULL
+			0,,0,
+			NULL,NULL,NULL,NULL
+			`), string(data))
+	})
+
+	t.Run("#3: null representation of column", func(t *testing.T) {
+		data, err := doEncode(v, func(cfg *EncodeConfig) {
+			cfg.NullRepresentation = "NULL"
+			cfg.ConfigureColumn("col2", func(cfg *EncodeColumnConfig) {
+				cfg.NullRepresentation = `\N`
+			})
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, gofn.MultilineString(
+			`col1,col2,col3,col4
+			NULL,\N,NULL,NULL
+			0,,0,
+			NULL,\N,NULL,NULL
+			`), string(data))
+	})
+}
+
+func Test_Encode_withNumberFormat(t *testing.T) {
+	type Item struct {
+		Col1 int      `csv:"col1"`
+		Col2 *float64 `csv:"col2"`
+		Col3 uint     `csv:"col3,omitempty"`
+		Col4 string   `csv:"col4"`
+	}
+	v := []Item{
+		{Col1: -1234, Col2: gofn.New(1234567.5), Col3: 1000, Col4: "1234.5"},
+		{Col1: 12, Col2: gofn.New(0.25)},
+	}
+
+	t.Run("#1: group by comma", func(t *testing.T) {
+		data, err := doEncode(v, func(cfg *EncodeConfig) {
+			cfg.NumberFormat = &NumberFormat{GroupSeparator: ','}
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, gofn.MultilineString(
+			`col1,col2,col3,col4
+			"-1,234","1,234,567.5","1,000",1234.5
+			12,0.25,,
+			`), string(data))
+	})
+
+	t.Run("#2: european format", func(t *testing.T) {
+		data, err := doEncode(v, func(cfg *EncodeConfig) {
+			cfg.NumberFormat = &NumberFormat{GroupSeparator: '.', DecimalSeparator: ','}
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, gofn.MultilineString(
+			`col1,col2,col3,col4
+			-1.234,"1.234.567,5",1.000,1234.5
+			12,"0,25",,
+			`), string(data))
+	})
+
+	t.Run("#3: invalid config", func(t *testing.T) {
+		_, err := doEncode(v, func(cfg *EncodeConfig) {
+			cfg.NumberFormat = &NumberFormat{GroupSeparator: ',', DecimalSeparator: ','}
+		})
+		assert.ErrorIs(t, err, ErrConfigOptionInvalid)
+	})
+}
+
+func Test_Encode_withFloatPrecision(t *testing.T) {
+	type Item struct {
+		Col1 float64  `csv:"col1"`
+		Col2 *float32 `csv:"col2"`
+		Col3 float64  `csv:"col3,omitempty"`
+	}
+	v := []Item{
+		{Col1: 1.2345678901234567, Col2: gofn.New(float32(0.5)), Col3: 1234.5678},
+		{Col1: 0, Col3: 0},
+	}
+
+	t.Run("#1: default precision", func(t *testing.T) {
+		data, err := doEncode(v)
+		assert.Nil(t, err)
+		assert.Equal(t, gofn.MultilineString(
+			`col1,col2,col3
+			1.2345678901234567,0.5,1234.5678
+			0,,
+			`), string(data))
+	})
+
+	t.Run("#2: global precision", func(t *testing.T) {
+		data, err := doEncode(v, func(cfg *EncodeConfig) {
+			cfg.FloatPrecision = 2
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, gofn.MultilineString(
+			`col1,col2,col3
+			1.23,0.50,1234.57
+			0.00,,
+			`), string(data))
+	})
+
+	t.Run("#3: column precision shadows the global one", func(t *testing.T) {
+		data, err := doEncode(v, func(cfg *EncodeConfig) {
+			cfg.FloatPrecision = 2
+			cfg.ConfigureColumn("col1", func(cfg *EncodeColumnConfig) {
+				cfg.FloatPrecision = -1
+			})
+			cfg.ConfigureColumn("col2", func(cfg *EncodeColumnConfig) {
+				cfg.FloatPrecision = 0
+			})
+			cfg.ConfigureColumn("col3", func(cfg *EncodeColumnConfig) {
+				cfg.BasePrefix = false // FloatPrecision is not set, the global one is used
+			})
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, gofn.MultilineString(
+			`col1,col2,col3
+			1.2345678901234567,0,1234.57
+			0,,
+			`), string(data))
+	})
+
+	t.Run("#4: scientific format", func(t *testing.T) {
+		data, err := doEncode(v, func(cfg *EncodeConfig) {
+			cfg.FloatFormat = 'e'
+			cfg.FloatPrecision = 3
+			cfg.ConfigureColumn("col3", func(cfg *EncodeColumnConfig) {
+				cfg.FloatFormat = 'g'
+				cfg.FloatPrecision = -1
+			})
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, gofn.MultilineString(
+			`col1,col2,col3
+			1.235e+00,5.000e-01,1234.5678
+			0.000e+00,,
+			`), string(data))
+	})
+
+	t.Run("#5: invalid config", func(t *testing.T) {
+		_, err := doEncode(v, func(cfg *EncodeConfig) {
+			cfg.FloatFormat = 'x'
+		})
+		assert.ErrorIs(t, err, ErrConfigOptionInvalid)
+
+		_, err = doEncode(v, func(cfg *EncodeConfig) {
+			cfg.FloatPrecision = -2
+		})
+		assert.ErrorIs(t, err, ErrConfigOptionInvalid)
+
+		_, err = doEncode(v, func(cfg *EncodeConfig) {
+			cfg.ConfigureColumn("col1", func(cfg *EncodeColumnConfig) {
+				cfg.FloatFormat = 'b'
+			})
+		})
+		assert.ErrorIs(t, err, ErrConfigOptionInvalid)
+	})
+
+	t.Run("#6: format tag", func(t *testing.T) {
+		type Item struct {
+			Col1 float64  `csv:"col1,format=.2f"`
+			Col2 *float32 `csv:"col2,format=e"`
+			Col3 float64  `csv:"col3,format=.1f"`
+		}
+		data, err := doEncode([]Item{{Col1: 1.2345, Col2: gofn.New(float32(0.5)), Col3: 1234.5678}, {}},
+			func(cfg *EncodeConfig) {
+				cfg.FloatPrecision = 3
+				cfg.ConfigureColumn("col3", func(cfg *EncodeColumnConfig) {
+					cfg.FloatPrecision = 0
+				})
+			})
+		assert.Nil(t, err)
+		assert.Equal(t, gofn.MultilineString(
+			`col1,col2,col3
+			1.23,5.000e-01,1235
+			0.00,,0
+			`), string(data))
+	})
+
+	t.Run("#7: format tag invalid", func(t *testing.T) {
+		type Item struct {
+			Col1 float64 `csv:"col1,format=2f"`
+		}
+		_, err := doEncode([]Item{{}})
+		assert.ErrorIs(t, err, ErrTagOptionInvalid)
+
+		type Item2 struct {
+			Col1 int `csv:"col1,format=.2f"`
+		}
+		_, err = doEncode([]Item2{{}})
+		assert.ErrorIs(t, err, ErrTagOptionInvalid)
+	})
+}
+
+func Test_Encode_withSliceSeparator(t *testing.T) {
+	t.Run("#1: success", func(t *testing.T) {
+		type Item struct {
+			Col1 []string        `csv:"col1,sep=;"`
+			Col2 []int           `csv:"col2,sep=|"`
+			Col3 []time.Duration `csv:"col3,sep=;,format=seconds"`
+		}
+		v := []Item{
+			{Col1: []string{"red", "blue"}, Col2: []int{1, 0, 2}, Col3: []time.Duration{time.Second, time.Minute}},
+			{},
+		}
+		data, err := doEncode(v)
+		assert.Nil(t, err)
+		assert.Equal(t, gofn.MultilineString(
+			`col1,col2,col3
+			red;blue,1|0|2,1;60
+			,,
+			`), string(data))
+	})
+
+	t.Run("#2: sep tag on non-slice field", func(t *testing.T) {
+		type Item struct {
+			Col1 string `csv:"col1,sep=;"`
+		}
+		_, err := doEncode([]Item{{Col1: "abc"}})
+		assert.ErrorIs(t, err, ErrTagOptionInvalid)
+	})
+}
+
+func Test_Encode_withEmbeddedStruct(t *testing.T) {
+	type Audit struct {
+		CreatedBy string `csv:"created_by"`
+		UpdatedBy string `csv:"updated_by,omitempty"`
+	}
+	type Base struct {
+		ID int `csv:"id"`
+		Audit
+	}
+
+	t.Run("#1: success", func(t *testing.T) {
+		type Item struct {
+			Base
+			Name string `csv:"name"`
+		}
+		v := []Item{
+			{Base: Base{ID: 1, Audit: Audit{CreatedBy: "tom", UpdatedBy: "jerry"}}, Name: "abc"},
+			{Base: Base{ID: 2}, Name: "def"},
+		}
+		data, err := doEncode(v, func(cfg *EncodeConfig) {
+			cfg.ConfigureColumn("created_by", func(cfg *EncodeColumnConfig) {
+				cfg.PostprocessorFuncs = []ProcessorFunc{strings.ToUpper}
+			})
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, gofn.MultilineString(
+			`id,created_by,updated_by,name
+			1,TOM,jerry,abc
+			2,,,def
+			`), string(data))
+	})
+
+	t.Run("#2: embedded pointer with prefix and omitempty", func(t *testing.T) {
+		type Item struct {
+			Name   string `csv:"name"`
+			*Audit `csv:",omitempty,prefix=audit_"`
+		}
+		v := []Item{
+			{Name: "abc", Audit: &Audit{CreatedBy: "tom"}},
+			{Name: "def"},
+		}
+		data, err := doEncode(v)
+		assert.Nil(t, err)
+		assert.Equal(t, gofn.MultilineString(
+			`name,audit_created_by,audit_updated_by
+			abc,tom,
+			def,,
+			`), string(data))
+	})
+
+	t.Run("#3: deep field with pointer intermediate", func(t *testing.T) {
+		type Level3 struct {
+			Code string `csv:"code"`
+		}
+		type Level2 struct {
+			*Level3
+		}
+		type Item struct {
+			Name string `csv:"name"`
+			Level2
+		}
+		v := []Item{
+			{Name: "abc", Level2: Level2{Level3: &Level3{Code: "x1"}}},
+			{Name: "def"},
+		}
+		data, err := doEncode(v)
+		assert.Nil(t, err)
+		assert.Equal(t, "name,code\nabc,x1\ndef,\n", string(data))
+	})
+}
+
+func Test_Encode_withRestColumn(t *testing.T) {
+	type Item struct {
+		Col1 int               `csv:"col1"`
+		Rest map[string]string `csv:",rest"`
+		Col2 string            `csv:"col2"`
+	}
+	v := []*Item{
+		{Col1: 1, Col2: "x", Rest: map[string]string{"extra2": "b", "extra1": "a"}},
+		nil,
+		{Col1: 2, Col2: "y", Rest: map[string]string{"extra3": "c"}},
+		{Col1: 3, Col2: "z"},
+	}
+
+	t.Run("#1: rest columns not encoded by default", func(t *testing.T) {
+		data, err := doEncode(v)
+		assert.Nil(t, err)
+		assert.Equal(t, gofn.MultilineString(
+			`col1,col2
+			1,x
+			2,y
+			3,z
+			`), string(data))
+	})
+
+	t.Run("#2: rest columns encoded in sorted order", func(t *testing.T) {
+		data, err := doEncode(v, func(cfg *EncodeConfig) {
+			cfg.EncodeRestColumns = true
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, gofn.MultilineString(
+			`col1,col2,extra1,extra2,extra3
+			1,x,a,b,
+			2,y,,,c
+			3,z,,,
+			`), string(data))
+	})
+
+	t.Run("#3: rest column with invalid type", func(t *testing.T) {
+		type Item struct {
+			Col1 int      `csv:"col1"`
+			Rest []string `csv:",rest"`
+		}
+		_, err := doEncode([]Item{{Col1: 1}})
+		assert.ErrorIs(t, err, ErrTagOptionInvalid)
+	})
+}
+
+var errPreEncode = errors.New("first name is required")
+
+type preEncodeHookItem struct {
+	FirstName string `csv:"-"`
+	LastName  string `csv:"-"`
+	FullName  string `csv:"full_name"`
+	Age       int    `csv:"age"`
+}
+
+func (item *preEncodeHookItem) PreEncode() error {
+	if item.FirstName == "" {
+		return errPreEncode
+	}
+	item.FullName = strings.TrimSpace(item.FirstName + " " + item.LastName)
+	return nil
+}
+
+type preEncodeHookValueItem struct {
+	Col1 int `csv:"col1"`
+}
+
+func (item preEncodeHookValueItem) PreEncode() error {
+	if item.Col1 < 0 {
+		return errPreEncode
+	}
+	return nil
+}
+
+func Test_Encode_withPreEncodeHook(t *testing.T) {
+	t.Run("#1: hook modifies the fields", func(t *testing.T) {
+		v := []preEncodeHookItem{
+			{FirstName: "John", LastName: "Doe", Age: 30},
+			{FirstName: "Jane", Age: 20},
+		}
+		data, err := doEncode(v)
+		assert.Nil(t, err)
+		assert.Equal(t, gofn.MultilineString(
+			`full_name,age
+			John Doe,30
+			Jane,20
+			`), string(data))
+	})
+
+	t.Run("#2: slice of pointers", func(t *testing.T) {
+		v := []*preEncodeHookItem{
+			{FirstName: "John", LastName: "Doe", Age: 30},
+			nil,
+			{FirstName: "Jane", Age: 20},
+		}
+		data, err := doEncode(v)
+		assert.Nil(t, err)
+		assert.Equal(t, gofn.MultilineString(
+			`full_name,age
+			John Doe,30
+			Jane,20
+			`), string(data))
+		assert.Equal(t, "John Doe", v[0].FullName)
+	})
+
+	t.Run("#3: hook error stops the encoding by default", func(t *testing.T) {
+		e, w, buf := makeEncoder()
+		err := e.Encode([]preEncodeHookItem{{FirstName: "John", Age: 30}, {Age: 20}, {FirstName: "Jane"}})
+		assert.ErrorIs(t, err, errPreEncode)
+		w.Flush()
+		assert.Equal(t, "full_name,age\nJohn,30\n", buf.String())
+		err = e.Encode([]preEncodeHookItem{{FirstName: "Jane"}})
+		assert.ErrorIs(t, err, ErrAlreadyFailed)
+	})
+
+	t.Run("#4: hook errors with StopOnError = false", func(t *testing.T) {
+		var rows []int
+		e, w, buf := makeEncoder(func(cfg *EncodeConfig) {
+			cfg.StopOnError = false
+			cfg.OnRowEncoded = func(row int, total int) {
+				rows = append(rows, row)
+			}
+		})
+		err := e.Encode([]*preEncodeHookItem{{FirstName: "John", Age: 30}, nil, {Age: 20}, {FirstName: "Jane"}})
+		assert.ErrorIs(t, err, errPreEncode)
+		rowErrs := err.(*Errors).Unwrap() // nolint: errorlint
+		assert.Equal(t, 1, len(rowErrs))
+		// Rows are numbered the same as in OnRowEncoded
+		assert.Equal(t, []int{1, 2, 3, 4}, rows)
+		assert.Equal(t, 3, rowErrs[0].(*RowErrors).Row()) // nolint: errorlint
+
+		// The encoder is still usable, the row numbers continue
+		err = e.EncodeOne(&preEncodeHookItem{Age: 40})
+		assert.ErrorIs(t, err, errPreEncode)
+		assert.Equal(t, 5, err.(*Errors).Unwrap()[0].(*RowErrors).Row()) // nolint: errorlint
+		err = e.EncodeOne(&preEncodeHookItem{FirstName: "Jack", Age: 50})
+		assert.Nil(t, err)
+		w.Flush()
+		assert.Equal(t, gofn.MultilineString(
+			`full_name,age
+			John,30
+			Jane,0
+			Jack,50
+			`), buf.String())
+	})
+
+	t.Run("#5: hook with value receiver", func(t *testing.T) {
+		data, err := doEncode([]preEncodeHookValueItem{{Col1: 1}, {Col1: 2}})
+		assert.Nil(t, err)
+		assert.Equal(t, "col1\n1\n2\n", string(data))
+
+		_, err = doEncode([]preEncodeHookValueItem{{Col1: 1}, {Col1: -1}})
+		assert.ErrorIs(t, err, errPreEncode)
+	})
+
+	t.Run("#6: encode one item passed by value", func(t *testing.T) {
+		e, w, buf := makeEncoder()
+		item := preEncodeHookItem{FirstName: "John", LastName: "Doe", Age: 30}
+		err := e.EncodeOne(item)
+		assert.Nil(t, err)
+		w.Flush()
+		assert.Equal(t, "full_name,age\nJohn Doe,30\n", buf.String())
+		// The hook is called on a copy of the item
+		assert.Equal(t, "", item.FullName)
+	})
+}
+
+func Test_Encode_withOnRowEncoded(t *testing.T) {
+	type Item struct {
+		Col1 int `csv:"col1"`
+	}
+	type progress struct {
+		row, total int
+	}
+
+	t.Run("#1: callback for every item", func(t *testing.T) {
+		var reports []progress
+		_, err := doEncode([]*Item{{Col1: 1}, nil, {Col1: 3}}, func(cfg *EncodeConfig) {
+			cfg.OnRowEncoded = func(row int, total int) {
+				reports = append(reports, progress{row, total})
+			}
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []progress{{1, 3}, {2, 3}, {3, 3}}, reports)
+	})
+
+	t.Run("#2: callback for items having errors", func(t *testing.T) {
+		var reports []progress
+		e, _, _ := makeEncoder(func(cfg *EncodeConfig) {
+			cfg.StopOnError = false
+			cfg.OnRowEncoded = func(row int, total int) {
+				reports = append(reports, progress{row, total})
+			}
+		})
+		err := e.Encode([]preEncodeHookItem{{FirstName: "John"}, {}, {FirstName: "Jane"}})
+		assert.ErrorIs(t, err, errPreEncode)
+		assert.Equal(t, []progress{{1, 3}, {2, 3}, {3, 3}}, reports)
+
+		// The item stopping the encoding is also reported
+		reports = nil
+		e, _, _ = makeEncoder(func(cfg *EncodeConfig) {
+			cfg.OnRowEncoded = func(row int, total int) {
+				reports = append(reports, progress{row, total})
+			}
+		})
+		err = e.Encode([]preEncodeHookItem{{FirstName: "John"}, {}, {FirstName: "Jane"}})
+		assert.ErrorIs(t, err, errPreEncode)
+		assert.Equal(t, []progress{{1, 3}, {2, 3}}, reports)
+	})
+}
+
 func Test_EncodeOne(t *testing.T) {
 	type Item struct {
 		ColY bool
@@ -904,3 +1886,76 @@ func Test_EncodeOne(t *testing.T) {
 		assert.ErrorIs(t, err, ErrFinished)
 	})
 }
+
+func Test_EncodeContext(t *testing.T) {
+	type Item struct {
+		Col1 int     `csv:"col1"`
+		Col2 float32 `csv:"col2"`
+	}
+
+	t.Run("#1: context canceled before encoding", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		e, w, buf := makeEncoder()
+		err := e.EncodeContext(ctx, []Item{{Col1: 1, Col2: 1.1}})
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.IsType(t, &Errors{}, err)
+		w.Flush()
+		assert.Equal(t, "col1,col2\n", buf.String())
+		err = e.EncodeContext(context.Background(), []Item{{Col1: 1, Col2: 1.1}})
+		assert.ErrorIs(t, err, ErrAlreadyFailed)
+		assert.ErrorIs(t, e.Finish(), context.Canceled)
+	})
+
+	t.Run("#2: encode one with canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		e, w, buf := makeEncoder()
+		err := e.EncodeOneContext(ctx, Item{Col1: 1, Col2: 1.1})
+		assert.Nil(t, err)
+		cancel()
+		err = e.EncodeOneContext(ctx, Item{Col1: 2, Col2: 2.2})
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.IsType(t, &Errors{}, err)
+		err = e.EncodeOne(Item{Col1: 2, Col2: 2.2})
+		assert.ErrorIs(t, err, ErrAlreadyFailed)
+		assert.ErrorIs(t, e.Finish(), context.Canceled)
+		w.Flush()
+		assert.Equal(t, "col1,col2\n1,1.1\n", buf.String())
+	})
+}
+
+var errWrite = errors.New("write failed")
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) { return 0, errWrite }
+
+func Test_NewEncoderToWriter(t *testing.T) {
+	type Item struct {
+		Col1 int    `csv:"col1"`
+		Col2 string `csv:"col2"`
+	}
+
+	t.Run("#1: data are flushed on finish", func(t *testing.T) {
+		var buf bytes.Buffer
+		e := NewEncoderToWriter(&buf, func(cfg *EncodeConfig) {
+			cfg.Comma = ';'
+		})
+		assert.Nil(t, e.Encode([]Item{{Col1: 1, Col2: "a"}}))
+		assert.Nil(t, e.EncodeOne(Item{Col1: 2, Col2: "b"}))
+		assert.Equal(t, "", buf.String())
+		assert.Nil(t, e.Finish())
+		assert.Equal(t, gofn.MultilineString(
+			`col1;col2
+			1;a
+			2;b
+			`), buf.String())
+		assert.ErrorIs(t, e.Encode([]Item{}), ErrFinished)
+	})
+
+	t.Run("#2: flush error", func(t *testing.T) {
+		e := NewEncoderToWriter(errWriter{})
+		assert.Nil(t, e.Encode([]Item{{Col1: 1, Col2: "a"}}))
+		assert.ErrorIs(t, e.Finish(), errWrite)
+	})
+}
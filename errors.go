@@ -29,30 +29,46 @@ var (
 	ErrHeaderDynamicNotAllowUnrecognizedColumns = errors.New("ErrHeaderDynamicNotAllowUnrecognizedColumns")
 	ErrHeaderDynamicNotAllowLocalizedHeader     = errors.New("ErrHeaderDynamicNotAllowLocalizedHeader")
 
-	ErrValidationConversion = errors.New("ErrValidationConversion")
-	ErrValidation           = errors.New("ErrValidation")
-	ErrValidationLT         = fmt.Errorf("%w: LT", ErrValidation)
-	ErrValidationLTE        = fmt.Errorf("%w: LTE", ErrValidation)
-	ErrValidationGT         = fmt.Errorf("%w: GT", ErrValidation)
-	ErrValidationGTE        = fmt.Errorf("%w: GTE", ErrValidation)
-	ErrValidationRange      = fmt.Errorf("%w: Range", ErrValidation)
-	ErrValidationIN         = fmt.Errorf("%w: IN", ErrValidation)
-	ErrValidationStrLen     = fmt.Errorf("%w: StrLen", ErrValidation)
-	ErrValidationStrPrefix  = fmt.Errorf("%w: StrPrefix", ErrValidation)
-	ErrValidationStrSuffix  = fmt.Errorf("%w: StrSuffix", ErrValidation)
+	ErrValidationConversion  = errors.New("ErrValidationConversion")
+	ErrValidation            = errors.New("ErrValidation")
+	ErrValidationLT          = fmt.Errorf("%w: LT", ErrValidation)
+	ErrValidationLTE         = fmt.Errorf("%w: LTE", ErrValidation)
+	ErrValidationGT          = fmt.Errorf("%w: GT", ErrValidation)
+	ErrValidationGTE         = fmt.Errorf("%w: GTE", ErrValidation)
+	ErrValidationRange       = fmt.Errorf("%w: Range", ErrValidation)
+	ErrValidationPositive    = fmt.Errorf("%w: Positive", ErrValidation)
+	ErrValidationNonNegative = fmt.Errorf("%w: NonNegative", ErrValidation)
+	ErrValidationNegative    = fmt.Errorf("%w: Negative", ErrValidation)
+	ErrValidationNotZero     = fmt.Errorf("%w: NotZero", ErrValidation)
+	ErrValidationIN          = fmt.Errorf("%w: IN", ErrValidation)
+	ErrValidationStrLen      = fmt.Errorf("%w: StrLen", ErrValidation)
+	ErrValidationStrPrefix   = fmt.Errorf("%w: StrPrefix", ErrValidation)
+	ErrValidationStrSuffix   = fmt.Errorf("%w: StrSuffix", ErrValidation)
+	ErrValidationStrRegex    = fmt.Errorf("%w: StrRegex", ErrValidation)
+	ErrValidationStrNotRegex = fmt.Errorf("%w: StrNotRegex", ErrValidation)
+	ErrValidationNotEmpty    = fmt.Errorf("%w: NotEmpty", ErrValidation)
+	ErrValidationEmpty       = fmt.Errorf("%w: Empty", ErrValidation)
+	ErrValidationRequired    = fmt.Errorf("%w: Required", ErrValidation)
+	ErrValidationEmail       = fmt.Errorf("%w: Email", ErrValidation)
+	ErrValidationURL         = fmt.Errorf("%w: URL", ErrValidation)
+	ErrValidationInlineLen   = fmt.Errorf("%w: InlineLen", ErrValidation)
 
 	ErrDecodeValueType     = errors.New("ErrDecodeValueType")
 	ErrDecodeRowFieldCount = errors.New("ErrDecodeRowFieldCount")
 	ErrDecodeQuoteInvalid  = errors.New("ErrDecodeQuoteInvalid")
+	ErrDelimiterAmbiguous  = errors.New("ErrDelimiterAmbiguous")
+	ErrMaxRowsExceeded     = errors.New("ErrMaxRowsExceeded")
+	ErrTooManyErrors       = errors.New("ErrTooManyErrors")
 
 	ErrEncodeValueType = errors.New("ErrEncodeValueType")
 )
 
 // Errors represents errors returned by the encoder or decoder
 type Errors struct { // nolint: errname
-	errs     []error
-	totalRow int
-	header   []string
+	errs      []error
+	totalRow  int
+	header    []string
+	truncated bool
 }
 
 // NewErrors creates a new Errors object
@@ -70,6 +86,12 @@ func (e *Errors) Header() []string {
 	return e.header
 }
 
+// Truncated returns `true` when the decoding stops as the number of errors reaches DecodeConfig.MaxErrors.
+// In that case, the last error in the list is ErrTooManyErrors.
+func (e *Errors) Truncated() bool {
+	return e.truncated
+}
+
 // Error implements Go error interface
 func (e *Errors) Error() string {
 	return getErrorMsg(e.errs)
@@ -130,11 +152,114 @@ func (e *Errors) Is(err error) bool {
 	return false
 }
 
+// First gets the first error in the list, returns nil if the list is empty
+func (e *Errors) First() error {
+	if len(e.errs) == 0 {
+		return nil
+	}
+	return e.errs[0]
+}
+
+// Last gets the last error in the list, returns nil if the list is empty
+func (e *Errors) Last() error {
+	if len(e.errs) == 0 {
+		return nil
+	}
+	return e.errs[len(e.errs)-1]
+}
+
 // Unwrap implements Go error unwrap function
 func (e *Errors) Unwrap() []error {
 	return e.errs
 }
 
+// FilterRows returns a new Errors containing only the row errors matching the given predicate.
+// Errors not belonging to any row are dropped from the result.
+func (e *Errors) FilterRows(pred func(*RowErrors) bool) *Errors {
+	result := &Errors{totalRow: e.totalRow, header: e.header}
+	for _, err := range e.errs {
+		if rowErr, ok := err.(*RowErrors); ok && pred(rowErr) { // nolint: errorlint
+			result.errs = append(result.errs, rowErr)
+		}
+	}
+	return result
+}
+
+// FilterCells returns a new Errors containing only the cell errors matching the given predicate.
+// Rows having no remaining cell errors and errors which are not cell errors are dropped from the result.
+func (e *Errors) FilterCells(pred func(*CellError) bool) *Errors {
+	result := &Errors{totalRow: e.totalRow, header: e.header}
+	for _, err := range e.errs {
+		rowErr, ok := err.(*RowErrors) // nolint: errorlint
+		if !ok {
+			continue
+		}
+		var newRowErr *RowErrors
+		for _, err := range rowErr.errs {
+			if cellErr, ok := err.(*CellError); ok && pred(cellErr) { // nolint: errorlint
+				if newRowErr == nil {
+					newRowErr = NewRowErrors(rowErr.row, rowErr.line)
+				}
+				newRowErr.Add(cellErr)
+			}
+		}
+		if newRowErr != nil {
+			result.errs = append(result.errs, newRowErr)
+		}
+	}
+	return result
+}
+
+// GroupByColumn gets all cell errors grouped by column header
+func (e *Errors) GroupByColumn() map[string][]*CellError {
+	result := map[string][]*CellError{}
+	for _, err := range e.errs {
+		rowErr, ok := err.(*RowErrors) // nolint: errorlint
+		if !ok {
+			continue
+		}
+		for _, err := range rowErr.errs {
+			if cellErr, ok := err.(*CellError); ok { // nolint: errorlint
+				result[cellErr.header] = append(result[cellErr.header], cellErr)
+			}
+		}
+	}
+	return result
+}
+
+// AllCellErrorsByColumn gets all cell errors grouped by column header, this is the same as GroupByColumn
+func (e *Errors) AllCellErrorsByColumn() map[string][]*CellError {
+	return e.GroupByColumn()
+}
+
+// ErrorsByRow gets the row errors keyed by row number (1-based).
+// Errors not belonging to any row are not included. The returned row errors are copies, adding errors
+// to them doesn't affect the source, the errors within them (e.g. CellError) are shared.
+func (e *Errors) ErrorsByRow() map[int]*RowErrors {
+	result := map[int]*RowErrors{}
+	for _, err := range e.errs {
+		if rowErr, ok := err.(*RowErrors); ok { // nolint: errorlint
+			result[rowErr.row] = &RowErrors{
+				errs: append(make([]error, 0, len(rowErr.errs)), rowErr.errs...),
+				row:  rowErr.row,
+				line: rowErr.line,
+			}
+		}
+	}
+	return result
+}
+
+// AllCellErrors gets all cell errors of all rows in the order they are collected (by row then by column)
+func (e *Errors) AllCellErrors() []*CellError {
+	var result []*CellError
+	for _, err := range e.errs {
+		if rowErr, ok := err.(*RowErrors); ok { // nolint: errorlint
+			result = append(result, rowErr.CellErrors()...)
+		}
+	}
+	return result
+}
+
 // RowErrors data structure of error of a row
 type RowErrors struct { // nolint: errname
 	errs []error
@@ -183,6 +308,44 @@ func (e *RowErrors) TotalCellError() int {
 	return c
 }
 
+// CellErrors gets the errors of cells in the order they are added
+func (e *RowErrors) CellErrors() []*CellError {
+	var result []*CellError
+	for _, err := range e.errs {
+		if cellErr, ok := err.(*CellError); ok { // nolint: errorlint
+			result = append(result, cellErr)
+		}
+	}
+	return result
+}
+
+// CommonErrors gets the errors which are not errors of cells (e.g. errors of the row structure)
+func (e *RowErrors) CommonErrors() []error {
+	var result []error
+	for _, err := range e.errs {
+		if _, ok := err.(*CellError); !ok { // nolint: errorlint
+			result = append(result, err)
+		}
+	}
+	return result
+}
+
+// CellErrorForColumn gets the first error of the cell at the specified column index,
+// returns nil if there is no error of the cell
+func (e *RowErrors) CellErrorForColumn(column int) *CellError {
+	for _, err := range e.errs {
+		if cellErr, ok := err.(*CellError); ok && cellErr.column == column { // nolint: errorlint
+			return cellErr
+		}
+	}
+	return nil
+}
+
+// HasCellError checks if there is at least one error of the cell at the specified column index
+func (e *RowErrors) HasCellError(column int) bool {
+	return e.CellErrorForColumn(column) != nil
+}
+
 // Add appends errors to the list
 func (e *RowErrors) Add(errs ...error) {
 	e.errs = append(e.errs, errs...)
@@ -198,6 +361,22 @@ func (e *RowErrors) Is(err error) bool {
 	return false
 }
 
+// First gets the first error in the list, returns nil if the list is empty
+func (e *RowErrors) First() error {
+	if len(e.errs) == 0 {
+		return nil
+	}
+	return e.errs[0]
+}
+
+// Last gets the last error in the list, returns nil if the list is empty
+func (e *RowErrors) Last() error {
+	if len(e.errs) == 0 {
+		return nil
+	}
+	return e.errs[len(e.errs)-1]
+}
+
 // Unwrap implements Go error unwrap function
 func (e *RowErrors) Unwrap() []error {
 	return e.errs
@@ -263,6 +442,23 @@ func (e *CellError) WithParam(k string, v any) *CellError {
 	return e
 }
 
+// SetFields merges the given fields into the params of error
+func (e *CellError) SetFields(fields map[string]any) *CellError {
+	for k, v := range fields {
+		e.fields[k] = v
+	}
+	return e
+}
+
+// Fields gets a copy of the params of error
+func (e *CellError) Fields() map[string]any {
+	fields := make(map[string]any, len(e.fields))
+	for k, v := range e.fields {
+		fields[k] = v
+	}
+	return fields
+}
+
 // LocalizationKey gets localization key of error
 func (e *CellError) LocalizationKey() string {
 	return e.localizationKey
@@ -273,6 +469,17 @@ func (e *CellError) SetLocalizationKey(k string) {
 	e.localizationKey = k
 }
 
+// errorWithParams error carrying params which will be set to the cell error built from it
+type errorWithParams struct {
+	error
+	params ParameterMap
+}
+
+// Unwrap implements Go error unwrap function
+func (e *errorWithParams) Unwrap() error {
+	return e.error
+}
+
 func getErrorMsg(errs []error) string {
 	s := ""
 	for i, e := range errs {
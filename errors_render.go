@@ -26,6 +26,7 @@ type ErrorRenderConfig struct {
 	//   {{.TotalRowError}}  - number of rows have error
 	//   {{.TotalCellError}} - number of cells have error
 	//   {{.TotalError}}     - number of errors
+	//   {{.Truncated}}      - `true` when the errors are truncated by DecodeConfig.MaxErrors
 	//
 	// Extra params:
 	//   {{.CrLf}} - line break
@@ -81,6 +82,13 @@ type ErrorRenderConfig struct {
 
 	// CommonErrorRenderFunc renders common error (not RowErrors, CellError) (optional)
 	CommonErrorRenderFunc func(error, ParameterMap) (string, error)
+
+	// HTMLTableAttrs extra attributes of the `<table>` element when rendering as HTML, e.g. `class="errors"`.
+	// The value is put into the output as is without escaping (optional).
+	HTMLTableAttrs string
+
+	// HTMLRowClassFunc gets the class of the `<tr>` element of a row error when rendering as HTML (optional)
+	HTMLRowClassFunc func(*RowErrors) string
 }
 
 func defaultRenderConfig() *ErrorRenderConfig {
@@ -129,15 +137,7 @@ func (r *SimpleRenderer) Render() (msg string, transErr error, err error) {
 	cfg := r.cfg
 	errs := r.sourceErr.Unwrap()
 	content := make([]string, 0, len(errs)+1)
-	params := gofn.MapUpdate(ParameterMap{
-		"CrLf": cfg.LineBreak,
-		"Tab":  "\t",
-
-		"TotalRow":       r.sourceErr.TotalRow(),
-		"TotalError":     r.sourceErr.TotalError(),
-		"TotalRowError":  r.sourceErr.TotalRowError(),
-		"TotalCellError": r.sourceErr.TotalCellError(),
-	}, cfg.Params)
+	params := r.renderParams()
 
 	// Header line
 	if cfg.HeaderFormatKey != "" {
@@ -163,6 +163,19 @@ func (r *SimpleRenderer) Render() (msg string, transErr error, err error) {
 	return strings.Join(content, cfg.RowSeparator), r.transErr, nil
 }
 
+func (r *SimpleRenderer) renderParams() ParameterMap {
+	return gofn.MapUpdate(ParameterMap{
+		"CrLf": r.cfg.LineBreak,
+		"Tab":  "\t",
+
+		"TotalRow":       r.sourceErr.TotalRow(),
+		"TotalError":     r.sourceErr.TotalError(),
+		"TotalRowError":  r.sourceErr.TotalRowError(),
+		"TotalCellError": r.sourceErr.TotalCellError(),
+		"Truncated":      r.sourceErr.Truncated(),
+	}, r.cfg.Params)
+}
+
 func (r *SimpleRenderer) renderRow(rowErr *RowErrors, exparams ParameterMap) string {
 	cfg := r.cfg
 	errs := rowErr.Unwrap()
@@ -3,6 +3,7 @@ package csvlib
 import (
 	"bytes"
 	"encoding/csv"
+	"encoding/json"
 	"sort"
 	"strconv"
 	"strings"
@@ -61,12 +62,16 @@ type CSVRenderConfig struct {
 
 	// CommonErrorRenderFunc renders common error (not RowErrors, CellError) (optional)
 	CommonErrorRenderFunc func(error, ParameterMap) (string, error)
+
+	// JSONOmitEmptyCells omit the cells having no error when rendering as JSON (default is `true`)
+	JSONOmitEmptyCells bool
 }
 
 func defaultCSVRenderConfig() *CSVRenderConfig {
 	return &CSVRenderConfig{
-		CellSeparator: ", ",
-		LineBreak:     newLine,
+		CellSeparator:      ", ",
+		LineBreak:          newLine,
+		JSONOmitEmptyCells: true,
 
 		RenderHeader:                 true,
 		RenderRowNumberColumnIndex:   0,
@@ -86,9 +91,17 @@ type CSVRenderer struct {
 	transErr          error
 	numColumns        int
 	startCellErrIndex int
+	header            []string
 	data              [][]string
 }
 
+// CSVJSONContent the JSON structure of the CSV rendering result.
+// Each row is keyed by the rendered header.
+type CSVJSONContent struct {
+	Header []string            `json:"header"`
+	Rows   []map[string]string `json:"rows"`
+}
+
 // NewCSVRenderer creates a new CSVRenderer
 func NewCSVRenderer(err *Errors, options ...func(*CSVRenderConfig)) (*CSVRenderer, error) {
 	cfg := defaultCSVRenderConfig()
@@ -142,6 +155,7 @@ func (r *CSVRenderer) Render() (data [][]string, transErr error, err error) {
 		"TotalError":     r.sourceErr.TotalError(),
 		"TotalRowError":  r.sourceErr.TotalRowError(),
 		"TotalCellError": r.sourceErr.TotalCellError(),
+		"Truncated":      r.sourceErr.Truncated(),
 	}, cfg.Params)
 
 	// Render header row
@@ -174,6 +188,41 @@ func (r *CSVRenderer) RenderAsString() (msg string, transErr error, err error) {
 	return buf.String(), transErr, nil
 }
 
+// RenderAsJSON renders the input as JSON data, the rows are keyed by the header columns.
+// The header is always included in the output regardless of CSVRenderConfig.RenderHeader.
+//
+// Sample output:
+//
+//	{"header":["Row","Line","CommonError","Name","Age"],"rows":[{"Row":"10","Line":"12","Name":"ERR_NAME"}]}
+func (r *CSVRenderer) RenderAsJSON() (data []byte, transErr error, err error) {
+	csvData, transErr, err := r.Render()
+	if err != nil {
+		return nil, transErr, err
+	}
+	if r.cfg.RenderHeader {
+		csvData = csvData[1:]
+	}
+	content := &CSVJSONContent{
+		Header: r.header,
+		Rows:   make([]map[string]string, 0, len(csvData)),
+	}
+	for _, row := range csvData {
+		rowContent := make(map[string]string, len(row))
+		for i, cell := range row {
+			if cell == "" && r.cfg.JSONOmitEmptyCells {
+				continue
+			}
+			rowContent[r.header[i]] = cell
+		}
+		content.Rows = append(content.Rows, rowContent)
+	}
+	data, err = json.Marshal(content)
+	if err != nil {
+		return nil, transErr, err
+	}
+	return data, transErr, nil
+}
+
 // RenderTo renders the input as CSV string and writes it to the writer
 func (r *CSVRenderer) RenderTo(w Writer) (transErr error, err error) {
 	csvData, transErr, err := r.Render()
@@ -194,9 +243,6 @@ func (r *CSVRenderer) RenderTo(w Writer) (transErr error, err error) {
 }
 
 func (r *CSVRenderer) renderHeader(exparams ParameterMap) {
-	if !r.cfg.RenderHeader {
-		return
-	}
 	cfg := r.cfg
 	header := make([]string, r.numColumns)
 	if cfg.RenderRowNumberColumnIndex >= 0 {
@@ -215,7 +261,10 @@ func (r *CSVRenderer) renderHeader(exparams ParameterMap) {
 	if cfg.HeaderRenderFunc != nil {
 		cfg.HeaderRenderFunc(header, exparams)
 	}
-	r.data = append(r.data, header)
+	r.header = header
+	if cfg.RenderHeader {
+		r.data = append(r.data, header)
+	}
 }
 
 func (r *CSVRenderer) renderRow(rowErr *RowErrors, exparams ParameterMap) []string {
@@ -1,6 +1,7 @@
 package csvlib
 
 import (
+	"encoding/json"
 	"errors"
 	"testing"
 
@@ -94,4 +95,44 @@ func Test_ErrorRenderAsCSV(t *testing.T) {
 			,20,ErrValidation: StrLen,ErrValidation: Range,
 			`), msg)
 	})
+
+	t.Run("#4: render as JSON", func(t *testing.T) {
+		r, err := NewCSVRenderer(csvErr)
+		assert.Nil(t, err)
+		data, _, err := r.RenderAsJSON()
+		assert.Nil(t, err)
+
+		var content CSVJSONContent
+		assert.Nil(t, json.Unmarshal(data, &content))
+		assert.Equal(t, []string{"Row", "Line", "CommonError", "Name", "Age", "Address"}, content.Header)
+		assert.Equal(t, []map[string]string{
+			{
+				"Row": "10", "Line": "12", "CommonError": "ErrDecodeQuoteInvalid",
+				"Name": "ERR_NAME_TOO_LONG", "Age": "ERR_AGE_OUT_OF_RANGE",
+			},
+			{"Row": "20", "Line": "22", "Name": "ErrValidation: StrLen", "Age": "ErrValidation: Range"},
+		}, content.Rows)
+	})
+
+	t.Run("#5: render as JSON without omitting empty cells", func(t *testing.T) {
+		r, err := NewCSVRenderer(csvErr, func(cfg *CSVRenderConfig) {
+			cfg.RenderHeader = false
+			cfg.RenderLineNumberColumnIndex = -1
+			cfg.JSONOmitEmptyCells = false
+		})
+		assert.Nil(t, err)
+		data, _, err := r.RenderAsJSON()
+		assert.Nil(t, err)
+
+		var content CSVJSONContent
+		assert.Nil(t, json.Unmarshal(data, &content))
+		assert.Equal(t, []string{"Row", "CommonError", "Name", "Age", "Address"}, content.Header)
+		assert.Equal(t, []map[string]string{
+			{
+				"Row": "10", "CommonError": "ErrDecodeQuoteInvalid",
+				"Name": "ERR_NAME_TOO_LONG", "Age": "ERR_AGE_OUT_OF_RANGE", "Address": "",
+			},
+			{"Row": "20", "CommonError": "", "Name": "ErrValidation: StrLen", "Age": "ErrValidation: Range", "Address": ""},
+		}, content.Rows)
+	})
 }
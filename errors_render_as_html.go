@@ -0,0 +1,69 @@
+package csvlib
+
+import (
+	"html"
+	"strings"
+)
+
+// RenderAsHTML renders Errors object as an HTML table fragment.
+// The header is rendered as the table caption, each row error is rendered as a `<tr>` element
+// with the same content as the one produced by Render(). All the content is HTML-escaped.
+//
+// Sample output:
+//
+//	<table class="errors">
+//	<caption>There are 5 total errors in your CSV file</caption>
+//	<tr class="row-error"><td>Row 20 (line 21): column 2: invalid type (Int)</td></tr>
+//	<tr><td>invalid number of columns (10)</td></tr>
+//	</table>
+func (r *SimpleRenderer) RenderAsHTML() (msg string, transErr error, err error) {
+	cfg := r.cfg
+	params := r.renderParams()
+
+	var sb strings.Builder
+	sb.WriteString("<table")
+	if cfg.HTMLTableAttrs != "" {
+		sb.WriteString(" ")
+		sb.WriteString(cfg.HTMLTableAttrs)
+	}
+	sb.WriteString(">")
+
+	if cfg.HeaderFormatKey != "" {
+		header := r.localizeKeySkipError(cfg.HeaderFormatKey, params)
+		if header != "" {
+			sb.WriteString(newLine)
+			sb.WriteString("<caption>")
+			sb.WriteString(html.EscapeString(header))
+			sb.WriteString("</caption>")
+		}
+	}
+
+	for _, err := range r.sourceErr.Unwrap() {
+		var detail, class string
+		if rowErr, ok := err.(*RowErrors); ok { // nolint: errorlint
+			detail = r.renderRow(rowErr, params)
+			if cfg.HTMLRowClassFunc != nil {
+				class = cfg.HTMLRowClassFunc(rowErr)
+			}
+		} else {
+			detail = r.renderCommonError(err, params)
+		}
+		if detail == "" {
+			continue
+		}
+		sb.WriteString(newLine)
+		sb.WriteString("<tr")
+		if class != "" {
+			sb.WriteString(` class="`)
+			sb.WriteString(html.EscapeString(class))
+			sb.WriteString(`"`)
+		}
+		sb.WriteString("><td>")
+		sb.WriteString(html.EscapeString(detail))
+		sb.WriteString("</td></tr>")
+	}
+
+	sb.WriteString(newLine)
+	sb.WriteString("</table>")
+	return sb.String(), r.transErr, nil
+}
@@ -0,0 +1,77 @@
+package csvlib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tiendc/gofn"
+)
+
+func Test_ErrorRenderAsHTML(t *testing.T) {
+	csvErr := NewErrors()
+	csvErr.totalRow = 100
+	rowErr1 := NewRowErrors(10, 12)
+	rowErr2 := NewRowErrors(20, 22)
+	csvErr.Add(rowErr1, rowErr2)
+
+	cellErr11 := NewCellError(ErrValidationStrLen, 0, "Name")
+	cellErr11.SetLocalizationKey("'{{.Value}}' must be <= 10 chars")
+	cellErr11.value = "<b>David</b> & co"
+	cellErr12 := NewCellError(ErrValidationRange, 1, "Age")
+	rowErr1.Add(cellErr11, cellErr12)
+
+	cellErr21 := NewCellError(ErrValidationRange, 1, "Age")
+	rowErr2.Add(cellErr21)
+
+	csvErr.Add(ErrTypeUnsupported)
+
+	t.Run("#1: default rendering", func(t *testing.T) {
+		r, err := NewRenderer(csvErr)
+		assert.Nil(t, err)
+		msg, transErr, err := r.RenderAsHTML()
+		assert.Nil(t, err)
+		assert.Nil(t, transErr)
+		// nolint: lll
+		assert.Equal(t, gofn.MultilineString(
+			`<table>
+			<caption>Error content: TotalRow: 100, TotalRowError: 2, TotalCellError: 3, TotalError: 4</caption>
+			<tr><td>Row 10 (line 12): &#39;&lt;b&gt;David&lt;/b&gt; &amp; co&#39; must be &lt;= 10 chars, ErrValidation: Range</td></tr>
+			<tr><td>Row 20 (line 22): ErrValidation: Range</td></tr>
+			<tr><td>ErrTypeUnsupported</td></tr>
+			</table>`), msg)
+	})
+
+	t.Run("#2: custom table attributes and row class", func(t *testing.T) {
+		r, err := NewRenderer(csvErr, func(cfg *ErrorRenderConfig) {
+			cfg.HeaderFormatKey = ""
+			cfg.RowFormatKey = "Row {{.Row}}: {{.Error}}"
+			cfg.HTMLTableAttrs = `class="errors" id="csv-errors"`
+			cfg.HTMLRowClassFunc = func(rowErr *RowErrors) string {
+				if rowErr.TotalCellError() > 1 {
+					return `multi"error`
+				}
+				return ""
+			}
+		})
+		assert.Nil(t, err)
+		msg, _, err := r.RenderAsHTML()
+		assert.Nil(t, err)
+		// nolint: lll
+		assert.Equal(t, gofn.MultilineString(
+			`<table class="errors" id="csv-errors">
+			<tr class="multi&#34;error"><td>Row 10: &#39;&lt;b&gt;David&lt;/b&gt; &amp; co&#39; must be &lt;= 10 chars, ErrValidation: Range</td></tr>
+			<tr><td>Row 20: ErrValidation: Range</td></tr>
+			<tr><td>ErrTypeUnsupported</td></tr>
+			</table>`), msg)
+	})
+
+	t.Run("#3: no errors", func(t *testing.T) {
+		r, err := NewRenderer(NewErrors(), func(cfg *ErrorRenderConfig) {
+			cfg.HeaderFormatKey = ""
+		})
+		assert.Nil(t, err)
+		msg, _, err := r.RenderAsHTML()
+		assert.Nil(t, err)
+		assert.Equal(t, "<table>\n</table>", msg)
+	})
+}
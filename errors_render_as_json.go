@@ -0,0 +1,254 @@
+package csvlib
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/tiendc/gofn"
+)
+
+type JSONRenderConfig struct {
+	// LocalizeCellFields localize cell's fields before rendering the cell error (default is `true`)
+	LocalizeCellFields bool
+
+	// LocalizeCellHeader localize cell header before rendering the cell error (default is `true`).
+	// This only affects the param {{.ColumnHeader}}, the keys of `cells` are always the input header.
+	LocalizeCellHeader bool
+
+	// Params custom params user wants to send to the localization (optional)
+	Params ParameterMap
+
+	// LocalizationFunc function to translate message (optional)
+	LocalizationFunc LocalizationFunc
+
+	// CellRenderFunc custom render function for rendering a cell error (optional).
+	// The func can return ("", false) to skip rendering the cell error, return ("", true) to let the
+	// renderer continue using its solution, and return ("<str>", true) to override the value.
+	//
+	// Supported params:
+	//   {{.Column}}       - column index (0-based)
+	//   {{.ColumnHeader}} - column name
+	//   {{.Value}}        - cell value
+	//   {{.Error}}        - error detail which is result of calling err.Error()
+	//
+	// Use cellErr.WithParam() to add more extra params
+	CellRenderFunc func(*RowErrors, *CellError, ParameterMap) (string, bool)
+
+	// CommonErrorRenderFunc renders common error (not RowErrors, CellError) (optional)
+	CommonErrorRenderFunc func(error, ParameterMap) (string, error)
+}
+
+func defaultJSONRenderConfig() *JSONRenderConfig {
+	return &JSONRenderConfig{
+		LocalizeCellFields: true,
+		LocalizeCellHeader: true,
+	}
+}
+
+// JSONErrorContent the JSON structure of the rendering result
+type JSONErrorContent struct {
+	TotalRow     int             `json:"totalRow"`
+	TotalError   int             `json:"totalError"`
+	Truncated    bool            `json:"truncated,omitempty"`
+	Rows         []*JSONRowError `json:"rows"`
+	CommonErrors []string        `json:"commonErrors,omitempty"`
+}
+
+// JSONRowError the JSON structure of a row error.
+// `Cells` is keyed by column header, errors not relating to any column are put in `CommonErrors`.
+type JSONRowError struct {
+	Row          int                 `json:"row"`
+	Line         int                 `json:"line"`
+	Cells        map[string][]string `json:"cells"`
+	CommonErrors []string            `json:"commonErrors,omitempty"`
+}
+
+// JSONRenderer an implementation of error renderer which can produce messages
+// for the input errors as JSON data.
+type JSONRenderer struct {
+	cfg       *JSONRenderConfig
+	sourceErr *Errors
+	transErr  error
+}
+
+// NewJSONRenderer creates a new JSONRenderer
+func NewJSONRenderer(err *Errors, options ...func(*JSONRenderConfig)) (*JSONRenderer, error) {
+	cfg := defaultJSONRenderConfig()
+	for _, opt := range options {
+		opt(cfg)
+	}
+	return &JSONRenderer{cfg: cfg, sourceErr: err}, nil
+}
+
+// Render renders Errors object as JSON data.
+// Translation errors can be retrieved via TranslationError() after rendering.
+//
+// Sample output:
+//
+//	{"totalRow":100,"totalError":3,"rows":[{"row":10,"line":12,"cells":{"Name":["Name is too long"]}}]}
+func (r *JSONRenderer) Render() ([]byte, error) {
+	return json.Marshal(r.RenderAsContent())
+}
+
+// RenderTo renders Errors object as JSON data and writes it to the writer
+func (r *JSONRenderer) RenderTo(w io.Writer) error {
+	return json.NewEncoder(w).Encode(r.RenderAsContent())
+}
+
+// RenderAsContent renders Errors object as a JSON structure
+func (r *JSONRenderer) RenderAsContent() *JSONErrorContent {
+	r.transErr = nil
+	errs := r.sourceErr.Unwrap()
+	params := gofn.MapUpdate(ParameterMap{
+		"TotalRow":       r.sourceErr.TotalRow(),
+		"TotalError":     r.sourceErr.TotalError(),
+		"TotalRowError":  r.sourceErr.TotalRowError(),
+		"TotalCellError": r.sourceErr.TotalCellError(),
+		"Truncated":      r.sourceErr.Truncated(),
+	}, r.cfg.Params)
+
+	content := &JSONErrorContent{
+		TotalRow:   r.sourceErr.TotalRow(),
+		TotalError: r.sourceErr.TotalError(),
+		Truncated:  r.sourceErr.Truncated(),
+		Rows:       make([]*JSONRowError, 0, len(errs)),
+	}
+	for _, err := range errs {
+		if rowErr, ok := err.(*RowErrors); ok { // nolint: errorlint
+			content.Rows = append(content.Rows, r.renderRow(rowErr, params))
+			continue
+		}
+		if detail := r.renderCommonError(err, params); detail != "" {
+			content.CommonErrors = append(content.CommonErrors, detail)
+		}
+	}
+	return content
+}
+
+// TranslationError gets the translation error happened in the last rendering
+func (r *JSONRenderer) TranslationError() error {
+	return r.transErr
+}
+
+func (r *JSONRenderer) renderRow(rowErr *RowErrors, exparams ParameterMap) *JSONRowError {
+	errs := rowErr.Unwrap()
+	rowContent := &JSONRowError{
+		Row:   rowErr.Row(),
+		Line:  rowErr.Line(),
+		Cells: make(map[string][]string, len(errs)),
+	}
+
+	params := gofn.MapUpdate(ParameterMap{}, exparams)
+	params["Row"] = rowErr.Row()
+	params["Line"] = rowErr.Line()
+
+	for _, err := range errs {
+		cellErr, ok := err.(*CellError) // nolint: errorlint
+		if !ok {
+			if detail := r.renderCommonError(err, params); detail != "" {
+				rowContent.CommonErrors = append(rowContent.CommonErrors, detail)
+			}
+			continue
+		}
+		detail := r.renderCell(rowErr, cellErr, params)
+		if detail == "" {
+			continue
+		}
+		if cellErr.column == -1 {
+			rowContent.CommonErrors = append(rowContent.CommonErrors, detail)
+			continue
+		}
+		header := cellErr.Header()
+		if cellErr.column < len(r.sourceErr.header) {
+			header = r.sourceErr.header[cellErr.column]
+		}
+		rowContent.Cells[header] = append(rowContent.Cells[header], detail)
+	}
+	return rowContent
+}
+
+func (r *JSONRenderer) renderCell(rowErr *RowErrors, cellErr *CellError, exparams ParameterMap) string {
+	params := gofn.MapUpdate(ParameterMap{}, exparams)
+	params = gofn.MapUpdate(params, r.renderCellFields(cellErr, params))
+	params["Column"] = cellErr.Column()
+	params["ColumnHeader"] = r.renderCellHeader(cellErr, params)
+	params["Value"] = cellErr.Value()
+	params["Error"] = cellErr.Error()
+
+	if r.cfg.CellRenderFunc != nil {
+		msg, flag := r.cfg.CellRenderFunc(rowErr, cellErr, exparams)
+		if !flag {
+			return ""
+		}
+		if msg != "" {
+			return msg
+		}
+	}
+
+	locKey := cellErr.LocalizationKey()
+	if locKey == "" {
+		locKey = cellErr.Error()
+	}
+	return r.localizeKeySkipError(locKey, params)
+}
+
+func (r *JSONRenderer) renderCellFields(cellErr *CellError, params ParameterMap) ParameterMap {
+	if !r.cfg.LocalizeCellFields {
+		return cellErr.fields
+	}
+	result := make(ParameterMap, len(cellErr.fields))
+	for k, v := range cellErr.fields {
+		vAsStr, ok := v.(string)
+		if !ok {
+			result[k] = v
+			continue
+		}
+		if translated, err := r.localizeKey(vAsStr, params); err != nil {
+			result[k] = v
+		} else {
+			result[k] = translated
+		}
+	}
+	return result
+}
+
+func (r *JSONRenderer) renderCellHeader(cellErr *CellError, params ParameterMap) string {
+	if !r.cfg.LocalizeCellHeader {
+		return cellErr.Header()
+	}
+	return r.localizeKeySkipError(cellErr.Header(), params)
+}
+
+func (r *JSONRenderer) renderCommonError(err error, params ParameterMap) string {
+	if r.cfg.CommonErrorRenderFunc == nil {
+		return r.localizeKeySkipError(err.Error(), params)
+	}
+	msg, err := r.cfg.CommonErrorRenderFunc(err, params)
+	if err != nil {
+		r.transErr = multierror.Append(r.transErr, err)
+	}
+	return msg
+}
+
+func (r *JSONRenderer) localizeKey(key string, params ParameterMap) (string, error) {
+	if r.cfg.LocalizationFunc == nil {
+		return processTemplate(key, params)
+	}
+	msg, err := r.cfg.LocalizationFunc(key, params)
+	if err != nil {
+		err = multierror.Append(ErrLocalization, err)
+		r.transErr = multierror.Append(r.transErr, err)
+		return "", err
+	}
+	return msg, nil
+}
+
+func (r *JSONRenderer) localizeKeySkipError(key string, params ParameterMap) string {
+	s, err := r.localizeKey(key, params)
+	if err == nil || r.cfg.LocalizationFunc == nil {
+		return s
+	}
+	s, _ = processTemplate(key, params)
+	return s
+}
@@ -0,0 +1,123 @@
+package csvlib
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ErrorRenderAsJSON(t *testing.T) {
+	// CSV error has 2 row errors
+	csvErr := NewErrors()
+	csvErr.totalRow = 200
+	csvErr.header = []string{"Name", "Age", "Address"}
+
+	rowErr1 := NewRowErrors(10, 12)
+	rowErr2 := NewRowErrors(20, 22)
+	csvErr.Add(rowErr1, rowErr2)
+
+	// First row error has 2 cell errors and an unexpected error
+	cellErr11 := NewCellError(ErrValidationStrLen, 0, "Name")
+	cellErr11.SetLocalizationKey("ERR_NAME_TOO_LONG")
+	cellErr11.value = "David David David"
+	_ = cellErr11.WithParam("MinLen", 1).WithParam("MaxLen", 10)
+
+	cellErr12 := NewCellError(ErrValidationRange, 1, "Age")
+	cellErr12.SetLocalizationKey("ERR_AGE_OUT_OF_RANGE")
+	cellErr12.value = "101"
+	_ = cellErr12.WithParam("MinValue", 1).WithParam("MaxValue", 100)
+
+	cellErr13 := NewCellError(ErrDecodeQuoteInvalid, -1, "") // error not relate to any column
+	rowErr1.Add(cellErr11, cellErr12, cellErr13)
+
+	// Second row error has 2 other cell errors on the same column
+	cellErr21 := NewCellError(ErrValidationStrLen, 0, "Name")
+	cellErr22 := NewCellError(ErrValidationStrPrefix, 0, "Name")
+	rowErr2.Add(cellErr21, cellErr22)
+
+	// An unexpected error
+	csvErr.Add(ErrTypeUnsupported)
+
+	t.Run("#1: default rendering", func(t *testing.T) {
+		r, err := NewJSONRenderer(csvErr)
+		assert.Nil(t, err)
+		data, err := r.Render()
+		assert.Nil(t, err)
+		assert.JSONEq(t, `{
+			"totalRow": 200,
+			"totalError": 6,
+			"rows": [
+				{
+					"row": 10,
+					"line": 12,
+					"cells": {"Name": ["ERR_NAME_TOO_LONG"], "Age": ["ERR_AGE_OUT_OF_RANGE"]},
+					"commonErrors": ["ErrDecodeQuoteInvalid"]
+				},
+				{
+					"row": 20,
+					"line": 22,
+					"cells": {"Name": ["ErrValidation: StrLen", "ErrValidation: StrPrefix"]}
+				}
+			],
+			"commonErrors": ["ErrTypeUnsupported"]
+		}`, string(data))
+	})
+
+	t.Run("#2: translate en_US", func(t *testing.T) {
+		r, err := NewJSONRenderer(csvErr, func(cfg *JSONRenderConfig) {
+			cfg.LocalizationFunc = localizeEnUs
+		})
+		assert.Nil(t, err)
+		content := r.RenderAsContent()
+		assert.NotNil(t, r.TranslationError())
+		assert.Equal(t, []string{"'David David David' at column 0 - Name length must be from 1 to 10"},
+			content.Rows[0].Cells["Name"])
+		assert.Equal(t, []string{"'101' at column 1 - Age must be from 1 to 100"}, content.Rows[0].Cells["Age"])
+		assert.Equal(t, []string{"ErrValidation: StrLen", "ErrValidation: StrPrefix"}, content.Rows[1].Cells["Name"])
+	})
+
+	t.Run("#3: custom render funcs", func(t *testing.T) {
+		r, err := NewJSONRenderer(csvErr, func(cfg *JSONRenderConfig) {
+			cfg.Params = ParameterMap{"Source": "upload.csv"}
+			cfg.CellRenderFunc = func(rowErr *RowErrors, cellErr *CellError, params ParameterMap) (string, bool) {
+				if errors.Is(cellErr, ErrDecodeQuoteInvalid) {
+					return "invalid quote", true
+				}
+				if errors.Is(cellErr, ErrValidationStrPrefix) {
+					return "", false
+				}
+				return "", true
+			}
+			cfg.CommonErrorRenderFunc = func(err error, params ParameterMap) (string, error) {
+				return "unexpected error in " + params["Source"].(string), nil
+			}
+		})
+		assert.Nil(t, err)
+
+		var buf bytes.Buffer
+		assert.Nil(t, r.RenderTo(&buf))
+		assert.Nil(t, r.TranslationError())
+
+		var content JSONErrorContent
+		assert.Nil(t, json.Unmarshal(buf.Bytes(), &content))
+		assert.Equal(t, 2, len(content.Rows))
+		assert.Equal(t, []string{"invalid quote"}, content.Rows[0].CommonErrors)
+		assert.Equal(t, []string{"ErrValidation: StrLen"}, content.Rows[1].Cells["Name"])
+		assert.Equal(t, []string{"unexpected error in upload.csv"}, content.CommonErrors)
+	})
+
+	t.Run("#4: common error render func fails", func(t *testing.T) {
+		r, err := NewJSONRenderer(csvErr, func(cfg *JSONRenderConfig) {
+			cfg.CommonErrorRenderFunc = func(err error, params ParameterMap) (string, error) {
+				return "", errKeyNotFound
+			}
+		})
+		assert.Nil(t, err)
+		content := r.RenderAsContent()
+		assert.Equal(t, 0, len(content.CommonErrors))
+		assert.ErrorIs(t, r.TranslationError(), errKeyNotFound)
+	})
+}
@@ -61,6 +61,117 @@ func TestErrors_Is(t *testing.T) {
 	assert.False(t, errors.Is(e, errRow2))
 }
 
+func TestErrors_FirstLast(t *testing.T) {
+	e := NewErrors()
+	assert.Nil(t, e.First())
+	assert.Nil(t, e.Last())
+
+	e.Add(errRow1)
+	assert.Same(t, errRow1, e.First())
+	assert.Same(t, errRow1, e.Last())
+
+	e.Add(errRow2, ErrTypeUnsupported)
+	assert.Same(t, errRow1, e.First())
+	assert.True(t, errors.Is(e.First(), errCell1))
+	assert.False(t, errors.Is(e.First(), errCell2))
+	assert.Equal(t, ErrTypeUnsupported, e.Last())
+	assert.True(t, errors.Is(e.Last(), ErrTypeUnsupported))
+}
+
+func TestErrors_FilterRows(t *testing.T) {
+	e := &Errors{totalRow: 10, header: []string{"column-1", "column-2"}}
+	e.Add(ErrTypeUnsupported, errRow1, errRow2)
+
+	ret := e.FilterRows(func(rowErr *RowErrors) bool { return rowErr.Row() >= 2 })
+	assert.Equal(t, 10, ret.TotalRow())
+	assert.Equal(t, []string{"column-1", "column-2"}, ret.Header())
+	assert.Equal(t, []error{errRow2}, ret.Unwrap())
+	assert.False(t, errors.Is(ret, ErrTypeUnsupported))
+
+	ret = e.FilterRows(func(rowErr *RowErrors) bool { return false })
+	assert.False(t, ret.HasError())
+	assert.Equal(t, 10, ret.TotalRow())
+
+	ret = NewErrors().FilterRows(func(rowErr *RowErrors) bool { return true })
+	assert.False(t, ret.HasError())
+}
+
+func TestErrors_FilterCells(t *testing.T) {
+	e := &Errors{totalRow: 10, header: []string{"column-1", "column-2"}}
+	e.Add(ErrTypeUnsupported, errRow1, errRow2)
+
+	ret := e.FilterCells(func(cellErr *CellError) bool { return cellErr.Header() == "column-2" })
+	assert.Equal(t, 10, ret.TotalRow())
+	assert.Equal(t, []string{"column-1", "column-2"}, ret.Header())
+	assert.Equal(t, 1, ret.TotalRowError())
+	assert.Equal(t, 1, ret.TotalError())
+	rowErr := ret.Unwrap()[0].(*RowErrors) // nolint: errorlint
+	assert.Equal(t, 2, rowErr.Row())
+	assert.Equal(t, 22, rowErr.Line())
+	assert.Equal(t, []error{errCell2}, rowErr.Unwrap())
+	assert.False(t, errors.Is(ret, ErrTypeUnsupported))
+	assert.False(t, errors.Is(ret, errTest3))
+	// Source is not modified
+	assert.Equal(t, 3, errRow2.TotalError())
+
+	ret = e.FilterCells(func(cellErr *CellError) bool { return cellErr.Header() == "column-x" })
+	assert.False(t, ret.HasError())
+	assert.Equal(t, 10, ret.TotalRow())
+}
+
+func TestErrors_GroupByColumn(t *testing.T) {
+	errCell3 := NewCellError(errTest3, 1, "column-2")
+	errRow3 := NewRowErrors(3, 33)
+	errRow3.Add(errCell3)
+
+	e := NewErrors()
+	assert.Equal(t, map[string][]*CellError{}, e.GroupByColumn())
+
+	e.Add(ErrTypeUnsupported, errRow1, errRow2, errRow3)
+	assert.Equal(t, map[string][]*CellError{
+		"column-1": {errCell1},
+		"column-2": {errCell2, errCell3},
+	}, e.GroupByColumn())
+}
+
+func TestErrors_ErrorsByRow(t *testing.T) {
+	e := NewErrors()
+	assert.Equal(t, map[int]*RowErrors{}, e.ErrorsByRow())
+
+	e.Add(ErrTypeUnsupported, errRow1, errRow2)
+	ret := e.ErrorsByRow()
+	assert.Equal(t, map[int]*RowErrors{1: errRow1, 2: errRow2}, ret)
+	// Source is not modified
+	delete(ret, 1)
+	assert.Equal(t, 2, e.TotalRowError())
+	ret[2].Add(errTest1)
+	assert.Equal(t, 4, ret[2].TotalError())
+	assert.Equal(t, 3, errRow2.TotalError())
+	assert.Equal(t, 6, e.TotalError())
+}
+
+func TestErrors_AllCellErrors(t *testing.T) {
+	errCell3 := NewCellError(errTest3, 0, "column-1")
+	errCell4 := NewCellError(errTest3, 1, "column-2")
+	errRow3 := NewRowErrors(3, 33)
+	errRow3.Add(errCell3, errTest1, errCell4)
+
+	e := NewErrors()
+	assert.Nil(t, e.AllCellErrors())
+	assert.Equal(t, map[string][]*CellError{}, e.AllCellErrorsByColumn())
+
+	e.Add(ErrTypeUnsupported, errRow1, errRow2, errRow3)
+	ret := e.AllCellErrors()
+	assert.Equal(t, []*CellError{errCell1, errCell2, errCell3, errCell4}, ret)
+	assert.Equal(t, map[string][]*CellError{
+		"column-1": {errCell1, errCell3},
+		"column-2": {errCell2, errCell4},
+	}, e.AllCellErrorsByColumn())
+	// Source is not modified
+	ret[0] = nil
+	assert.Equal(t, []error{errTest1, errCell1}, errRow1.Unwrap())
+}
+
 func TestRowErrors(t *testing.T) {
 	e := NewRowErrors(1, 11)
 	assert.Equal(t, 1, e.Row())
@@ -77,6 +188,45 @@ func TestRowErrors(t *testing.T) {
 	assert.Equal(t, 1, e.TotalCellError())
 }
 
+func TestRowErrors_CellErrors(t *testing.T) {
+	e := NewRowErrors(1, 11)
+	assert.Nil(t, e.CellErrors())
+	assert.Nil(t, e.CommonErrors())
+	assert.Nil(t, e.CellErrorForColumn(0))
+	assert.False(t, e.HasCellError(0))
+
+	errCell3 := NewCellError(errTest3, 0, "column-1")
+	e.Add(errCell1, errTest1, errCell3, errCell2, errTest2)
+	assert.Equal(t, []*CellError{errCell1, errCell3, errCell2}, e.CellErrors())
+	assert.Equal(t, []error{errTest1, errTest2}, e.CommonErrors())
+	assert.Equal(t, errCell1, e.CellErrorForColumn(0))
+	assert.Equal(t, errCell2, e.CellErrorForColumn(1))
+	assert.Nil(t, e.CellErrorForColumn(2))
+	assert.True(t, e.HasCellError(1))
+	assert.False(t, e.HasCellError(2))
+	// Source is not modified
+	e.CellErrors()[0] = nil
+	e.CommonErrors()[0] = nil
+	assert.Equal(t, []error{errCell1, errTest1, errCell3, errCell2, errTest2}, e.Unwrap())
+}
+
+func TestRowErrors_FirstLast(t *testing.T) {
+	e := NewRowErrors(1, 11)
+	assert.Nil(t, e.First())
+	assert.Nil(t, e.Last())
+
+	e.Add(errCell1)
+	assert.Same(t, errCell1, e.First())
+	assert.Same(t, errCell1, e.Last())
+
+	e.Add(errTest2, errCell2)
+	assert.Same(t, errCell1, e.First())
+	assert.True(t, errors.Is(e.First(), errTest1))
+	assert.Same(t, errCell2, e.Last())
+	assert.True(t, errors.Is(e.Last(), errTest2))
+	assert.False(t, errors.Is(e.Last(), errTest1))
+}
+
 func TestRowErrors_Is(t *testing.T) {
 	e := NewRowErrors(1, 11)
 	assert.False(t, errors.Is(e, errTest1))
@@ -108,6 +258,26 @@ func TestCellError(t *testing.T) {
 	assert.Equal(t, 1, e2.fields["k"])
 }
 
+func TestCellError_SetFields(t *testing.T) {
+	e := NewCellError(errTest1, 1, "column-1")
+	assert.Equal(t, map[string]any{}, e.Fields())
+
+	_ = e.SetFields(nil)
+	assert.Equal(t, map[string]any{}, e.Fields())
+
+	_ = e.WithParam("k1", 1).SetFields(map[string]any{"k2": "v2", "k3": 3}).WithParam("k4", true)
+	assert.Equal(t, map[string]any{"k1": 1, "k2": "v2", "k3": 3, "k4": true}, e.Fields())
+
+	_ = e.SetFields(map[string]any{"k1": 100})
+	assert.Equal(t, map[string]any{"k1": 100, "k2": "v2", "k3": 3, "k4": true}, e.Fields())
+
+	// Modifying the returned map does not affect the error
+	fields := e.Fields()
+	fields["k5"] = 5
+	delete(fields, "k1")
+	assert.Equal(t, map[string]any{"k1": 100, "k2": "v2", "k3": 3, "k4": true}, e.Fields())
+}
+
 func TestCellError_Is(t *testing.T) {
 	assert.False(t, errors.Is(NewCellError(nil, 1, "column-1"), errTest1))
 	assert.False(t, errors.Is(NewCellError(errTest1, 1, "column-1"), errTest2))
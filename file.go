@@ -0,0 +1,73 @@
+package csvlib
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+const (
+	// defaultFileMode mode of the files created by MarshalFile
+	defaultFileMode = os.FileMode(0o644)
+)
+
+// UnmarshalFile convenient method to decode the CSV file at the given path into a slice of structs.
+// The file is read via NewDecoderFromReader, so DecodeConfig.SourceCharset and the other options of it
+// can be used.
+func UnmarshalFile(path string, v any, options ...DecodeOption) (result *DecodeResult, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := f.Close(); closeErr != nil && err == nil {
+			result, err = nil, closeErr
+		}
+	}()
+	return NewDecoderFromReader(bufio.NewReader(f), options...).Decode(v)
+}
+
+// MarshalFile convenient method to encode a slice of structs into a CSV file at the given path.
+// The data are written to a temporary file in the same directory which then replaces the target file,
+// so an interrupted export never leaves a truncated file. The mode of the existing target file is kept.
+func MarshalFile(path string, v any, options ...EncodeOption) (err error) {
+	mode := defaultFileMode
+	if stat, err := os.Stat(path); err == nil {
+		mode = stat.Mode().Perm()
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := f.Name()
+	defer func() {
+		if err != nil {
+			_ = f.Close()
+			_ = os.Remove(tmpPath)
+		}
+	}()
+
+	w := csv.NewWriter(f) // csv.Writer buffers the data itself
+	if err = NewEncoder(w, options...).Encode(v); err != nil {
+		return err
+	}
+	w.Flush()
+	if err = w.Error(); err != nil {
+		return err
+	}
+	if err = f.Chmod(mode); err != nil {
+		return err
+	}
+	if err = f.Sync(); err != nil {
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
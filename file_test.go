@@ -0,0 +1,100 @@
+package csvlib
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_UnmarshalFile(t *testing.T) {
+	type Item struct {
+		Col1 int    `csv:"col1"`
+		Col2 string `csv:"col2"`
+	}
+
+	t.Run("#1: success", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "data.csv")
+		assert.Nil(t, os.WriteFile(path, []byte("col1;col2\n1;a\n2;b"), 0o600))
+
+		var v []Item
+		ret, err := UnmarshalFile(path, &v, func(cfg *DecodeConfig) {
+			cfg.Comma = ';'
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 3, ret.TotalRow())
+		assert.Equal(t, []Item{{Col1: 1, Col2: "a"}, {Col1: 2, Col2: "b"}}, v)
+	})
+
+	t.Run("#2: file not found", func(t *testing.T) {
+		var v []Item
+		_, err := UnmarshalFile(filepath.Join(t.TempDir(), "data.csv"), &v)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("#3: decoding error", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "data.csv")
+		assert.Nil(t, os.WriteFile(path, []byte("col1,col2\nabc,a"), 0o600))
+
+		var v []Item
+		_, err := UnmarshalFile(path, &v)
+		assert.ErrorIs(t, err, ErrDecodeValueType)
+	})
+}
+
+func Test_MarshalFile(t *testing.T) {
+	type Item struct {
+		Col1 int    `csv:"col1"`
+		Col2 string `csv:"col2"`
+	}
+
+	t.Run("#1: success", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "data.csv")
+		err := MarshalFile(path, []Item{{Col1: 1, Col2: "a"}, {Col1: 2, Col2: "b"}})
+		assert.Nil(t, err)
+		data, err := os.ReadFile(path)
+		assert.Nil(t, err)
+		assert.Equal(t, "col1,col2\n1,a\n2,b\n", string(data))
+		stat, err := os.Stat(path)
+		assert.Nil(t, err)
+		assert.Equal(t, defaultFileMode, stat.Mode().Perm())
+	})
+
+	t.Run("#2: replace existing file and keep its mode", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "data.csv")
+		assert.Nil(t, os.WriteFile(path, []byte("old data"), 0o600))
+
+		err := MarshalFile(path, []Item{{Col1: 1, Col2: "a"}})
+		assert.Nil(t, err)
+		data, err := os.ReadFile(path)
+		assert.Nil(t, err)
+		assert.Equal(t, "col1,col2\n1,a\n", string(data))
+		stat, err := os.Stat(path)
+		assert.Nil(t, err)
+		assert.Equal(t, os.FileMode(0o600), stat.Mode().Perm())
+	})
+
+	t.Run("#3: encoding error keeps the existing file", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "data.csv")
+		assert.Nil(t, os.WriteFile(path, []byte("old data"), 0o600))
+
+		err := MarshalFile(path, []Item{{Col1: 1}}, func(cfg *EncodeConfig) {
+			cfg.Comma = '"'
+		})
+		assert.ErrorIs(t, err, ErrConfigOptionInvalid)
+		data, err := os.ReadFile(path)
+		assert.Nil(t, err)
+		assert.Equal(t, "old data", string(data))
+		// The temporary file is removed
+		entries, err := os.ReadDir(dir)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(entries))
+	})
+
+	t.Run("#4: directory not found", func(t *testing.T) {
+		err := MarshalFile(filepath.Join(t.TempDir(), "sub", "data.csv"), []Item{})
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
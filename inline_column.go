@@ -20,6 +20,24 @@ type InlineColumn[T any] struct {
 	Values []T
 }
 
+// dynamicInlineColumnElemType gets the element type of the given dynamic inline column type, a dynamic inline
+// column type is a struct having field `Header` of type `[]string` and field `Values` of a slice type
+func dynamicInlineColumnElemType(typ reflect.Type) (elemType reflect.Type, ok bool) {
+	typ = indirectType(typ)
+	if typ.Kind() != reflect.Struct {
+		return nil, false
+	}
+	headerField, ok := typ.FieldByName(dynamicInlineColumnHeader)
+	if !ok || headerField.Type != reflect.TypeOf([]string{}) {
+		return nil, false
+	}
+	valuesField, ok := typ.FieldByName(dynamicInlineColumnValues)
+	if !ok || valuesField.Type.Kind() != reflect.Slice {
+		return nil, false
+	}
+	return valuesField.Type.Elem(), true
+}
+
 // inlineColumnMeta metadata of inline columns
 type inlineColumnMeta struct {
 	headerText  []string
@@ -51,7 +69,7 @@ func (m *inlineColumnMeta) decodeInitInlineStruct(inlineStruct reflect.Value) {
 		numCols := len(m.headerText)
 		inlineStruct.FieldByName(dynamicInlineColumnHeader).Set(reflect.ValueOf(m.headerText))
 
-		columnValues := inlineStruct.Field(m.targetField.Index[0])
+		columnValues := fieldByIndexInit(inlineStruct, m.targetField.Index)
 		columnValues.Set(reflect.MakeSlice(reflect.SliceOf(m.dataType), numCols, numCols))
 		m.columnCurrIndex = 0
 	}
@@ -65,12 +83,12 @@ func (m *inlineColumnMeta) decodeGetColumnValue(inlineStruct reflect.Value) refl
 
 	switch m.inlineType {
 	case inlineColumnStructFixed:
-		return inlineStruct.Field(m.targetField.Index[0])
+		return fieldByIndexInit(inlineStruct, m.targetField.Index)
 	case inlineColumnStructDynamic:
 		if m.columnCurrIndex == -1 {
 			m.decodeInitInlineStruct(inlineStruct)
 		}
-		colVal := inlineStruct.Field(m.targetField.Index[0]).Index(m.columnCurrIndex)
+		colVal := fieldByIndexInit(inlineStruct, m.targetField.Index).Index(m.columnCurrIndex)
 		m.columnCurrIndex++
 		return colVal
 	}
@@ -91,9 +109,13 @@ func (m *inlineColumnMeta) encodeGetColumnValue(inlineStruct reflect.Value) refl
 
 	switch m.inlineType {
 	case inlineColumnStructFixed:
-		return inlineStruct.Field(m.targetField.Index[0])
+		return fieldByIndex(inlineStruct, m.targetField.Index)
 	case inlineColumnStructDynamic:
-		colVal := inlineStruct.Field(m.targetField.Index[0]).Index(m.columnCurrIndex)
+		values := fieldByIndex(inlineStruct, m.targetField.Index)
+		if !values.IsValid() {
+			return values
+		}
+		colVal := values.Index(m.columnCurrIndex)
 		m.columnCurrIndex++
 		return colVal
 	}
@@ -1,7 +1,10 @@
 package csvlib
 
 import (
+	"encoding/base64"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/tiendc/gofn"
 )
@@ -36,3 +39,120 @@ func ProcessorNumberGroupComma(s string) string {
 func ProcessorNumberUngroupComma(s string) string {
 	return gofn.NumberFmtUngroup(s, ',')
 }
+
+// ProcessorBase64Encode encodes a string with the standard base64 encoding
+func ProcessorBase64Encode(s string) string {
+	return base64.StdEncoding.EncodeToString([]byte(s))
+}
+
+// ProcessorBase64Decode decodes a string encoded with the standard base64 encoding, with or without padding.
+// If the string is not valid base64, it is returned unchanged.
+func ProcessorBase64Decode(s string) string {
+	return processorBase64Decode(s, base64.StdEncoding, base64.RawStdEncoding)
+}
+
+// ProcessorBase64URLEncode encodes a string with the URL-safe base64 encoding
+func ProcessorBase64URLEncode(s string) string {
+	return base64.URLEncoding.EncodeToString([]byte(s))
+}
+
+// ProcessorBase64URLDecode decodes a string encoded with the URL-safe base64 encoding, with or without padding.
+// If the string is not valid base64, it is returned unchanged.
+func ProcessorBase64URLDecode(s string) string {
+	return processorBase64Decode(s, base64.URLEncoding, base64.RawURLEncoding)
+}
+
+func processorBase64Decode(s string, encodings ...*base64.Encoding) string {
+	for _, enc := range encodings {
+		if b, err := enc.DecodeString(s); err == nil {
+			return string(b)
+		}
+	}
+	return s
+}
+
+// ProcessorRegexReplace replaces the first `n` matches of the regex pattern in a string.
+// If n < 0, there is no limit on the number of replacements. Inside the replacement,
+// `$1` or `${name}` refers to the corresponding group of the match.
+// This func panics if the pattern is invalid, use ProcessorRegexReplaceE to get the error instead.
+func ProcessorRegexReplace(pattern, replacement string, n int) ProcessorFunc {
+	return processorRegexReplace(regexp.MustCompile(pattern), replacement, n)
+}
+
+// ProcessorRegexReplaceE replaces the first `n` matches of the regex pattern in a string.
+// This func returns an error if the pattern is invalid.
+func ProcessorRegexReplaceE(pattern, replacement string, n int) (ProcessorFunc, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return processorRegexReplace(re, replacement, n), nil
+}
+
+// ProcessorRegexReplaceAll replaces all matches of the regex pattern in a string.
+// This func panics if the pattern is invalid, use ProcessorRegexReplaceAllE to get the error instead.
+func ProcessorRegexReplaceAll(pattern, replacement string) ProcessorFunc {
+	return processorRegexReplace(regexp.MustCompile(pattern), replacement, -1)
+}
+
+// ProcessorRegexReplaceAllE replaces all matches of the regex pattern in a string.
+// This func returns an error if the pattern is invalid.
+func ProcessorRegexReplaceAllE(pattern, replacement string) (ProcessorFunc, error) {
+	return ProcessorRegexReplaceE(pattern, replacement, -1)
+}
+
+func processorRegexReplace(re *regexp.Regexp, replacement string, n int) ProcessorFunc {
+	if n < 0 {
+		return func(s string) string {
+			return re.ReplaceAllString(s, replacement)
+		}
+	}
+	return func(s string) string {
+		matches := re.FindAllStringSubmatchIndex(s, n)
+		if len(matches) == 0 {
+			return s
+		}
+		result := make([]byte, 0, len(s))
+		last := 0
+		for _, match := range matches {
+			result = append(result, s[last:match[0]]...)
+			result = re.ExpandString(result, replacement, s, match)
+			last = match[1]
+		}
+		return string(append(result, s[last:]...))
+	}
+}
+
+// ProcessorDateFormat converts a date string from a layout to another one, e.g. from `02/01/2006`
+// to `2006-01-02`. The wall clock and the zone offset of the parsed time are kept, a date parsed
+// without zone information is in UTC. If the string can't be parsed, it is returned unchanged.
+func ProcessorDateFormat(fromLayout, toLayout string) ProcessorFunc {
+	return func(s string) string {
+		t, err := time.Parse(fromLayout, s)
+		if err != nil {
+			return s
+		}
+		return t.Format(toLayout)
+	}
+}
+
+// ProcessorChain combines the given processor functions into one which applies them in order
+func ProcessorChain(fns ...ProcessorFunc) ProcessorFunc {
+	return func(s string) string {
+		for _, fn := range fns {
+			s = fn(s)
+		}
+		return s
+	}
+}
+
+// ProcessorIf applies the processor function only when the condition is satisfied,
+// otherwise the string is returned unchanged
+func ProcessorIf(cond func(string) bool, fn ProcessorFunc) ProcessorFunc {
+	return func(s string) string {
+		if !cond(s) {
+			return s
+		}
+		return fn(s)
+	}
+}
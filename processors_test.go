@@ -1,7 +1,9 @@
 package csvlib
 
 import (
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -68,3 +70,100 @@ func Test_ProcessorNumberUngroupComma(t *testing.T) {
 	assert.Equal(t, "123", ProcessorNumberUngroupComma("123"))
 	assert.Equal(t, "1234567.8", ProcessorNumberUngroupComma("12,3456,7.8"))
 }
+
+func Test_ProcessorRegexReplace(t *testing.T) {
+	assert.Equal(t, "", ProcessorRegexReplace(`\d`, "x", 1)(""))
+	assert.Equal(t, "abc", ProcessorRegexReplace(`\d`, "x", 1)("abc"))
+	assert.Equal(t, "ax1b2", ProcessorRegexReplace(`\d`, "x", 1)("a01b2"))
+	assert.Equal(t, "axxbx", ProcessorRegexReplace(`\d`, "x", 3)("a01b2"))
+	assert.Equal(t, "axxbx", ProcessorRegexReplace(`\d`, "x", -1)("a01b2"))
+	assert.Equal(t, "a01b2", ProcessorRegexReplace(`\d`, "x", 0)("a01b2"))
+	assert.Equal(t, "東京-tokyo", ProcessorRegexReplace(`\p{Han}+`, "東京", 1)("大阪-tokyo"))
+	assert.Equal(t, "2024/01/31 2024-02-29", ProcessorRegexReplace(
+		`(\d{4})-(\d{2})-(\d{2})`, "$1/$2/$3", 1)("2024-01-31 2024-02-29"))
+	assert.Equal(t, "31.01.2024", ProcessorRegexReplace(
+		`(?P<y>\d{4})-(?P<m>\d{2})-(?P<d>\d{2})`, "${d}.${m}.${y}", 1)("2024-01-31"))
+	assert.Panics(t, func() { ProcessorRegexReplace(`(abc`, "", 1) })
+
+	fn, err := ProcessorRegexReplaceE(`[$€,]`, "", 2)
+	assert.Nil(t, err)
+	assert.Equal(t, "1000,000.5", fn("$1,000,000.5"))
+	fn, err = ProcessorRegexReplaceE(`[a-`, "", 1)
+	assert.NotNil(t, err)
+	assert.Nil(t, fn)
+}
+
+func Test_ProcessorRegexReplaceAll(t *testing.T) {
+	assert.Equal(t, "", ProcessorRegexReplaceAll(`<[^>]*>`, "")(""))
+	assert.Equal(t, "abc", ProcessorRegexReplaceAll(`<[^>]*>`, "")("abc"))
+	assert.Equal(t, "bold and italic", ProcessorRegexReplaceAll(`<[^>]*>`, "")("<b>bold</b> and <i>italic</i>"))
+	assert.Equal(t, "1000000.5", ProcessorRegexReplaceAll(`[$€,]`, "")("€1,000,000.5"))
+	assert.Equal(t, "[東][京]", ProcessorRegexReplaceAll(`(\p{Han})`, "[$1]")("東京"))
+	assert.Panics(t, func() { ProcessorRegexReplaceAll(`a)`, "") })
+
+	fn, err := ProcessorRegexReplaceAllE(`\s+`, " ")
+	assert.Nil(t, err)
+	assert.Equal(t, "a b c", fn("a  b \t c"))
+	fn, err = ProcessorRegexReplaceAllE(`(`, "")
+	assert.NotNil(t, err)
+	assert.Nil(t, fn)
+}
+
+func Test_ProcessorDateFormat(t *testing.T) {
+	toISO := ProcessorDateFormat("02/01/2006", "2006-01-02")
+	fromISO := ProcessorDateFormat("2006-01-02", "02/01/2006")
+	assert.Equal(t, "2023-12-31", toISO("31/12/2023"))
+	assert.Equal(t, "31/12/2023", fromISO(toISO("31/12/2023")))
+	assert.Equal(t, "32/12/2023", toISO("32/12/2023"))
+	assert.Equal(t, "2023-12-31", toISO("2023-12-31"))
+	assert.Equal(t, "", toISO(""))
+
+	// Zone offset of the input is kept
+	assert.Equal(t, "2024-01-02 03:04 +0900",
+		ProcessorDateFormat(time.RFC3339, "2006-01-02 15:04 -0700")("2024-01-02T03:04:05+09:00"))
+	// Input without zone information is in UTC
+	assert.Equal(t, "2024-01-02T03:04:00Z",
+		ProcessorDateFormat("2006-01-02 15:04", time.RFC3339)("2024-01-02 03:04"))
+	assert.Equal(t, "2024-01-02", ProcessorDateFormat(time.RFC3339, "2006-01-02")("2024-01-02T23:30:00-05:00"))
+}
+
+func Test_ProcessorBase64(t *testing.T) {
+	assert.Equal(t, "", ProcessorBase64Encode(""))
+	assert.Equal(t, "", ProcessorBase64Decode(""))
+	assert.Equal(t, "YWI=", ProcessorBase64Encode("ab"))
+	assert.Equal(t, "ab", ProcessorBase64Decode("YWI="))
+	assert.Equal(t, "ab", ProcessorBase64Decode("YWI"))
+	assert.Equal(t, "\xff\xfe\x00", ProcessorBase64Decode(ProcessorBase64Encode("\xff\xfe\x00")))
+	assert.Equal(t, "//8=", ProcessorBase64Encode("\xff\xff"))
+	assert.Equal(t, "YW=I", ProcessorBase64Decode("YW=I"))
+	assert.Equal(t, "__8=", ProcessorBase64Decode("__8="))
+}
+
+func Test_ProcessorBase64URL(t *testing.T) {
+	assert.Equal(t, "", ProcessorBase64URLEncode(""))
+	assert.Equal(t, "", ProcessorBase64URLDecode(""))
+	assert.Equal(t, "__8=", ProcessorBase64URLEncode("\xff\xff"))
+	assert.Equal(t, "\xff\xff", ProcessorBase64URLDecode("__8="))
+	assert.Equal(t, "\xff\xff", ProcessorBase64URLDecode("__8"))
+	assert.Equal(t, "//8=", ProcessorBase64URLDecode("//8="))
+	assert.Equal(t, "abc!", ProcessorBase64URLDecode("abc!"))
+}
+
+func Test_ProcessorChain(t *testing.T) {
+	appendX := func(s string) string { return s + "x" }
+	fn := ProcessorChain(ProcessorTrim, ProcessorUpper, appendX)
+	assert.Equal(t, "ABCx", fn("  abc "))
+	assert.Equal(t, "x", fn(""))
+	// Order is preserved
+	assert.Equal(t, "ABCX", ProcessorChain(appendX, ProcessorUpper)("abc"))
+	// Empty chain is identity
+	assert.Equal(t, " abc ", ProcessorChain()(" abc "))
+}
+
+func Test_ProcessorIf(t *testing.T) {
+	isNumber := func(s string) bool { return regexp.MustCompile(`^[0-9,]+$`).MatchString(s) }
+	fn := ProcessorIf(isNumber, ProcessorNumberUngroupComma)
+	assert.Equal(t, "1234567", fn("1,234,567"))
+	assert.Equal(t, "a,b", fn("a,b"))
+	assert.Equal(t, "", fn(""))
+}
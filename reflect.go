@@ -17,6 +17,19 @@ func isKindOrPtrOf(t reflect.Type, kinds ...reflect.Kind) bool {
 	return false
 }
 
+// isNumberType checks if the type is an integer or float type, or a pointer to one of them
+func isNumberType(t reflect.Type) bool {
+	return isKindOrPtrOf(t, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64)
+}
+
+// isSQLNullType checks if the type is one of the nullable types of package `database/sql`
+func isSQLNullType(t reflect.Type) bool {
+	_, ok := sqlNullTypes[t]
+	return ok
+}
+
 func indirectType(t reflect.Type) reflect.Type {
 	for t.Kind() == reflect.Pointer {
 		return t.Elem()
@@ -31,6 +44,20 @@ func indirectValue(v reflect.Value) reflect.Value {
 	return v
 }
 
+// isNilValue checks if the value is a nil pointer or a nil interface, or an interface holding a nil pointer
+func isNilValue(v reflect.Value) bool {
+	for v.Kind() == reflect.Interface || v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return true
+		}
+		if v.Kind() == reflect.Pointer {
+			return false
+		}
+		v = v.Elem()
+	}
+	return false
+}
+
 func initAndIndirectValue(v reflect.Value) reflect.Value {
 	if v.Kind() == reflect.Pointer {
 		if v.IsNil() {
@@ -41,3 +68,28 @@ func initAndIndirectValue(v reflect.Value) reflect.Value {
 	}
 	return v
 }
+
+// fieldByIndexInit gets the nested field by index, nil embedded pointers are initialized on the way
+func fieldByIndexInit(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 {
+			v = initAndIndirectValue(v)
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+// fieldByIndex gets the nested field by index, returns an invalid value when a nil embedded pointer is met
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
@@ -38,3 +38,24 @@ func Test_initAndIndirectValue(t *testing.T) {
 	s := []*string{nil}
 	assert.Equal(t, "", initAndIndirectValue(reflect.ValueOf(s).Index(0)).String())
 }
+
+func Test_fieldByIndex(t *testing.T) {
+	type Inner struct {
+		Val string
+	}
+	type Middle struct {
+		*Inner
+	}
+	type Outer struct {
+		ID int
+		Middle
+	}
+	index := []int{1, 0, 0}
+
+	v := Outer{}
+	assert.False(t, fieldByIndex(reflect.ValueOf(&v).Elem(), index).IsValid())
+	fieldByIndexInit(reflect.ValueOf(&v).Elem(), index).SetString("abc")
+	assert.Equal(t, "abc", v.Inner.Val)
+	assert.Equal(t, "abc", fieldByIndex(reflect.ValueOf(v), index).String())
+	assert.Equal(t, int64(0), fieldByIndex(reflect.ValueOf(v), []int{0}).Int())
+}
@@ -0,0 +1,103 @@
+package csvlib
+
+import (
+	"reflect"
+	"sync"
+)
+
+var (
+	registryMu         sync.RWMutex
+	decodeFuncRegistry = map[reflect.Type]DecodeFunc{}
+	encodeFuncRegistry = map[reflect.Type]EncodeFunc{}
+)
+
+// RegisterDecodeFunc registers the decode function for all fields of the given type, e.g. to decode
+// third-party types without implementing CSVUnmarshaler for them. Fields of pointer to the given type
+// are also decoded by the function. The functions set via DecodeColumnConfig.DecodeFunc and
+// DecodeConfig.TypeDecodeFuncs take precedence.
+// Passing a nil function removes the registration. This func is safe for concurrent use, but the
+// registration only affects the decoders prepared after it.
+func RegisterDecodeFunc(typ reflect.Type, fn DecodeFunc) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if fn == nil {
+		delete(decodeFuncRegistry, typ)
+		return
+	}
+	decodeFuncRegistry[typ] = fn
+}
+
+// RegisterEncodeFunc registers the encode function for all fields of the given type.
+// Fields of pointer to the given type are also encoded by the function, nil pointers are encoded as empty.
+// The functions set via EncodeColumnConfig.EncodeFunc and EncodeConfig.TypeEncodeFuncs take precedence.
+// Passing a nil function removes the registration. This func is safe for concurrent use, but the
+// registration only affects the encoders prepared after it.
+func RegisterEncodeFunc(typ reflect.Type, fn EncodeFunc) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if fn == nil {
+		delete(encodeFuncRegistry, typ)
+		return
+	}
+	encodeFuncRegistry[typ] = fn
+}
+
+// ClearRegisteredFuncs removes all the registered decode and encode functions
+func ClearRegisteredFuncs() {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	decodeFuncRegistry = map[reflect.Type]DecodeFunc{}
+	encodeFuncRegistry = map[reflect.Type]EncodeFunc{}
+}
+
+// getRegisteredDecodeFunc gets the registered decode function for the type or the type it points to
+func getRegisteredDecodeFunc(typ reflect.Type) DecodeFunc {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	return findDecodeFunc(decodeFuncRegistry, typ)
+}
+
+// getRegisteredEncodeFunc gets the registered encode function for the type or the type it points to
+func getRegisteredEncodeFunc(typ reflect.Type) EncodeFunc {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	return findEncodeFunc(encodeFuncRegistry, typ)
+}
+
+// findDecodeFunc finds the decode function for the type or the type it points to in the given map
+func findDecodeFunc(funcs map[reflect.Type]DecodeFunc, typ reflect.Type) DecodeFunc {
+	if fn, ok := funcs[typ]; ok {
+		return fn
+	}
+	if typ.Kind() != reflect.Pointer {
+		return nil
+	}
+	fn, ok := funcs[typ.Elem()]
+	if !ok {
+		return nil
+	}
+	return func(s string, v reflect.Value) error {
+		return fn(s, initAndIndirectValue(v))
+	}
+}
+
+// findEncodeFunc finds the encode function for the type or the type it points to in the given map
+func findEncodeFunc(funcs map[reflect.Type]EncodeFunc, typ reflect.Type) EncodeFunc {
+	if fn, ok := funcs[typ]; ok {
+		return fn
+	}
+	if typ.Kind() != reflect.Pointer {
+		return nil
+	}
+	fn, ok := funcs[typ.Elem()]
+	if !ok {
+		return nil
+	}
+	return func(v reflect.Value, omitempty bool) (string, error) {
+		v = v.Elem()
+		if !v.IsValid() {
+			return "", nil
+		}
+		return fn(v, omitempty)
+	}
+}
@@ -0,0 +1,132 @@
+package csvlib
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tiendc/gofn"
+)
+
+type registryPoint struct {
+	X, Y int
+}
+
+func decodeRegistryPoint(s string, v reflect.Value) error {
+	var p registryPoint
+	if _, err := fmt.Sscanf(s, "%d:%d", &p.X, &p.Y); err != nil {
+		return fmt.Errorf("%w: %s", ErrDecodeValueType, s)
+	}
+	v.Set(reflect.ValueOf(p))
+	return nil
+}
+
+func encodeRegistryPoint(v reflect.Value, _ bool) (string, error) {
+	p := v.Interface().(registryPoint) // nolint: forcetypeassert
+	return fmt.Sprintf("%d:%d", p.X, p.Y), nil
+}
+
+func Test_RegisterDecodeFunc(t *testing.T) {
+	type Item struct {
+		Col1 registryPoint   `csv:"col1"`
+		Col2 *registryPoint  `csv:"col2,omitempty"`
+		Col3 []registryPoint `csv:"col3,sep=;"`
+	}
+	data := gofn.MultilineString(
+		`col1,col2,col3
+		1:2,3:4,5:6;7:8
+		0:0,,`)
+
+	t.Run("#1: type not registered", func(t *testing.T) {
+		var v []Item
+		_, err := makeDecoder(data).Decode(&v)
+		assert.ErrorIs(t, err, ErrTypeUnsupported)
+	})
+
+	t.Run("#2: registered func", func(t *testing.T) {
+		RegisterDecodeFunc(reflect.TypeOf(registryPoint{}), decodeRegistryPoint)
+		t.Cleanup(ClearRegisteredFuncs)
+
+		var v []Item
+		_, err := makeDecoder(data).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Item{
+			{Col1: registryPoint{1, 2}, Col2: &registryPoint{3, 4}, Col3: []registryPoint{{5, 6}, {7, 8}}},
+			{},
+		}, v)
+	})
+
+	t.Run("#3: column config takes precedence", func(t *testing.T) {
+		RegisterDecodeFunc(reflect.TypeOf(registryPoint{}), decodeRegistryPoint)
+		t.Cleanup(ClearRegisteredFuncs)
+
+		var v []Item
+		_, err := makeDecoder(data, func(cfg *DecodeConfig) {
+			cfg.ConfigureColumn("col1", func(cfg *DecodeColumnConfig) {
+				cfg.DecodeFunc = func(s string, v reflect.Value) error {
+					v.Set(reflect.ValueOf(registryPoint{X: len(s)}))
+					return nil
+				}
+			})
+		}).Decode(&v)
+		assert.Nil(t, err)
+		assert.Equal(t, registryPoint{X: 3}, v[0].Col1)
+		assert.Equal(t, &registryPoint{3, 4}, v[0].Col2)
+	})
+
+	t.Run("#4: remove registration", func(t *testing.T) {
+		RegisterDecodeFunc(reflect.TypeOf(registryPoint{}), decodeRegistryPoint)
+		RegisterDecodeFunc(reflect.TypeOf(registryPoint{}), nil)
+
+		var v []Item
+		_, err := makeDecoder(data).Decode(&v)
+		assert.ErrorIs(t, err, ErrTypeUnsupported)
+	})
+}
+
+func Test_RegisterEncodeFunc(t *testing.T) {
+	type Item struct {
+		Col1 registryPoint   `csv:"col1"`
+		Col2 *registryPoint  `csv:"col2"`
+		Col3 []registryPoint `csv:"col3,sep=;"`
+	}
+	v := []Item{
+		{Col1: registryPoint{1, 2}, Col2: &registryPoint{3, 4}, Col3: []registryPoint{{5, 6}, {7, 8}}},
+		{},
+	}
+
+	t.Run("#1: type not registered", func(t *testing.T) {
+		_, err := doEncode(v)
+		assert.ErrorIs(t, err, ErrTypeUnsupported)
+	})
+
+	t.Run("#2: registered func", func(t *testing.T) {
+		RegisterEncodeFunc(reflect.TypeOf(registryPoint{}), encodeRegistryPoint)
+		t.Cleanup(ClearRegisteredFuncs)
+
+		data, err := doEncode(v)
+		assert.Nil(t, err)
+		assert.Equal(t, gofn.MultilineString(
+			`col1,col2,col3
+			1:2,3:4,5:6;7:8
+			0:0,,
+			`), string(data))
+	})
+
+	t.Run("#3: concurrent registration", func(t *testing.T) {
+		t.Cleanup(ClearRegisteredFuncs)
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				RegisterEncodeFunc(reflect.TypeOf(registryPoint{}), encodeRegistryPoint)
+				_, err := doEncode(v)
+				assert.Nil(t, err)
+			}()
+		}
+		wg.Wait()
+	})
+}
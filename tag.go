@@ -3,17 +3,33 @@ package csvlib
 import (
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 )
 
+const (
+	// intBaseAuto base of integers set via tag option `base=0`, the base is implied by the prefix
+	// of the cell text (`0x`, `0o`, `0b` or `0`) as strconv.ParseInt does
+	intBaseAuto = -1
+)
+
 type tagDetail struct {
 	name      string
 	prefix    string
+	aliases   []string
+	format    string
+	sep       string
+	base      int
+	defValue  string
 	ignored   bool
 	empty     bool
+	unnamed   bool
 	omitEmpty bool
 	optional  bool
+	required  bool
 	inline    bool
+	rest      bool
+	index     int
 }
 
 func parseTag(tagName string, field reflect.StructField) (*tagDetail, error) {
@@ -22,17 +38,19 @@ func parseTag(tagName string, field reflect.StructField) (*tagDetail, error) {
 		return nil, nil
 	}
 
-	tag := &tagDetail{}
+	tag := &tagDetail{index: -1}
 	tags := strings.Split(tagValue, ",")
 	if len(tags) == 1 && tags[0] == "" {
 		tag.name = field.Name
 		tag.empty = true
+		tag.unnamed = true
 	} else {
 		switch tags[0] {
 		case "-":
 			tag.ignored = true
 		case "":
 			tag.name = field.Name
+			tag.unnamed = true
 		default:
 			tag.name = tags[0]
 		}
@@ -41,28 +59,162 @@ func parseTag(tagName string, field reflect.StructField) (*tagDetail, error) {
 			switch {
 			case tagOpt == "optional":
 				tag.optional = true
+			case tagOpt == "required":
+				tag.required = true
 			case tagOpt == "omitempty":
 				tag.omitEmpty = true
 			case tagOpt == "inline":
 				tag.inline = true
+			case tagOpt == "rest":
+				tag.rest = true
 			case strings.HasPrefix(tagOpt, "prefix="):
 				tag.prefix = tagOpt[len("prefix="):]
+			case strings.HasPrefix(tagOpt, "format="):
+				tag.format = tagOpt[len("format="):]
+			case strings.HasPrefix(tagOpt, "default="):
+				tag.defValue = tagOpt[len("default="):]
+			case strings.HasPrefix(tagOpt, "sep="):
+				tag.sep = tagOpt[len("sep="):]
+			case strings.HasPrefix(tagOpt, "base="):
+				base, err := strconv.Atoi(tagOpt[len("base="):])
+				if err != nil || base == 1 || base < 0 || base > 36 {
+					return nil, fmt.Errorf("%w: base tag must be 0 or an integer from 2 to 36", ErrTagOptionInvalid)
+				}
+				tag.base = base
+				if base == 0 {
+					tag.base = intBaseAuto
+				}
+			case strings.HasPrefix(tagOpt, "aliases="):
+				tag.aliases = append(tag.aliases, strings.Split(tagOpt[len("aliases="):], "|")...)
+			case strings.HasPrefix(tagOpt, "alias="):
+				tag.aliases = append(tag.aliases, strings.Split(tagOpt[len("alias="):], ";")...)
+			case strings.HasPrefix(tagOpt, "index="):
+				index, err := strconv.Atoi(tagOpt[len("index="):])
+				if err != nil || index < 0 {
+					return nil, fmt.Errorf("%w: index tag must be a non-negative integer", ErrTagOptionInvalid)
+				}
+				tag.index = index
 			}
 		}
 	}
 
+	embedded := field.Anonymous && tag.unnamed
 	// Validation: struct field unexported
-	if !tag.ignored && !field.IsExported() {
+	if !tag.ignored && !field.IsExported() && !embedded {
 		return nil, fmt.Errorf("%w: struct field %s unexported", ErrTagOptionInvalid, field.Name)
 	}
-	// Validation: only inline column can have prefix
-	if tag.prefix != "" && !tag.inline {
+	// Validation: only inline column or embedded struct can have prefix
+	if tag.prefix != "" && !tag.inline && !embedded {
 		return nil, fmt.Errorf("%w: prefix tag is only accepted for inline column", ErrTagOptionInvalid)
 	}
 	// Validation: inline column must not be optional
 	if tag.inline && tag.optional {
 		return nil, fmt.Errorf("%w: inline column must not be optional", ErrTagOptionInvalid)
 	}
+	// Validation: inline column must not have aliases
+	if tag.inline && len(tag.aliases) > 0 {
+		return nil, fmt.Errorf("%w: aliases tag is not accepted for inline column", ErrTagOptionInvalid)
+	}
+	// Validation: inline column must not have index
+	if tag.inline && tag.index >= 0 {
+		return nil, fmt.Errorf("%w: index tag is not accepted for inline column", ErrTagOptionInvalid)
+	}
+	// Validation: rest column must not be inline
+	if tag.rest && tag.inline {
+		return nil, fmt.Errorf("%w: rest column must not be inline", ErrTagOptionInvalid)
+	}
+	// Validation: inline column must not have separator
+	if tag.inline && tag.sep != "" {
+		return nil, fmt.Errorf("%w: sep tag is not accepted for inline column", ErrTagOptionInvalid)
+	}
+	// Validation: inline column must not have default value
+	if tag.inline && tag.defValue != "" {
+		return nil, fmt.Errorf("%w: default tag is not accepted for inline column", ErrTagOptionInvalid)
+	}
+	// Validation: inline column must not have base
+	if tag.inline && tag.base != 0 {
+		return nil, fmt.Errorf("%w: base tag is not accepted for inline column", ErrTagOptionInvalid)
+	}
 
 	return tag, nil
 }
+
+// embeddedStruct details of an embedded struct whose fields are promoted as columns of the parent struct
+type embeddedStruct struct {
+	index     []int
+	prefix    string
+	optional  bool
+	omitEmpty bool
+}
+
+// isEmbeddedStructField checks if the field is an embedded struct whose fields should be promoted.
+// Embedded struct fields having explicit column names are treated as normal columns.
+func isEmbeddedStructField(field reflect.StructField, tag *tagDetail) bool {
+	if !field.Anonymous || indirectType(field.Type).Kind() != reflect.Struct {
+		return false
+	}
+	// Pointer of unexported struct type can't be initialized
+	if field.Type.Kind() == reflect.Pointer && !field.IsExported() {
+		return false
+	}
+	return tag == nil || (tag.unnamed && !tag.ignored && !tag.inline && !tag.rest)
+}
+
+// embed builds details of the embedded struct of the given field.
+// Options `prefix`, `optional` and `omitempty` set on the field apply to all of the promoted fields.
+func (e *embeddedStruct) embed(field reflect.StructField, tag *tagDetail) *embeddedStruct {
+	embedded := &embeddedStruct{
+		index:     field.Index,
+		prefix:    e.prefix,
+		optional:  e.optional,
+		omitEmpty: e.omitEmpty,
+	}
+	if tag != nil {
+		embedded.prefix += tag.prefix
+		embedded.optional = embedded.optional || tag.optional
+		embedded.omitEmpty = embedded.omitEmpty || tag.omitEmpty
+	}
+	return embedded
+}
+
+// structField gets the field of the embedded struct with the full index from the root struct
+func (e *embeddedStruct) structField(typ reflect.Type, i int) reflect.StructField {
+	field := typ.Field(i)
+	field.Index = append(append(make([]int, 0, len(e.index)+1), e.index...), field.Index...)
+	return field
+}
+
+// parseFloatFormat parses the tag option `format=` of float columns, the format consists of
+// an optional precision and a format accepted by strconv.FormatFloat, e.g. `.2f`, `e` or `.3g`.
+// When the precision is not set, floatPrecisionInherit is returned.
+func parseFloatFormat(format string) (fmtByte byte, precision int, ok bool) {
+	if format == "" || !isFloatFormatValid(format[len(format)-1]) {
+		return 0, 0, false
+	}
+	fmtByte, precision = format[len(format)-1], floatPrecisionInherit
+	if precisionStr := format[:len(format)-1]; precisionStr != "" {
+		if precisionStr[0] != '.' {
+			return 0, 0, false
+		}
+		p, err := strconv.Atoi(precisionStr[1:])
+		if err != nil || p < 0 {
+			return 0, 0, false
+		}
+		precision = p
+	}
+	return fmtByte, precision, true
+}
+
+// validateFormatTag checks the tag option `format=` is applicable to the type. This is called
+// after time and duration types are handled, so only float types accept the option.
+func validateFormatTag(typ reflect.Type, format string) error {
+	if format == "" {
+		return nil
+	}
+	if isKindOrPtrOf(typ, reflect.Float32, reflect.Float64) {
+		if _, _, ok := parseFloatFormat(format); ok {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: format=%s not accepted for type %v", ErrTagOptionInvalid, format, typ)
+}
@@ -3,6 +3,7 @@ package csvlib
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -58,4 +59,164 @@ func Test_parseTag(t *testing.T) {
 	col7, _ := structType.FieldByName("col7")
 	_, err = parseTag(DefaultTagName, col7)
 	assert.ErrorIs(t, err, ErrTagOptionInvalid)
+
+	type Item2 struct {
+		Col1 int               `csv:"col1,index=3"`
+		Col2 int               `csv:"col2,index=x"`
+		Col3 int               `csv:"col3,index=-1"`
+		Col4 InlineColumn[int] `csv:"col4,inline,index=1"`
+	}
+	structType2 := reflect.TypeOf(Item2{})
+
+	col21, _ := structType2.FieldByName("Col1")
+	tag21, err := parseTag(DefaultTagName, col21)
+	assert.Nil(t, err)
+	assert.True(t, tag21.name == "col1" && tag21.index == 3)
+	assert.Equal(t, -1, tag1.index)
+
+	col22, _ := structType2.FieldByName("Col2")
+	_, err = parseTag(DefaultTagName, col22)
+	assert.ErrorIs(t, err, ErrTagOptionInvalid)
+
+	col23, _ := structType2.FieldByName("Col3")
+	_, err = parseTag(DefaultTagName, col23)
+	assert.ErrorIs(t, err, ErrTagOptionInvalid)
+
+	col24, _ := structType2.FieldByName("Col4")
+	_, err = parseTag(DefaultTagName, col24)
+	assert.ErrorIs(t, err, ErrTagOptionInvalid)
+
+	type Item3 struct {
+		Col1 int               `csv:"qty,aliases=quantity|qty ordered"`
+		Col2 InlineColumn[int] `csv:"col2,inline,aliases=abc"`
+	}
+	structType3 := reflect.TypeOf(Item3{})
+
+	col31, _ := structType3.FieldByName("Col1")
+	tag31, err := parseTag(DefaultTagName, col31)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"quantity", "qty ordered"}, tag31.aliases)
+
+	type Item3b struct {
+		Col1 string `csv:"email,alias=email_address;e-mail"`
+		Col2 string `csv:"phone,aliases=tel,alias=phone_number"`
+	}
+	structType3b := reflect.TypeOf(Item3b{})
+
+	col3b1, _ := structType3b.FieldByName("Col1")
+	tag3b1, err := parseTag(DefaultTagName, col3b1)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"email_address", "e-mail"}, tag3b1.aliases)
+
+	col3b2, _ := structType3b.FieldByName("Col2")
+	tag3b2, err := parseTag(DefaultTagName, col3b2)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"tel", "phone_number"}, tag3b2.aliases)
+
+	type Item4 struct {
+		Col1 time.Time `csv:"col1,optional,required,format=2006-01-02"`
+	}
+	col41, _ := reflect.TypeOf(Item4{}).FieldByName("Col1")
+	tag41, err := parseTag(DefaultTagName, col41)
+	assert.Nil(t, err)
+	assert.True(t, tag41.optional && tag41.required && tag41.format == "2006-01-02")
+
+	type Item5 struct {
+		Col1 []string `csv:"col1,sep=;"`
+		Col2 struct{} `csv:"col2,inline,sep=;"`
+	}
+	col51, _ := reflect.TypeOf(Item5{}).FieldByName("Col1")
+	tag51, err := parseTag(DefaultTagName, col51)
+	assert.Nil(t, err)
+	assert.Equal(t, ";", tag51.sep)
+	col52, _ := reflect.TypeOf(Item5{}).FieldByName("Col2")
+	_, err = parseTag(DefaultTagName, col52)
+	assert.ErrorIs(t, err, ErrTagOptionInvalid)
+
+	type Item6 struct {
+		Rest  map[string]string `csv:",rest"`
+		Rest2 struct{}          `csv:",rest,inline"`
+	}
+	col61, _ := reflect.TypeOf(Item6{}).FieldByName("Rest")
+	tag61, err := parseTag(DefaultTagName, col61)
+	assert.Nil(t, err)
+	assert.True(t, tag61.rest)
+	col62, _ := reflect.TypeOf(Item6{}).FieldByName("Rest2")
+	_, err = parseTag(DefaultTagName, col62)
+	assert.ErrorIs(t, err, ErrTagOptionInvalid)
+
+	type embedded struct{}
+	type Item7 struct {
+		embedded `csv:",prefix=x_"`
+		Col2     int `csv:"col2,prefix=x_"`
+	}
+	col71, _ := reflect.TypeOf(Item7{}).FieldByName("embedded")
+	tag71, err := parseTag(DefaultTagName, col71)
+	assert.Nil(t, err)
+	assert.True(t, tag71.unnamed && tag71.prefix == "x_")
+	assert.True(t, isEmbeddedStructField(col71, tag71))
+	col72, _ := reflect.TypeOf(Item7{}).FieldByName("Col2")
+	_, err = parseTag(DefaultTagName, col72)
+	assert.ErrorIs(t, err, ErrTagOptionInvalid)
+
+	type Item8 struct {
+		Col1 int      `csv:"col1,base=16"`
+		Col2 int      `csv:"col2,base=0"`
+		Col3 int      `csv:"col3,base=1"`
+		Col4 int      `csv:"col4,base=37"`
+		Col5 struct{} `csv:"col5,inline,base=16"`
+	}
+	structType8 := reflect.TypeOf(Item8{})
+	col81, _ := structType8.FieldByName("Col1")
+	tag81, err := parseTag(DefaultTagName, col81)
+	assert.Nil(t, err)
+	assert.Equal(t, 16, tag81.base)
+	col82, _ := structType8.FieldByName("Col2")
+	tag82, err := parseTag(DefaultTagName, col82)
+	assert.Nil(t, err)
+	assert.Equal(t, intBaseAuto, tag82.base)
+	for _, name := range []string{"Col3", "Col4", "Col5"} {
+		col, _ := structType8.FieldByName(name)
+		_, err = parseTag(DefaultTagName, col)
+		assert.ErrorIs(t, err, ErrTagOptionInvalid)
+	}
+
+	type Item9 struct {
+		Col1 string            `csv:"col1,default=not set,omitempty"`
+		Col2 string            `csv:"col2,default="`
+		Col3 InlineColumn[int] `csv:"col3,inline,default=0"`
+	}
+	structType9 := reflect.TypeOf(Item9{})
+	col91, _ := structType9.FieldByName("Col1")
+	tag91, err := parseTag(DefaultTagName, col91)
+	assert.Nil(t, err)
+	assert.True(t, tag91.defValue == "not set" && tag91.omitEmpty)
+	col92, _ := structType9.FieldByName("Col2")
+	tag92, err := parseTag(DefaultTagName, col92)
+	assert.Nil(t, err)
+	assert.Equal(t, "", tag92.defValue)
+	col93, _ := structType9.FieldByName("Col3")
+	_, err = parseTag(DefaultTagName, col93)
+	assert.ErrorIs(t, err, ErrTagOptionInvalid)
+
+	col32, _ := structType3.FieldByName("Col2")
+	_, err = parseTag(DefaultTagName, col32)
+	assert.ErrorIs(t, err, ErrTagOptionInvalid)
+}
+
+func Test_parseFloatFormat(t *testing.T) {
+	fmtByte, precision, ok := parseFloatFormat(".2f")
+	assert.True(t, ok)
+	assert.Equal(t, byte('f'), fmtByte)
+	assert.Equal(t, 2, precision)
+
+	fmtByte, precision, ok = parseFloatFormat("e")
+	assert.True(t, ok)
+	assert.Equal(t, byte('e'), fmtByte)
+	assert.Equal(t, floatPrecisionInherit, precision)
+
+	for _, format := range []string{"", "x", "2f", ".f", ".-1f", "2006-01-02"} {
+		_, _, ok = parseFloatFormat(format)
+		assert.False(t, ok, format)
+	}
 }
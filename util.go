@@ -41,3 +41,13 @@ func processTemplate(templ string, params ParameterMap) (detail string, retErr e
 	}
 	return
 }
+
+// firstNonEmpty returns the first non-empty slice of the given ones
+func firstNonEmpty[T any](slices ...[]T) []T {
+	for _, s := range slices {
+		if len(s) > 0 {
+			return s
+		}
+	}
+	return nil
+}
@@ -2,10 +2,15 @@ package csvlib
 
 import (
 	"fmt"
+	"net/mail"
+	"net/url"
 	"reflect"
+	"regexp"
 	"strings"
 	"unicode/utf8"
 	"unsafe"
+
+	"github.com/tiendc/gofn"
 )
 
 // ValidatorLT validates a value to be less than the given value
@@ -78,6 +83,62 @@ func ValidatorRange[T LTComparable](min, max T) ValidatorFunc {
 	}
 }
 
+// ValidatorPositive validates a number to be greater than 0
+func ValidatorPositive[T NumberEx]() ValidatorFunc {
+	return func(v any) error {
+		v1, ok := v.(T)
+		if !ok {
+			return errValidationConversion(v, v1)
+		}
+		if v1 > 0 {
+			return nil
+		}
+		return ErrValidationPositive
+	}
+}
+
+// ValidatorNonNegative validates a number to be greater than or equal to 0
+func ValidatorNonNegative[T NumberEx]() ValidatorFunc {
+	return func(v any) error {
+		v1, ok := v.(T)
+		if !ok {
+			return errValidationConversion(v, v1)
+		}
+		if v1 >= 0 {
+			return nil
+		}
+		return ErrValidationNonNegative
+	}
+}
+
+// ValidatorNegative validates a number to be less than 0
+func ValidatorNegative[T NumberEx]() ValidatorFunc {
+	return func(v any) error {
+		v1, ok := v.(T)
+		if !ok {
+			return errValidationConversion(v, v1)
+		}
+		if v1 < 0 {
+			return nil
+		}
+		return ErrValidationNegative
+	}
+}
+
+// ValidatorNotZero validates a number to be different from 0
+func ValidatorNotZero[T NumberEx]() ValidatorFunc {
+	return func(v any) error {
+		v1, ok := v.(T)
+		if !ok {
+			return errValidationConversion(v, v1)
+		}
+		if v1 != 0 {
+			return nil
+		}
+		return ErrValidationNotZero
+	}
+}
+
 // ValidatorIN validates a value to be one of the specific values
 func ValidatorIN[T LTComparable](vals ...T) ValidatorFunc {
 	return func(v any) error {
@@ -94,6 +155,48 @@ func ValidatorIN[T LTComparable](vals ...T) ValidatorFunc {
 	}
 }
 
+// ValidatorINFold validates a string to be one of the specific values under Unicode case-folding
+func ValidatorINFold[T StringEx](vals ...T) ValidatorFunc {
+	return func(v any) error {
+		s, ok := v.(T)
+		if !ok {
+			return errValidationConversion(v, s)
+		}
+		str := *(*string)(unsafe.Pointer(&s))
+		for i := range vals {
+			if strings.EqualFold(str, *(*string)(unsafe.Pointer(&vals[i]))) {
+				return nil
+			}
+		}
+		return ErrValidationIN
+	}
+}
+
+// ValidatorINAny validates a value to match one of the specific entries. An entry can be a value
+// of the same type which is compared for equality, or a predicate `func(T) bool`.
+// Entries of other types never match.
+func ValidatorINAny[T LTComparable](vals ...any) ValidatorFunc {
+	return func(v any) error {
+		v1, ok := v.(T)
+		if !ok {
+			return errValidationConversion(v, v1)
+		}
+		for _, val := range vals {
+			switch val := val.(type) {
+			case T:
+				if v1 == val {
+					return nil
+				}
+			case func(T) bool:
+				if val(v1) {
+					return nil
+				}
+			}
+		}
+		return ErrValidationIN
+	}
+}
+
 // ValidatorStrLen validates a string to have length in the given range.
 // Pass argument -1 to skip the equivalent validation.
 func ValidatorStrLen[T StringEx](minLen, maxLen int, lenFuncs ...func(s string) int) ValidatorFunc {
@@ -142,6 +245,169 @@ func ValidatorStrSuffix[T StringEx](suffix string) ValidatorFunc {
 	}
 }
 
+// ValidatorStrRegex validates a string to match the given regex pattern.
+// This func panics if the pattern is invalid, use ValidatorStrRegexE to get the error instead.
+func ValidatorStrRegex[T StringEx](pattern string) ValidatorFunc {
+	return validatorStrRegex[T](regexp.MustCompile(pattern), true)
+}
+
+// ValidatorStrRegexE validates a string to match the given regex pattern.
+// This func returns an error if the pattern is invalid.
+func ValidatorStrRegexE[T StringEx](pattern string) (ValidatorFunc, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return validatorStrRegex[T](re, true), nil
+}
+
+// ValidatorStrNotRegex validates a string to not match the given regex pattern.
+// This func panics if the pattern is invalid.
+func ValidatorStrNotRegex[T StringEx](pattern string) ValidatorFunc {
+	return validatorStrRegex[T](regexp.MustCompile(pattern), false)
+}
+
+func validatorStrRegex[T StringEx](re *regexp.Regexp, shouldMatch bool) ValidatorFunc {
+	return func(v any) error {
+		s, ok := v.(T)
+		if !ok {
+			return errValidationConversion(v, s)
+		}
+		if re.MatchString(*(*string)(unsafe.Pointer(&s))) == shouldMatch {
+			return nil
+		}
+		if shouldMatch {
+			return ErrValidationStrRegex
+		}
+		return ErrValidationStrNotRegex
+	}
+}
+
+// ValidatorNotEmpty validates a string to be not empty or all-whitespace.
+// Pointers to strings are also accepted, the nil pointer is considered empty.
+func ValidatorNotEmpty[T StringEx]() ValidatorFunc {
+	return validatorStrEmpty[T](false)
+}
+
+// ValidatorEmpty validates a string to be empty or all-whitespace.
+// Pointers to strings are also accepted, the nil pointer is considered empty.
+func ValidatorEmpty[T StringEx]() ValidatorFunc {
+	return validatorStrEmpty[T](true)
+}
+
+func validatorStrEmpty[T StringEx](shouldBeEmpty bool) ValidatorFunc {
+	return func(v any) error {
+		var s T
+		switch vv := v.(type) {
+		case T:
+			s = vv
+		case *T:
+			if vv != nil {
+				s = *vv
+			}
+		default:
+			return errValidationConversion(v, s)
+		}
+		if (strings.TrimSpace(*(*string)(unsafe.Pointer(&s))) == "") == shouldBeEmpty {
+			return nil
+		}
+		if shouldBeEmpty {
+			return ErrValidationEmpty
+		}
+		return ErrValidationNotEmpty
+	}
+}
+
+// ValidatorEmail validates a string to be a well-formed email address such as `name@example.com`.
+// Addresses with display names (e.g. `Name <name@example.com>`) and surrounding spaces are rejected,
+// use a preprocessor to trim the cell text if needed.
+func ValidatorEmail[T StringEx]() ValidatorFunc {
+	return func(v any) error {
+		s, ok := v.(T)
+		if !ok {
+			return errValidationConversion(v, s)
+		}
+		str := *(*string)(unsafe.Pointer(&s))
+		addr, err := mail.ParseAddress(str)
+		if err != nil {
+			return ErrValidationEmail
+		}
+		if addr.Name != "" || addr.Address != str {
+			return ErrValidationEmail
+		}
+		return nil
+	}
+}
+
+// ValidatorURL validates a string to be an absolute URL having a host such as `https://example.com/path`.
+// If `allowedSchemes` are given, the URL scheme must be one of them (case-insensitive).
+func ValidatorURL[T StringEx](allowedSchemes ...string) ValidatorFunc {
+	return func(v any) error {
+		s, ok := v.(T)
+		if !ok {
+			return errValidationConversion(v, s)
+		}
+		u, err := url.ParseRequestURI(*(*string)(unsafe.Pointer(&s)))
+		if err != nil {
+			return ErrValidationURL
+		}
+		if u.Host == "" {
+			return ErrValidationURL
+		}
+		if len(allowedSchemes) > 0 &&
+			!gofn.ContainBy(allowedSchemes, func(scheme string) bool { return strings.EqualFold(scheme, u.Scheme) }) {
+			return ErrValidationURL
+		}
+		return nil
+	}
+}
+
+// ValidatorHTTPURL validates a string to be an absolute URL with scheme `http` or `https`
+func ValidatorHTTPURL[T StringEx]() ValidatorFunc {
+	return ValidatorURL[T]("http", "https")
+}
+
+// ValidatorInlineLen validates a dynamic inline column to have the number of columns in the given range.
+// Pass argument -1 to skip the equivalent validation. This validator must be set via
+// DecodeColumnConfig.InlineValidatorFuncs as it validates the whole inline column, not a cell.
+func ValidatorInlineLen[T any](minLen, maxLen int) ValidatorFunc {
+	return func(v any) error {
+		var values []T
+		switch v1 := v.(type) {
+		case InlineColumn[T]:
+			values = v1.Values
+		case *InlineColumn[T]:
+			if v1 != nil {
+				values = v1.Values
+			}
+		default:
+			return errValidationConversion(v, InlineColumn[T]{})
+		}
+		length := len(values)
+		if (minLen == -1 || minLen <= length) && (maxLen == -1 || length <= maxLen) {
+			return nil
+		}
+		return ErrValidationInlineLen
+	}
+}
+
+// TypedRowValidator creates a row validator from the given typed func, the func is called with the decoded
+// item of a row (e.g. `TypedRowValidator(func(s Student) error {...})`). See DecodeConfig.RowValidatorFuncs.
+func TypedRowValidator[T any](fn func(T) error) ValidatorFunc {
+	return func(v any) error {
+		switch v1 := v.(type) {
+		case T:
+			return fn(v1)
+		case *T:
+			if v1 != nil {
+				return fn(*v1)
+			}
+		}
+		var zero T
+		return errValidationConversion(v, zero)
+	}
+}
+
 func errValidationConversion[T any](v1 any, v2 T) error {
 	return fmt.Errorf("%w: (%v -> %v)", ErrValidationConversion, reflect.TypeOf(v1), reflect.TypeOf(v2))
 }
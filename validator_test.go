@@ -1,9 +1,11 @@
 package csvlib
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/tiendc/gofn"
 )
 
 func Test_ValidatorLT(t *testing.T) {
@@ -46,6 +48,43 @@ func Test_ValidatorRange(t *testing.T) {
 	assert.ErrorIs(t, ValidatorRange("a", "g")("0bc"), ErrValidation)
 }
 
+func Test_ValidatorPositive(t *testing.T) {
+	type Amount int64
+	assert.Nil(t, ValidatorPositive[int]()(1))
+	assert.Nil(t, ValidatorPositive[float64]()(0.001))
+	assert.Nil(t, ValidatorPositive[Amount]()(Amount(10)))
+	assert.ErrorIs(t, ValidatorPositive[int]()(0), ErrValidationPositive)
+	assert.ErrorIs(t, ValidatorPositive[uint8]()(uint8(0)), ErrValidationPositive)
+	assert.ErrorIs(t, ValidatorPositive[float32]()(float32(-0.5)), ErrValidation)
+	assert.ErrorIs(t, ValidatorPositive[int]()(int8(1)), ErrValidationConversion)
+}
+
+func Test_ValidatorNonNegative(t *testing.T) {
+	assert.Nil(t, ValidatorNonNegative[int]()(0))
+	assert.Nil(t, ValidatorNonNegative[uint]()(uint(0)))
+	assert.Nil(t, ValidatorNonNegative[float64]()(1.5))
+	assert.ErrorIs(t, ValidatorNonNegative[int16]()(int16(-1)), ErrValidationNonNegative)
+	assert.ErrorIs(t, ValidatorNonNegative[float64]()(-0.001), ErrValidation)
+	assert.ErrorIs(t, ValidatorNonNegative[int]()("0"), ErrValidationConversion)
+}
+
+func Test_ValidatorNegative(t *testing.T) {
+	assert.Nil(t, ValidatorNegative[int]()(-1))
+	assert.Nil(t, ValidatorNegative[float32]()(float32(-0.1)))
+	assert.ErrorIs(t, ValidatorNegative[int]()(0), ErrValidationNegative)
+	assert.ErrorIs(t, ValidatorNegative[uint32]()(uint32(1)), ErrValidationNegative)
+	assert.ErrorIs(t, ValidatorNegative[int64]()(-1), ErrValidationConversion)
+}
+
+func Test_ValidatorNotZero(t *testing.T) {
+	assert.Nil(t, ValidatorNotZero[int]()(-1))
+	assert.Nil(t, ValidatorNotZero[uint64]()(uint64(1)))
+	assert.Nil(t, ValidatorNotZero[float64]()(0.0001))
+	assert.ErrorIs(t, ValidatorNotZero[int]()(0), ErrValidationNotZero)
+	assert.ErrorIs(t, ValidatorNotZero[float64]()(0.0), ErrValidationNotZero)
+	assert.ErrorIs(t, ValidatorNotZero[float64]()(0), ErrValidationConversion)
+}
+
 func Test_ValidatorIN(t *testing.T) {
 	assert.Nil(t, ValidatorIN("a", "b", "c")("b"))
 	assert.Nil(t, ValidatorIN("a", "b", "")(""))
@@ -54,6 +93,32 @@ func Test_ValidatorIN(t *testing.T) {
 	assert.ErrorIs(t, ValidatorIN("a", "b", "")("d"), ErrValidation)
 }
 
+func Test_ValidatorINFold(t *testing.T) {
+	assert.Nil(t, ValidatorINFold("active", "inactive")("Active"))
+	assert.Nil(t, ValidatorINFold("active", "inactive")("INACTIVE"))
+	assert.Nil(t, ValidatorINFold("straße", "Σίσυφος")("STRAßE"))
+	assert.Nil(t, ValidatorINFold("Σίσυφος")("ΣΊΣΥΦΟΣ"))
+	assert.Nil(t, ValidatorINFold("k")("\u212A")) // Kelvin sign
+	assert.Nil(t, ValidatorINFold[StrType]("abc")(StrType("ABC")))
+	assert.ErrorIs(t, ValidatorINFold("strasse")("straße"), ErrValidationIN)
+	assert.ErrorIs(t, ValidatorINFold("abc")(StrType("abc")), ErrValidationConversion)
+	assert.ErrorIs(t, ValidatorINFold[string]()("abc"), ErrValidationIN)
+	assert.ErrorIs(t, ValidatorINFold[string]()(""), ErrValidationIN)
+	assert.ErrorIs(t, ValidatorINFold("a", "b")("c"), ErrValidation)
+}
+
+func Test_ValidatorINAny(t *testing.T) {
+	isNegative := func(v int) bool { return v < 0 }
+	assert.Nil(t, ValidatorINAny[int](1, 2, isNegative)(2))
+	assert.Nil(t, ValidatorINAny[int](1, 2, isNegative)(-5))
+	assert.Nil(t, ValidatorINAny[StrType](StrType("a"), "b")(StrType("a")))
+	assert.ErrorIs(t, ValidatorINAny[StrType](StrType("a"), "b")(StrType("b")), ErrValidationIN)
+	assert.ErrorIs(t, ValidatorINAny[int64](1, int64(2))(int64(1)), ErrValidationIN)
+	assert.ErrorIs(t, ValidatorINAny[int](1, []int{2}, isNegative)(3), ErrValidationIN)
+	assert.ErrorIs(t, ValidatorINAny[int]()(0), ErrValidationIN)
+	assert.ErrorIs(t, ValidatorINAny[int](1)("1"), ErrValidationConversion)
+}
+
 func Test_ValidatorStrLen(t *testing.T) {
 	lenFn := func(s string) int { return len(s) }
 	assert.Nil(t, ValidatorStrLen[string](0, 5)("abc"))
@@ -86,3 +151,147 @@ func Test_ValidatorStrSuffix(t *testing.T) {
 	assert.ErrorIs(t, ValidatorStrSuffix[string]("x")("abc"), ErrValidation)
 	assert.ErrorIs(t, ValidatorStrSuffix[StrType]("x")(StrType("abc123")), ErrValidationStrSuffix)
 }
+
+func Test_ValidatorStrRegex(t *testing.T) {
+	assert.Nil(t, ValidatorStrRegex[string](`^\d{3}-\d{4}$`)("123-4567"))
+	assert.Nil(t, ValidatorStrRegex[string](`^\p{Han}+$`)("東京"))
+	assert.Nil(t, ValidatorStrRegex[string](`^$`)(""))
+	assert.Nil(t, ValidatorStrRegex[StrType](`^SKU-`)(StrType("SKU-123")))
+	assert.ErrorIs(t, ValidatorStrRegex[string](`^SKU-`)(StrType("SKU-123")), ErrValidationConversion)
+	assert.ErrorIs(t, ValidatorStrRegex[string](`^\d{3}-\d{4}$`)("1234567"), ErrValidationStrRegex)
+	assert.ErrorIs(t, ValidatorStrRegex[string](`^\p{Han}+$`)("tokyo"), ErrValidation)
+	assert.ErrorIs(t, ValidatorStrRegex[string](`.+`)(""), ErrValidationStrRegex)
+	assert.Panics(t, func() { ValidatorStrRegex[string](`(abc`) })
+}
+
+func Test_ValidatorStrRegexE(t *testing.T) {
+	fn, err := ValidatorStrRegexE[string](`^[a-z]+$`)
+	assert.Nil(t, err)
+	assert.Nil(t, fn("abc"))
+	assert.ErrorIs(t, fn("abc1"), ErrValidationStrRegex)
+
+	fn, err = ValidatorStrRegexE[string](`[a-z`)
+	assert.NotNil(t, err)
+	assert.Nil(t, fn)
+}
+
+func Test_ValidatorStrNotRegex(t *testing.T) {
+	assert.Nil(t, ValidatorStrNotRegex[string](`\s`)("abc"))
+	assert.Nil(t, ValidatorStrNotRegex[string](`.+`)(""))
+	assert.Nil(t, ValidatorStrNotRegex[StrType](`^\p{Han}`)(StrType("tokyo")))
+	assert.ErrorIs(t, ValidatorStrNotRegex[string](`\s`)(StrType("a b")), ErrValidationConversion)
+	assert.ErrorIs(t, ValidatorStrNotRegex[string](`\s`)("a b"), ErrValidationStrNotRegex)
+	assert.ErrorIs(t, ValidatorStrNotRegex[string](`^\p{Han}`)("東京"), ErrValidation)
+	assert.Panics(t, func() { ValidatorStrNotRegex[string](`a)`) })
+}
+
+func Test_ValidatorNotEmpty(t *testing.T) {
+	assert.Nil(t, ValidatorNotEmpty[string]()("abc"))
+	assert.Nil(t, ValidatorNotEmpty[string]()(" a "))
+	assert.Nil(t, ValidatorNotEmpty[StrType]()(StrType("abc")))
+	assert.Nil(t, ValidatorNotEmpty[string]()(gofn.New("abc")))
+	assert.ErrorIs(t, ValidatorNotEmpty[string]()(StrType("abc")), ErrValidationConversion)
+	assert.ErrorIs(t, ValidatorNotEmpty[string]()(""), ErrValidationNotEmpty)
+	assert.ErrorIs(t, ValidatorNotEmpty[string]()(" \t\n"), ErrValidationNotEmpty)
+	assert.ErrorIs(t, ValidatorNotEmpty[StrType]()(StrType("  ")), ErrValidation)
+	assert.ErrorIs(t, ValidatorNotEmpty[string]()(gofn.New(" ")), ErrValidationNotEmpty)
+	assert.ErrorIs(t, ValidatorNotEmpty[string]()((*string)(nil)), ErrValidationNotEmpty)
+
+	type Item struct {
+		Col1 string  `csv:"col1"`
+		Col2 *string `csv:"col2"`
+	}
+	var v []Item
+	_, err := Unmarshal([]byte("col1,col2\n  ,abc\n"), &v, func(cfg *DecodeConfig) {
+		cfg.ConfigureColumn("col1", func(cfg *DecodeColumnConfig) {
+			cfg.PreprocessorFuncs = []ProcessorFunc{ProcessorTrim}
+			cfg.ValidatorFuncs = []ValidatorFunc{ValidatorNotEmpty[string]()}
+		})
+		cfg.ConfigureColumn("col2", func(cfg *DecodeColumnConfig) {
+			cfg.ValidatorFuncs = []ValidatorFunc{ValidatorNotEmpty[string]()}
+		})
+	})
+	assert.ErrorIs(t, err, ErrValidationNotEmpty)
+}
+
+func Test_ValidatorEmpty(t *testing.T) {
+	assert.Nil(t, ValidatorEmpty[string]()(""))
+	assert.Nil(t, ValidatorEmpty[string]()("  "))
+	assert.Nil(t, ValidatorEmpty[StrType]()(StrType("")))
+	assert.Nil(t, ValidatorEmpty[string]()((*string)(nil)))
+	assert.Nil(t, ValidatorEmpty[StrType]()(gofn.New(StrType(" "))))
+	assert.ErrorIs(t, ValidatorEmpty[string]()(1), ErrValidationConversion)
+	assert.ErrorIs(t, ValidatorEmpty[string]()("abc"), ErrValidationEmpty)
+	assert.ErrorIs(t, ValidatorEmpty[string]()(gofn.New("abc")), ErrValidation)
+}
+
+func Test_ValidatorEmail(t *testing.T) {
+	assert.Nil(t, ValidatorEmail[string]()("abc@example.com"))
+	assert.Nil(t, ValidatorEmail[string]()("first.last+tag@sub.example.co.jp"))
+	assert.Nil(t, ValidatorEmail[string]()("user@例え.jp"))
+	assert.Nil(t, ValidatorEmail[StrType]()(StrType("abc@example.com")))
+	assert.ErrorIs(t, ValidatorEmail[string]()(StrType("abc@example.com")), ErrValidationConversion)
+	assert.ErrorIs(t, ValidatorEmail[string]()("abc.example.com"), ErrValidationEmail)
+	assert.ErrorIs(t, ValidatorEmail[string]()(" abc@example.com "), ErrValidationEmail)
+	assert.ErrorIs(t, ValidatorEmail[string]()("Abc <abc@example.com>"), ErrValidationEmail)
+	assert.ErrorIs(t, ValidatorEmail[string]()("abc@"), ErrValidationEmail)
+	assert.ErrorIs(t, ValidatorEmail[string]()("@example.com"), ErrValidationEmail)
+	// Errors from parsing must not change the error message as it is used as the localization key
+	assert.Equal(t, ErrValidationEmail, ValidatorEmail[string]()("abc@"))
+	assert.Equal(t, ErrValidationEmail, ValidatorEmail[string]()("abc.example.com"))
+	assert.ErrorIs(t, ValidatorEmail[string]()(""), ErrValidation)
+}
+
+func Test_ValidatorURL(t *testing.T) {
+	assert.Nil(t, ValidatorURL[string]()("https://example.com"))
+	assert.Nil(t, ValidatorURL[string]()("ftp://example.com/files?name=a#top"))
+	assert.Nil(t, ValidatorURL[string]("http", "https")("HTTPS://example.com/path"))
+	assert.Nil(t, ValidatorURL[StrType]()(StrType("http://localhost:8080")))
+	assert.ErrorIs(t, ValidatorURL[string]()(StrType("https://example.com")), ErrValidationConversion)
+	assert.ErrorIs(t, ValidatorURL[string]()("example.com/path"), ErrValidationURL)
+	assert.ErrorIs(t, ValidatorURL[string]()("/path/to/file"), ErrValidationURL)
+	assert.ErrorIs(t, ValidatorURL[string]()("?a=1&b=2"), ErrValidationURL)
+	assert.ErrorIs(t, ValidatorURL[string]()("mailto:abc@example.com"), ErrValidationURL)
+	assert.ErrorIs(t, ValidatorURL[string]()(""), ErrValidation)
+	assert.ErrorIs(t, ValidatorURL[string]("https")("http://example.com"), ErrValidationURL)
+	// Errors from parsing must not change the error message as it is used as the localization key
+	assert.Equal(t, ErrValidationURL, ValidatorURL[string]()("example.com/path"))
+	assert.Equal(t, ErrValidationURL, ValidatorURL[string]()(""))
+}
+
+func Test_ValidatorHTTPURL(t *testing.T) {
+	assert.Nil(t, ValidatorHTTPURL[string]()("http://example.com"))
+	assert.Nil(t, ValidatorHTTPURL[string]()("https://example.com/path?q=1"))
+	assert.ErrorIs(t, ValidatorHTTPURL[string]()("ftp://example.com"), ErrValidationURL)
+	assert.ErrorIs(t, ValidatorHTTPURL[string]()("www.example.com"), ErrValidationURL)
+}
+
+func Test_ValidatorInlineLen(t *testing.T) {
+	assert.Nil(t, ValidatorInlineLen[int](1, 3)(InlineColumn[int]{Values: []int{1}}))
+	assert.Nil(t, ValidatorInlineLen[int](1, 3)(&InlineColumn[int]{Values: []int{1, 2, 3}}))
+	assert.Nil(t, ValidatorInlineLen[int](-1, 3)(InlineColumn[int]{}))
+	assert.Nil(t, ValidatorInlineLen[int](1, -1)(InlineColumn[int]{Values: []int{1, 2, 3, 4}}))
+	assert.ErrorIs(t, ValidatorInlineLen[int](1, 3)(InlineColumn[string]{}), ErrValidationConversion)
+	assert.ErrorIs(t, ValidatorInlineLen[int](1, 3)([]int{1}), ErrValidationConversion)
+	assert.ErrorIs(t, ValidatorInlineLen[int](1, 3)(InlineColumn[int]{}), ErrValidationInlineLen)
+	assert.ErrorIs(t, ValidatorInlineLen[int](1, 3)((*InlineColumn[int])(nil)), ErrValidationInlineLen)
+	assert.ErrorIs(t, ValidatorInlineLen[int](1, 3)(InlineColumn[int]{Values: []int{1, 2, 3, 4}}), ErrValidation)
+}
+
+func Test_RowValidator(t *testing.T) {
+	type Item struct {
+		Start, End int
+	}
+	errEndBeforeStart := errors.New("end before start")
+	validator := TypedRowValidator(func(item Item) error {
+		if item.End < item.Start {
+			return errEndBeforeStart
+		}
+		return nil
+	})
+	assert.Nil(t, validator(Item{Start: 1, End: 2}))
+	assert.Nil(t, validator(&Item{Start: 1, End: 2}))
+	assert.ErrorIs(t, validator(Item{Start: 2, End: 1}), errEndBeforeStart)
+	assert.ErrorIs(t, validator((*Item)(nil)), ErrValidationConversion)
+	assert.ErrorIs(t, validator(1), ErrValidationConversion)
+}